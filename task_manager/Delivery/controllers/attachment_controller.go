@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left in upload requests for the multipart framing around the file
+const multipartOverhead = 1 << 20
+
+// AttachmentController handles incoming HTTP requests related to task attachments
+type AttachmentController struct {
+	AttachmentUsecase domain.AttachmentUsecase
+	MaxUploadSize     int64 // Largest accepted file, in bytes; larger requests are cut off before they are read
+}
+
+// GetAttachments handles GET /tasks/:id/attachments
+// Lists the files attached to the task, oldest first
+func (ac *AttachmentController) GetAttachments(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	attachments, err := ac.AttachmentUsecase.FetchByTaskID(c, id, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch attachments"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": attachments})
+}
+
+// UploadAttachment handles POST /tasks/:id/attachments
+// Attaches the file sent in the "file" field of a multipart form to the task
+func (ac *AttachmentController) UploadAttachment(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ac.MaxUploadSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": domain.ErrAttachmentTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a file is required in the file field"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	upload := domain.AttachmentUpload{Filename: header.Filename, Size: header.Size, Content: file}
+	attachment, err := ac.AttachmentUsecase.Upload(c, id, upload, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidFilename), errors.Is(err, domain.ErrEmptyAttachment):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrAttachmentTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrAttachmentTypeNotAllowed):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload attachment"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"data": attachment})
+}
+
+// DownloadAttachment handles GET /tasks/:id/attachments/:attachmentID
+// Streams the content of the file as a download
+func (ac *AttachmentController) DownloadAttachment(c *gin.Context) {
+	id := c.Param("id")
+	attachmentID := c.Param("attachmentID")
+	if id == "" || attachmentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	attachment, content, err := ac.AttachmentUsecase.Open(c, id, attachmentID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidAttachmentID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrAttachmentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to download attachment"})
+		}
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment handles DELETE /tasks/:id/attachments/:attachmentID
+// Removes the file; only its uploader or an admin may do so
+func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
+	id := c.Param("id")
+	attachmentID := c.Param("attachmentID")
+	if id == "" || attachmentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := ac.AttachmentUsecase.Delete(c, id, attachmentID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidAttachmentID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment id"})
+		case errors.Is(err, domain.ErrNotAttachmentUploader):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrAttachmentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete attachment"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "attachment deleted successfully"})
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// BatchController handles incoming HTTP requests applying many task changes at once
+type BatchController struct {
+	TaskBatchUsecase domain.TaskBatchUsecase
+}
+
+// batchRequest is the body of POST /tasks/batch
+type batchRequest struct {
+	Atomic     bool                    `json:"atomic"`
+	Operations []batchOperationRequest `json:"operations" binding:"required"`
+}
+
+// batchOperationRequest is one operation of a batch request
+type batchOperationRequest struct {
+	Op      string          `json:"op"`
+	ID      string          `json:"id"`      // Task changed by update and delete operations
+	Version int             `json:"version"` // Version expected by update and delete operations, like If-Match
+	Task    json.RawMessage `json:"task"`    // Body of POST /tasks for create, merge patch of PATCH /tasks/:id for update
+}
+
+// toOperation validates the operation the same way the single task endpoints validate their requests
+func (r batchOperationRequest) toOperation(ownerID string) (domain.BatchOperation, error) {
+	op, err := domain.ParseBatchOp(r.Op)
+	if err != nil {
+		return domain.BatchOperation{}, err
+	}
+	if r.Version < 0 {
+		return domain.BatchOperation{}, errors.New("version can not be negative")
+	}
+	operation := domain.BatchOperation{Op: op, TaskID: r.ID, Version: r.Version}
+
+	if op != domain.BatchCreate && r.ID == "" {
+		return domain.BatchOperation{}, errors.New("id is required")
+	}
+	if op != domain.BatchDelete && len(r.Task) == 0 {
+		return domain.BatchOperation{}, errors.New("task is required")
+	}
+
+	switch op {
+	case domain.BatchCreate:
+		var body createTaskRequest
+		if err := json.Unmarshal(r.Task, &body); err != nil {
+			return domain.BatchOperation{}, err
+		}
+		if err := binding.Validator.ValidateStruct(&body); err != nil {
+			return domain.BatchOperation{}, err
+		}
+		operation.Task = body.toTask(ownerID)
+	case domain.BatchUpdate:
+		patch, err := decodeTaskMergePatch(r.Task)
+		if err != nil {
+			return domain.BatchOperation{}, fmt.Errorf("invalid merge patch: %w", err)
+		}
+		operation.Patch = patch
+	}
+	return operation, nil
+}
+
+// batchResultResponse is the outcome of one operation in the response of POST /tasks/batch
+type batchResultResponse struct {
+	Index     int            `json:"index"`
+	Op        domain.BatchOp `json:"op"`
+	ID        string         `json:"id,omitempty"`
+	Status    int            `json:"status"`
+	Task      *domain.Task   `json:"task,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	BlockedBy []gin.H        `json:"blocked_by,omitempty"`
+}
+
+// ExecuteBatch handles POST /tasks/batch
+// Applies a list of create, update and delete operations and reports the outcome of each;
+// atomic batches are rolled back entirely when one operation fails
+func (bc *BatchController) ExecuteBatch(c *gin.Context) {
+	var body batchRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid request body: %s", err.Error())})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	operations := make([]domain.BatchOperation, 0, len(body.Operations))
+	for i, request := range body.Operations {
+		operation, err := request.toOperation(actor.ID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid operation %d: %s", i, err.Error())})
+			return
+		}
+		operations = append(operations, operation)
+	}
+
+	results, err := bc.TaskBatchUsecase.Execute(c, operations, body.Atomic, actor)
+	switch {
+	case err == nil:
+		c.IndentedJSON(http.StatusOK, gin.H{"data": batchResponse(results)})
+	case errors.Is(err, domain.ErrBatchRolledBack):
+		data := batchResponse(results)
+		// the failed operation is the only one not reported as aborted
+		status := http.StatusUnprocessableEntity
+		for _, result := range data {
+			if result.Status >= http.StatusInternalServerError {
+				status = http.StatusInternalServerError
+			}
+		}
+		c.IndentedJSON(status, gin.H{"error": err.Error(), "data": data})
+	case errors.Is(err, domain.ErrEmptyBatch), errors.Is(err, domain.ErrBatchTooLarge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrTransactionsUnsupported):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "atomic batches need a database that supports transactions"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to apply batch"})
+	}
+}
+
+// batchResponse converts the results of a batch into their response form
+func batchResponse(results []domain.BatchResult) []batchResultResponse {
+	data := make([]batchResultResponse, 0, len(results))
+	for _, result := range results {
+		response := batchResultResponse{Index: result.Index, Op: result.Op, ID: result.TaskID, Task: result.Task}
+		response.Status, response.Error = batchResultStatus(result)
+
+		var blocked *domain.BlockedError
+		if errors.As(result.Err, &blocked) {
+			response.BlockedBy = make([]gin.H, 0, len(blocked.Blockers))
+			for _, blocker := range blocked.Blockers {
+				response.BlockedBy = append(response.BlockedBy, gin.H{
+					"id":     blocker.ID,
+					"title":  blocker.Title,
+					"status": blocker.Status,
+				})
+			}
+		}
+		data = append(data, response)
+	}
+	return data
+}
+
+// batchResultStatus maps the outcome of an operation to the status and error message
+// the matching single task endpoint would have responded with
+func batchResultStatus(result domain.BatchResult) (int, string) {
+	var blocked *domain.BlockedError
+	err := result.Err
+	switch {
+	case err == nil && result.Op == domain.BatchCreate:
+		return http.StatusCreated, ""
+	case err == nil:
+		return http.StatusOK, ""
+	case errors.Is(err, domain.ErrBatchAborted):
+		return http.StatusFailedDependency, err.Error()
+	case errors.Is(err, domain.ErrProjectReadOnly):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, domain.ErrVersionConflict):
+		return http.StatusPreconditionFailed, "task was modified by someone else"
+	case errors.Is(err, domain.ErrInvalidTaskID), errors.Is(err, domain.ErrInvalidProjectID),
+		errors.Is(err, domain.ErrProjectRequired), errors.Is(err, domain.ErrEmptyPatch),
+		errors.Is(err, domain.ErrEmptyTitle), errors.Is(err, domain.ErrInvalidDueDate),
+		errors.Is(err, domain.ErrInvalidBatchOp):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidStatus), errors.Is(err, domain.ErrInvalidPriority),
+		errors.Is(err, domain.ErrInvalidRecurrence), errors.Is(err, domain.ErrInvalidTag):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, domain.ErrInvalidStatusTransition) && result.Op == domain.BatchCreate:
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.As(err, &blocked):
+		return http.StatusConflict, "task is blocked by open tasks"
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrTaskNotFound), errors.Is(err, domain.ErrProjectNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		return http.StatusInternalServerError, "failed to apply operation"
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// calendarFeedSuffix ends the path of every feed, calendar apps look for it
+const calendarFeedSuffix = ".ics"
+
+// CalendarController handles incoming HTTP requests for the calendar feeds
+type CalendarController struct {
+	CalendarUsecase domain.CalendarUsecase
+}
+
+// IssueCalendarToken handles POST /me/calendar-token
+// Creates the secret feed token of the authenticated user; the previous token, if any, stops working
+func (cc *CalendarController) IssueCalendarToken(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	token, err := cc.CalendarUsecase.IssueToken(c, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue calendar token"})
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"data": gin.H{
+		"token": token,
+		"path":  "/calendar/" + token + calendarFeedSuffix,
+	}})
+}
+
+// RevokeCalendarToken handles DELETE /me/calendar-token
+// Removes the feed token of the authenticated user, after which their feed URL stops working
+func (cc *CalendarController) RevokeCalendarToken(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := cc.CalendarUsecase.RevokeToken(c, actor)
+	switch {
+	case err == nil:
+		c.IndentedJSON(http.StatusOK, gin.H{"message": "calendar token revoked successfully"})
+	case errors.Is(err, domain.ErrCalendarTokenNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke calendar token"})
+	}
+}
+
+// GetCalendarFeed handles GET /calendar/:token.ics
+// Public, the token in the path is the credential; responds with 304 when If-None-Match holds the current ETag
+func (cc *CalendarController) GetCalendarFeed(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), calendarFeedSuffix)
+	if !ok || token == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": domain.ErrCalendarTokenNotFound.Error()})
+		return
+	}
+
+	feed, err := cc.CalendarUsecase.FetchFeed(c, token, c.GetHeader("If-None-Match"))
+	if errors.Is(err, domain.ErrCalendarTokenNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render calendar feed"})
+		return
+	}
+
+	c.Header("ETag", feed.ETag)
+	// the URL is a secret, shared caches must not keep the feed
+	c.Header("Cache-Control", "private, no-cache")
+	if feed.NotModified {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed.Content)
+}
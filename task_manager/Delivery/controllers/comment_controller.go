@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// CommentController handles incoming HTTP requests related to task comments
+type CommentController struct {
+	CommentUsecase domain.CommentUsecase
+}
+
+// GetComments handles GET /tasks/:id/comments
+// Returns one page of the comments of the task, oldest first
+func (cc *CommentController) GetComments(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	var params struct {
+		Limit  int    `form:"limit"`
+		Cursor string `form:"cursor"`
+	}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	query := domain.CommentQuery{TaskID: id, Limit: params.Limit, Cursor: params.Cursor}
+	page, err := cc.CommentUsecase.FetchByTaskID(c, query, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		case errors.Is(err, domain.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch comments"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{
+		"data":        page.Comments,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// CreateComment handles POST /tasks/:id/comments
+// Posts a comment on the task as the authenticated user
+func (cc *CommentController) CreateComment(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		Body string `json:"body" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	comment, err := cc.CommentUsecase.Create(c, id, body.Body, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrEmptyComment), errors.Is(err, domain.ErrCommentTooLong):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create comment"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"data": comment})
+}
+
+// UpdateComment handles PATCH /tasks/:id/comments/:commentID
+// Replaces the body of the comment; only its author or an admin may do so
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+	id := c.Param("id")
+	commentID := c.Param("commentID")
+	if id == "" || commentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		Body string `json:"body" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	comment, err := cc.CommentUsecase.Update(c, id, commentID, body.Body, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidCommentID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		case errors.Is(err, domain.ErrEmptyComment), errors.Is(err, domain.ErrCommentTooLong):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotCommentAuthor):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update comment"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": comment})
+}
+
+// DeleteComment handles DELETE /tasks/:id/comments/:commentID
+// Removes the comment; only its author or an admin may do so
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	id := c.Param("id")
+	commentID := c.Param("commentID")
+	if id == "" || commentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := cc.CommentUsecase.Delete(c, id, commentID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidCommentID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		case errors.Is(err, domain.ErrNotCommentAuthor):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrCommentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete comment"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "comment deleted successfully"})
+}
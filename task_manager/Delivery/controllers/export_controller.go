@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// exportContentTypes maps the export formats to the media type of their files
+var exportContentTypes = map[domain.ExportFormat]string{
+	domain.ExportCSV:   "text/csv; charset=utf-8",
+	domain.ExportJSONL: "application/x-ndjson",
+	domain.ExportICS:   "text/calendar; charset=utf-8",
+}
+
+// ExportController handles incoming HTTP requests exporting task lists
+type ExportController struct {
+	TaskExportUsecase domain.TaskExportUsecase
+}
+
+// exportWriter writes an export to the response, setting its headers on the first write
+// Until then the handler can still respond with an error instead
+type exportWriter struct {
+	c       *gin.Context
+	format  domain.ExportFormat
+	started bool // Whether the headers were written
+}
+
+// start sets the status and headers of the export, once
+func (w *exportWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+	w.c.Header("Content-Type", exportContentTypes[w.format])
+	w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, w.format))
+	w.c.Status(http.StatusOK)
+}
+
+// Write sends the headers of the export before its first bytes
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.start()
+	return w.c.Writer.Write(p)
+}
+
+// ExportTasks handles GET /tasks/export
+// Streams every task visible to the authenticated user that matches the filters of GET /tasks, as CSV, JSON Lines or iCalendar
+func (ec *ExportController) ExportTasks(c *gin.Context) {
+	var params struct {
+		Format    string    `form:"format" binding:"required"`
+		Project   string    `form:"project"`
+		Status    string    `form:"status"`
+		DueAfter  time.Time `form:"due_after" time_format:"2006-01-02T15:04:05Z07:00"`
+		DueBefore time.Time `form:"due_before" time_format:"2006-01-02T15:04:05Z07:00"`
+		Title     string    `form:"title"`
+		Tags      []string  `form:"tag"`
+		AnyTags   []string  `form:"any_tag"`
+		Sort      string    `form:"sort"`
+		Order     string    `form:"order" binding:"omitempty,oneof=asc desc"`
+	}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid query parameters: %s", err.Error())})
+		return
+	}
+
+	format, err := domain.ParseExportFormat(params.Format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	query := domain.TaskQuery{
+		ProjectID: params.Project,
+		Status:    domain.TaskStatus(params.Status),
+		DueAfter:  params.DueAfter,
+		DueBefore: params.DueBefore,
+		Title:     params.Title,
+		Tags:      params.Tags,
+		AnyTags:   params.AnyTags,
+		SortBy:    params.Sort,
+		SortDesc:  params.Order == "desc",
+	}
+
+	writer := &exportWriter{c: c, format: format}
+	err = ec.TaskExportUsecase.Export(c, query, format, writer, actor)
+	switch {
+	case err == nil:
+		// an empty JSON Lines export writes nothing at all
+		writer.start()
+		return
+	case writer.started:
+		// the status was sent with the first tasks, the client gets a truncated file
+		log.Printf("export of tasks for user %s failed after it started: %v", actor.ID, err)
+		return
+	}
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export tasks"})
+	}
+}
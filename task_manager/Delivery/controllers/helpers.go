@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/gin-gonic/gin"
+)
+
+// actorFromContext extracts the user set by AuthenticationMiddleware
+// Returns false if the context does not carry an authenticated user
+func actorFromContext(c *gin.Context) (domain.Actor, bool) {
+	u, ok := c.Get("user")
+	if !ok {
+		return domain.Actor{}, false
+	}
+	user, ok := u.(infrastructure.AuthenticatedUser)
+	if !ok {
+		return domain.Actor{}, false
+	}
+	return domain.Actor{ID: user.ID, IsAdmin: user.IsAdmin}, true
+}
+
+// taskETag formats a task version as a strong entity tag
+func taskETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// versionFromIfMatch reads the task version expected by the If-Match header
+// A missing header or "*" yields 0, meaning any version; false is returned for
+// entity tags that can never match a task version
+func versionFromIfMatch(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, false
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize is the largest accepted import file, in bytes
+const maxImportSize = 5 << 20
+
+// importContentTypes maps the media types accepted by POST /tasks/import to their format
+var importContentTypes = map[string]domain.ImportFormat{
+	"text/csv":         domain.ImportCSV,
+	"application/json": domain.ImportJSON,
+}
+
+// ImportController handles incoming HTTP requests creating tasks from a file
+type ImportController struct {
+	TaskImportUsecase domain.TaskImportUsecase
+}
+
+// importRowResponse is the outcome of one row in the response of POST /tasks/import
+type importRowResponse struct {
+	Row   int    `json:"row"`
+	Title string `json:"title"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// importResponse is the response of POST /tasks/import
+type importResponse struct {
+	DryRun   bool                `json:"dry_run"`
+	Valid    int                 `json:"valid"`
+	Invalid  int                 `json:"invalid"`
+	Imported int                 `json:"imported"`
+	Rows     []importRowResponse `json:"rows"`
+}
+
+// ImportTasks handles POST /tasks/import
+// Reads a CSV or JSON task list from the body and validates every row;
+// nothing is written unless confirm=true, which creates the valid rows
+func (ic *ImportController) ImportTasks(c *gin.Context) {
+	var params struct {
+		Format  string `form:"format"` // Overrides the format given by the Content-Type header
+		Confirm bool   `form:"confirm"`
+	}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid query parameters: %s", err.Error())})
+		return
+	}
+
+	var format domain.ImportFormat
+	if params.Format != "" {
+		var err error
+		if format, err = domain.ParseImportFormat(params.Format); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		var ok bool
+		if format, ok = importContentTypes[mediaType]; !ok {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be text/csv or application/json"})
+			return
+		}
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	report, err := ic.TaskImportUsecase.Import(c, format, c.Request.Body, !params.Confirm, actor)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("import file must be at most %d bytes", maxImportSize)})
+		case errors.Is(err, domain.ErrImportTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidImport), errors.Is(err, domain.ErrEmptyImport), errors.Is(err, domain.ErrInvalidImportFormat):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import tasks"})
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if !report.DryRun {
+		status = http.StatusCreated
+		// a confirmed import without a single valid row did not create anything
+		if report.Imported == 0 {
+			status = http.StatusUnprocessableEntity
+		}
+	}
+	c.IndentedJSON(status, gin.H{"data": importReportResponse(report)})
+}
+
+// importReportResponse converts an import report into its response form
+func importReportResponse(report domain.ImportReport) importResponse {
+	response := importResponse{
+		DryRun:   report.DryRun,
+		Valid:    report.Valid,
+		Invalid:  report.Invalid,
+		Imported: report.Imported,
+		Rows:     make([]importRowResponse, 0, len(report.Rows)),
+	}
+	for _, row := range report.Rows {
+		rowResponse := importRowResponse{Row: row.Row, Title: row.Title, ID: row.TaskID}
+		if row.Err != nil {
+			rowResponse.Error = row.Err.Error()
+		}
+		response.Rows = append(response.Rows, rowResponse)
+	}
+	return response
+}
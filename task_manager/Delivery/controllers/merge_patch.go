@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// mergePatchContentType is the media type of RFC 7396 JSON merge patch documents
+const mergePatchContentType = "application/merge-patch+json"
+
+// decodeTaskMergePatch converts an RFC 7396 merge patch document into a domain.TaskPatch
+// A null member removes the field, which is only allowed for the optional description
+func decodeTaskMergePatch(data []byte) (domain.TaskPatch, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil || members == nil {
+		return domain.TaskPatch{}, errors.New("body must be a JSON object")
+	}
+
+	var patch domain.TaskPatch
+	for name, raw := range members {
+		isNull := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+
+		switch name {
+		case "title":
+			if isNull {
+				return domain.TaskPatch{}, errors.New("title can not be removed")
+			}
+			patch.Title = new(string)
+			if err := json.Unmarshal(raw, patch.Title); err != nil {
+				return domain.TaskPatch{}, errors.New("title must be a string")
+			}
+		case "description":
+			patch.Description = new(string)
+			if isNull {
+				continue
+			}
+			if err := json.Unmarshal(raw, patch.Description); err != nil {
+				return domain.TaskPatch{}, errors.New("description must be a string")
+			}
+		case "due_date":
+			if isNull {
+				return domain.TaskPatch{}, errors.New("due_date can not be removed")
+			}
+			patch.DueDate = new(time.Time)
+			if err := json.Unmarshal(raw, patch.DueDate); err != nil {
+				return domain.TaskPatch{}, errors.New("due_date must be an RFC 3339 timestamp")
+			}
+		case "status":
+			if isNull {
+				return domain.TaskPatch{}, errors.New("status can not be removed")
+			}
+			patch.Status = new(domain.TaskStatus)
+			if err := json.Unmarshal(raw, patch.Status); err != nil {
+				return domain.TaskPatch{}, errors.New("status must be a string")
+			}
+		case "priority":
+			if isNull {
+				return domain.TaskPatch{}, errors.New("priority can not be removed")
+			}
+			patch.Priority = new(domain.TaskPriority)
+			if err := json.Unmarshal(raw, patch.Priority); err != nil {
+				return domain.TaskPatch{}, errors.New("priority must be a string")
+			}
+		default:
+			return domain.TaskPatch{}, fmt.Errorf("unknown field %q", name)
+		}
+	}
+	return patch, nil
+}
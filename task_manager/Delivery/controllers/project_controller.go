@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectController handles incoming HTTP requests related to projects and their members
+type ProjectController struct {
+	ProjectUsecase domain.ProjectUsecase
+}
+
+// projectRequest is the body of the endpoints creating or updating a project
+type projectRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// GetProjects handles GET /projects
+// Returns the projects the authenticated user is a member of, every project for admins
+func (pc *ProjectController) GetProjects(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	projects, err := pc.ProjectUsecase.FetchAll(c, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch projects"})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": projects})
+}
+
+// CreateProject handles POST /projects
+// Creates a project owned by the authenticated user
+func (pc *ProjectController) CreateProject(c *gin.Context) {
+	var body projectRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	project := domain.Project{Name: body.Name, Description: body.Description}
+	if err := pc.ProjectUsecase.Create(c, &project, actor); err != nil {
+		projectError(c, err, "failed to create project")
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"data": project})
+}
+
+// GetProject handles GET /projects/:id
+// Returns a project the authenticated user is a member of
+func (pc *ProjectController) GetProject(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	project, err := pc.ProjectUsecase.FetchByID(c, id, actor)
+	if err != nil {
+		projectError(c, err, "failed to fetch project")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": project})
+}
+
+// UpdateProject handles PUT /projects/:id
+// Renames the project and replaces its description; only its owners or an admin may do so
+func (pc *ProjectController) UpdateProject(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body projectRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	project := domain.Project{ID: id, Name: body.Name, Description: body.Description}
+	if err := pc.ProjectUsecase.Update(c, &project, actor); err != nil {
+		projectError(c, err, "failed to update project")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": project})
+}
+
+// AddProjectMember handles POST /projects/:id/members
+// Gives the user in the request body a role in the project
+func (pc *ProjectController) AddProjectMember(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		UserID string `json:"user_id" binding:"required"`
+		Role   string `json:"role" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	member := domain.ProjectMember{UserID: body.UserID, Role: domain.ProjectRole(body.Role)}
+	if err := pc.ProjectUsecase.AddMember(c, id, member, actor); err != nil {
+		projectError(c, err, "failed to add member")
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"message": "member added successfully"})
+}
+
+// UpdateProjectMember handles PATCH /projects/:id/members/:userID
+// Changes the role of a member of the project
+func (pc *ProjectController) UpdateProjectMember(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Param("userID")
+	if id == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project id and user id are required"})
+		return
+	}
+
+	var body struct {
+		Role string `json:"role" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	if err := pc.ProjectUsecase.UpdateMemberRole(c, id, userID, domain.ProjectRole(body.Role), actor); err != nil {
+		projectError(c, err, "failed to update member")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "member updated successfully"})
+}
+
+// RemoveProjectMember handles DELETE /projects/:id/members/:userID
+// Removes a member from the project; members may remove themselves
+func (pc *ProjectController) RemoveProjectMember(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Param("userID")
+	if id == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project id and user id are required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	if err := pc.ProjectUsecase.RemoveMember(c, id, userID, actor); err != nil {
+		projectError(c, err, "failed to remove member")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "member removed successfully"})
+}
+
+// projectError writes the response for an error returned by a project usecase
+func projectError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidProjectID):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project id"})
+	case errors.Is(err, domain.ErrInvalidUserID):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+	case errors.Is(err, domain.ErrInvalidProjectName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidProjectRole):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrNotProjectOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, domain.ErrNotProjectMember):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrProjectMemberExists), errors.Is(err, domain.ErrLastProjectOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	domain "github.com/A2SVTask7/Domain"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 // TaskController handles incoming HTTP requests related to tasks
@@ -15,32 +16,64 @@ type TaskController struct {
 	TaskUsecase domain.TaskUsecase
 }
 
+// createTaskRequest is the body of the endpoints creating a task
+type createTaskRequest struct {
+	ProjectID   string    `json:"project_id"` // Ignored for subtasks, which belong to the project of their parent
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date" binding:"required"`
+	Status      string    `json:"status" binding:"required"`
+	Priority    string    `json:"priority"`
+	RRule       string    `json:"rrule"`
+	Tags        []string  `json:"tags"`
+}
+
+// toTask builds the task described by the request, owned by ownerID
+func (r createTaskRequest) toTask(ownerID string) domain.Task {
+	return domain.Task{
+		ProjectID:   r.ProjectID,
+		Title:       r.Title,
+		Description: r.Description,
+		DueDate:     r.DueDate,
+		Status:      domain.TaskStatus(r.Status),
+		Priority:    domain.TaskPriority(r.Priority),
+		OwnerID:     ownerID,
+		Recurrence:  r.RRule,
+		Tags:        r.Tags,
+	}
+}
+
 // CreateTask handles POST /tasks
 // Validates the request, checks for due date, creates a new task
 func (tc *TaskController) CreateTask(c *gin.Context) {
-	var body struct {
-		Title       string    `json:"title" binding:"required"`
-		Description string    `json:"description"`
-		DueDate     time.Time `json:"due_date" binding:"required"`
-		Status      string    `json:"status" binding:"required"`
-	}
+	var body createTaskRequest
 
 	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid request body: %s", err.Error())})
 		return
 	}
 
-	task := domain.Task{
-		Title:       body.Title,
-		Description: body.Description,
-		DueDate:     body.DueDate,
-		Status:      body.Status,
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
 	}
 
-	if err := tc.TaskUsecase.Create(c, &task); err != nil {
+	task := body.toTask(actor.ID)
+
+	if err := tc.TaskUsecase.Create(c, &task, actor); err != nil {
 		switch {
 		case errors.Is(err, domain.ErrInvalidDueDate):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "due date can't be in the past"})
+		case errors.Is(err, domain.ErrProjectRequired), errors.Is(err, domain.ErrInvalidProjectID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrProjectNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidStatus), errors.Is(err, domain.ErrInvalidRecurrence), errors.Is(err, domain.ErrInvalidTag),
+			errors.Is(err, domain.ErrInvalidPriority), errors.Is(err, domain.ErrInvalidStatusTransition):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create task"})
 		}
@@ -59,12 +92,28 @@ func (tc *TaskController) DeleteTask(c *gin.Context) {
 		return
 	}
 
+	version, ok := versionFromIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the task version"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
 	// Delete the task
-	err := tc.TaskUsecase.DeleteByTaskID(c, id)
+	err := tc.TaskUsecase.DeleteByTaskID(c, id, version, actor)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		case errors.Is(err, domain.ErrTaskNotFound):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "task was modified by someone else"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete task"})
 		}
@@ -75,14 +124,70 @@ func (tc *TaskController) DeleteTask(c *gin.Context) {
 }
 
 // GetAllTasks handles GET /tasks
-// Fetches and returns all tasks
+// Fetches a filtered, sorted page of the tasks visible to the authenticated user
 func (tc *TaskController) GetAllTasks(c *gin.Context) {
-	tasks, err := tc.TaskUsecase.FetchAllTasks(c)
+	var params struct {
+		Project   string    `form:"project"`
+		Status    string    `form:"status"`
+		DueAfter  time.Time `form:"due_after" time_format:"2006-01-02T15:04:05Z07:00"`
+		DueBefore time.Time `form:"due_before" time_format:"2006-01-02T15:04:05Z07:00"`
+		Title     string    `form:"title"`
+		Tags      []string  `form:"tag"`
+		AnyTags   []string  `form:"any_tag"`
+		Sort      string    `form:"sort"`
+		Order     string    `form:"order" binding:"omitempty,oneof=asc desc"`
+		Limit     int       `form:"limit"`
+		Offset    int       `form:"offset"`
+		Cursor    string    `form:"cursor"`
+	}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid query parameters: %s", err.Error())})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	query := domain.TaskQuery{
+		ProjectID: params.Project,
+		Status:    domain.TaskStatus(params.Status),
+		DueAfter:  params.DueAfter,
+		DueBefore: params.DueBefore,
+		Title:     params.Title,
+		Tags:      params.Tags,
+		AnyTags:   params.AnyTags,
+		SortBy:    params.Sort,
+		SortDesc:  params.Order == "desc",
+		Limit:     params.Limit,
+		Offset:    params.Offset,
+		Cursor:    params.Cursor,
+	}
+
+	page, err := tc.TaskUsecase.FetchAllTasks(c, query, actor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch all tasks"})
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		case errors.Is(err, domain.ErrProjectNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch all tasks"})
+		}
 		return
 	}
-	c.IndentedJSON(http.StatusOK, tasks)
+	c.IndentedJSON(http.StatusOK, gin.H{
+		"data":        page.Tasks,
+		"total":       page.Total,
+		"limit":       page.Limit,
+		"offset":      page.Offset,
+		"next_cursor": page.NextCursor,
+	})
 }
 
 // GetTaskByID handles GET /tasks/:id
@@ -94,7 +199,13 @@ func (tc *TaskController) GetTaskByID(c *gin.Context) {
 		return
 	}
 
-	task, err := tc.TaskUsecase.FetchByTaskID(c, id)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	task, err := tc.TaskUsecase.FetchByTaskID(c, id, actor)
 	if err != nil {
 		if errors.Is(err, domain.ErrTaskNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
@@ -103,9 +214,40 @@ func (tc *TaskController) GetTaskByID(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch task"})
 		return
 	}
+	c.Header("ETag", taskETag(task.Version))
 	c.IndentedJSON(http.StatusOK, task)
 }
 
+// GetTaskHistory handles GET /tasks/:id/history
+// Returns the recorded changes of a task, oldest first
+func (tc *TaskController) GetTaskHistory(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	history, err := tc.TaskUsecase.FetchHistory(c, id, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch task history"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": history})
+}
+
 // UpdateTask handles PUT /tasks/:id
 // Validates the ID and request body, updates the task fields
 func (tc *TaskController) UpdateTask(c *gin.Context) {
@@ -119,7 +261,8 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 		Title       string    `json:"title" binding:"required"`
 		Description string    `json:"description"`
 		DueDate     time.Time `json:"due_date" binding:"required"`
-		Status      string    `json:"status" binding:"required,oneof=pending completed missed"`
+		Status      string    `json:"status" binding:"required"`
+		Priority    string    `json:"priority"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -127,21 +270,49 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 		return
 	}
 
+	version, ok := versionFromIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the task version"})
+		return
+	}
+
 	task := domain.Task{
 		ID:          id,
 		Title:       body.Title,
 		Description: body.Description,
 		DueDate:     body.DueDate,
-		Status:      body.Status,
+		Status:      domain.TaskStatus(body.Status),
+		Priority:    domain.TaskPriority(body.Priority),
+		Version:     version,
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
 	}
 
-	err := tc.TaskUsecase.UpdateByTaskID(c, &task)
+	err := tc.TaskUsecase.UpdateByTaskID(c, &task, actor)
 	if err != nil {
+		var blocked *domain.BlockedError
 		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVersionConflict) && version > 0:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "task was modified by someone else"})
+		case errors.Is(err, domain.ErrVersionConflict):
+			// the task changed between the status check and the write
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
 		case errors.Is(err, domain.ErrInvalidTaskID):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
 		case errors.Is(err, domain.ErrInvalidDueDate):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "due date can not be in the past"})
+		case errors.Is(err, domain.ErrInvalidStatus), errors.Is(err, domain.ErrInvalidPriority):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.As(err, &blocked):
+			blockedResponse(c, blocked)
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		case errors.Is(err, domain.ErrTaskNotFound):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "task not found"})
 		case errors.Is(err, domain.ErrNoChangesMade):
@@ -154,5 +325,835 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 		}
 		return
 	}
+	if task.Version > 0 {
+		c.Header("ETag", taskETag(task.Version))
+	}
+	c.IndentedJSON(http.StatusOK, task)
+}
+
+// PatchTask handles PATCH /tasks/:id
+// Applies an RFC 7396 JSON merge patch, only the fields present in the body are changed
+func (tc *TaskController) PatchTask(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	// plain JSON is accepted as well for clients that cannot set the media type
+	if ct := c.ContentType(); ct != mergePatchContentType && ct != binding.MIMEJSON {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "content type must be " + mergePatchContentType})
+		return
+	}
+
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	patch, err := decodeTaskMergePatch(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid merge patch: %s", err.Error())})
+		return
+	}
+
+	version, ok := versionFromIfMatch(c)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the task version"})
+		return
+	}
+	patch.Version = version
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	task, err := tc.TaskUsecase.PatchByTaskID(c, id, patch, actor)
+	if err != nil {
+		var blocked *domain.BlockedError
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVersionConflict) && version > 0:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "task was modified by someone else"})
+		case errors.Is(err, domain.ErrVersionConflict):
+			// the task changed between the status check and the write
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrEmptyPatch),
+			errors.Is(err, domain.ErrEmptyTitle):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidStatus), errors.Is(err, domain.ErrInvalidPriority):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.As(err, &blocked):
+			blockedResponse(c, blocked)
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidDueDate):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "due date can not be in the past"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrNoChangesMade):
+			c.JSON(http.StatusOK, gin.H{
+				"message": "no changes were made",
+				"data":    task,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update task"})
+		}
+		return
+	}
+	c.Header("ETag", taskETag(task.Version))
+	c.IndentedJSON(http.StatusOK, task)
+}
+
+// AssignUser handles POST /tasks/:id/assignees
+// Adds the user given in the request body to the task's assignees
+func (tc *TaskController) AssignUser(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		UserID string `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.AssignUser(c, id, body.UserID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidUserID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case errors.Is(err, domain.ErrNotProjectMember):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrUserAlreadyAssigned):
+			c.JSON(http.StatusConflict, gin.H{"error": "user is already assigned to the task"})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to assign user"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "user assigned successfully"})
+}
+
+// UnassignUser handles DELETE /tasks/:id/assignees/:userID
+// Removes the user from the task's assignees
+func (tc *TaskController) UnassignUser(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Param("userID")
+	if id == "" || userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task id and user id are required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.UnassignUser(c, id, userID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrUserNotAssigned):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user is not assigned to the task"})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unassign user"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "user unassigned successfully"})
+}
+
+// GetMyTasks handles GET /me/tasks
+// Returns the tasks assigned to the authenticated user
+func (tc *TaskController) GetMyTasks(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	tasks, err := tc.TaskUsecase.FetchAssignedTasks(c, actor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch assigned tasks"})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, tasks)
+}
+
+// SearchTasks handles GET /tasks/search
+// Runs a full-text search over the tasks visible to the authenticated user
+func (tc *TaskController) SearchTasks(c *gin.Context) {
+	var params struct {
+		Q     string `form:"q" binding:"required"`
+		Limit int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "search text q is required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	results, err := tc.TaskUsecase.Search(c, domain.TaskSearchQuery{Text: params.Q, Limit: params.Limit}, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search tasks"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": results})
+}
+
+// GetTrash handles GET /trash
+// Returns the deleted tasks that can still be restored
+func (tc *TaskController) GetTrash(c *gin.Context) {
+	tasks, err := tc.TaskUsecase.FetchTrash(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch trash"})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": tasks})
+}
+
+// RestoreTask handles POST /trash/:id/restore
+// Moves a deleted task back out of the trash
+func (tc *TaskController) RestoreTask(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.RestoreByTaskID(c, id, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found in trash"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore task"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "task restored successfully"})
+}
+
+// PurgeTrash handles DELETE /trash
+// Permanently removes the tasks that have outlived the trash retention period
+func (tc *TaskController) PurgeTrash(c *gin.Context) {
+	purged, err := tc.TaskUsecase.PurgeTrash(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to purge trash"})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "trash purged successfully", "purged": purged})
+}
+
+// SetRecurrence handles PUT /tasks/:id/recurrence
+// Makes the task repeat on the RRULE given in the request body, or edits the rule of its series
+func (tc *TaskController) SetRecurrence(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		RRule string `json:"rrule" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	task, err := tc.TaskUsecase.SetRecurrence(c, id, body.RRule, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidRecurrence):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set recurrence"})
+		}
+		return
+	}
+
+	c.Header("ETag", taskETag(task.Version))
 	c.IndentedJSON(http.StatusOK, task)
 }
+
+// StopRecurrence handles DELETE /tasks/:id/recurrence
+// Stops the series of the task; existing occurrences are kept
+func (tc *TaskController) StopRecurrence(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.StopRecurrence(c, id, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrNotRecurring):
+			c.JSON(http.StatusConflict, gin.H{"error": "task does not recur"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to stop recurrence"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "recurrence stopped successfully"})
+}
+
+// GetSubtasks handles GET /tasks/:id/subtasks
+// Returns the subtasks of a task in their order
+func (tc *TaskController) GetSubtasks(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	subtasks, err := tc.TaskUsecase.FetchSubtasks(c, id, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch subtasks"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": subtasks})
+}
+
+// CreateSubtask handles POST /tasks/:id/subtasks
+// Creates a new task under the given parent, after its existing subtasks
+func (tc *TaskController) CreateSubtask(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body createTaskRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid request body: %s", err.Error())})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	task := body.toTask(actor.ID)
+	if err := tc.TaskUsecase.CreateSubtask(c, id, &task, actor); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "parent task not found"})
+		case errors.Is(err, domain.ErrInvalidDueDate):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "due date can't be in the past"})
+		case errors.Is(err, domain.ErrInvalidStatus), errors.Is(err, domain.ErrInvalidRecurrence), errors.Is(err, domain.ErrInvalidTag),
+			errors.Is(err, domain.ErrInvalidPriority), errors.Is(err, domain.ErrInvalidStatusTransition):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create subtask"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, task)
+}
+
+// ReorderSubtasks handles PUT /tasks/:id/subtasks/order
+// Orders the subtasks of a task as listed in the request body
+func (tc *TaskController) ReorderSubtasks(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		IDs []string `json:"ids" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	subtasks, err := tc.TaskUsecase.ReorderSubtasks(c, id, body.IDs, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidOrder):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "subtasks were modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reorder subtasks"})
+		}
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": subtasks})
+}
+
+// AddChecklistItem handles POST /tasks/:id/checklist
+// Appends an item to the checklist of a task
+func (tc *TaskController) AddChecklistItem(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		Text string `json:"text" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	item, err := tc.TaskUsecase.AddChecklistItem(c, id, body.Text, actor)
+	if err != nil {
+		checklistError(c, err, "failed to add checklist item")
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, item)
+}
+
+// UpdateChecklistItem handles PATCH /tasks/:id/checklist/:itemID
+// Changes the text of a checklist item or toggles its completion state
+func (tc *TaskController) UpdateChecklistItem(c *gin.Context) {
+	id := c.Param("id")
+	itemID := c.Param("itemID")
+	if id == "" || itemID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task id and item id are required"})
+		return
+	}
+
+	var body struct {
+		Text *string `json:"text"`
+		Done *bool   `json:"done"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	patch := domain.ChecklistItemPatch{Text: body.Text, Done: body.Done}
+	item, err := tc.TaskUsecase.UpdateChecklistItem(c, id, itemID, patch, actor)
+	if err != nil {
+		checklistError(c, err, "failed to update checklist item")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, item)
+}
+
+// RemoveChecklistItem handles DELETE /tasks/:id/checklist/:itemID
+// Removes an item from the checklist of a task
+func (tc *TaskController) RemoveChecklistItem(c *gin.Context) {
+	id := c.Param("id")
+	itemID := c.Param("itemID")
+	if id == "" || itemID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task id and item id are required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	if err := tc.TaskUsecase.RemoveChecklistItem(c, id, itemID, actor); err != nil {
+		checklistError(c, err, "failed to remove checklist item")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "checklist item removed successfully"})
+}
+
+// ReorderChecklist handles PUT /tasks/:id/checklist/order
+// Orders the checklist of a task as listed in the request body
+func (tc *TaskController) ReorderChecklist(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		IDs []string `json:"ids" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	checklist, err := tc.TaskUsecase.ReorderChecklist(c, id, body.IDs, actor)
+	if err != nil {
+		checklistError(c, err, "failed to reorder checklist")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": checklist})
+}
+
+// checklistError writes the response for an error returned by a checklist usecase
+func checklistError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrProjectReadOnly):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidTaskID):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+	case errors.Is(err, domain.ErrEmptyChecklistItem), errors.Is(err, domain.ErrEmptyPatch), errors.Is(err, domain.ErrInvalidOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, domain.ErrChecklistItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "checklist item not found"})
+	case errors.Is(err, domain.ErrVersionConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// AddDependency handles POST /tasks/:id/dependencies
+// Marks the task as blocked by the task given in the request body
+func (tc *TaskController) AddDependency(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		BlockerID string `json:"blocker_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.AddDependency(c, id, body.BlockerID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrDependencyCycle), errors.Is(err, domain.ErrProjectMismatch):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrDependencyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add dependency"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, gin.H{"message": "dependency added successfully"})
+}
+
+// RemoveDependency handles DELETE /tasks/:id/dependencies/:blockerID
+// Lifts the block of the task by the given task
+func (tc *TaskController) RemoveDependency(c *gin.Context) {
+	id := c.Param("id")
+	blockerID := c.Param("blockerID")
+	if id == "" || blockerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task id and blocker id are required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.RemoveDependency(c, id, blockerID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrDependencyNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove dependency"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "dependency removed successfully"})
+}
+
+// blockedResponse writes the conflict caused by completing a task with open blockers
+// The blockers are listed so the client can tell what to finish first
+func blockedResponse(c *gin.Context, blocked *domain.BlockedError) {
+	blockers := make([]gin.H, 0, len(blocked.Blockers))
+	for _, blocker := range blocked.Blockers {
+		blockers = append(blockers, gin.H{
+			"id":     blocker.ID,
+			"title":  blocker.Title,
+			"status": blocker.Status,
+		})
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"error":      "task is blocked by open tasks",
+		"blocked_by": blockers,
+	})
+}
+
+// GetTags handles GET /tags
+// Lists the tags of the tasks visible to the authenticated user with their usage counts
+func (tc *TaskController) GetTags(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	counts, err := tc.TaskUsecase.FetchTags(c, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch tags"})
+		return
+	}
+
+	tags := make([]gin.H, 0, len(counts))
+	for _, count := range counts {
+		tags = append(tags, gin.H{"tag": count.Tag, "count": count.Count})
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": tags})
+}
+
+// AddTag handles POST /tasks/:id/tags
+// Labels the task with the tag given in the request body
+func (tc *TaskController) AddTag(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id can not be empty"})
+		return
+	}
+
+	var body struct {
+		Tag string `json:"tag" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.AddTag(c, id, body.Tag, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidTag):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrTagExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add tag"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, gin.H{"message": "tag added successfully"})
+}
+
+// RemoveTag handles DELETE /tasks/:id/tags/:tag
+// Removes the tag from the task
+func (tc *TaskController) RemoveTag(c *gin.Context) {
+	id := c.Param("id")
+	tag := c.Param("tag")
+	if id == "" || tag == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task id and tag are required"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	err := tc.TaskUsecase.RemoveTag(c, id, tag, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProjectReadOnly):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidTaskID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		case errors.Is(err, domain.ErrInvalidTag):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, domain.ErrTagNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "task was modified concurrently, try again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove tag"})
+		}
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "tag removed successfully"})
+}
@@ -38,14 +38,34 @@ func (uc *UserController) GetUserByID(c *gin.Context) {
 }
 
 // GetAllUsers handles GET /users
-// Returns all registered users
+// Returns a page of registered users, continuing from the cursor if one is given
 func (uc *UserController) GetAllUsers(c *gin.Context) {
-	tasks, err := uc.UserUsecase.FetchAllUsers(c)
+	var params struct {
+		Limit  int    `form:"limit"`
+		Cursor string `form:"cursor"`
+	}
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	page, err := uc.UserUsecase.FetchAllUsers(c, domain.UserQuery{Limit: params.Limit, Cursor: params.Cursor})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch all users"})
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuery):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		case errors.Is(err, domain.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch all users"})
+		}
 		return
 	}
-	c.IndentedJSON(http.StatusOK, tasks)
+	c.IndentedJSON(http.StatusOK, gin.H{
+		"data":        page.Users,
+		"next_cursor": page.NextCursor,
+	})
 }
 
 // Login handles POST /auth/login
@@ -109,6 +129,7 @@ func (uc *UserController) Register(c *gin.Context) {
 	var body struct {
 		Username string `json:"username" binding:"required"`
 		Password string `json:"password" binding:"required"`
+		Email    string `json:"email" binding:"omitempty,email"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -118,6 +139,7 @@ func (uc *UserController) Register(c *gin.Context) {
 
 	user := domain.User{
 		Username: body.Username,
+		Email:    body.Email,
 	}
 
 	err := uc.UserUsecase.Create(c, &user)
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController handles incoming HTTP requests managing webhooks and their deliveries
+type WebhookController struct {
+	WebhookUsecase domain.WebhookUsecase
+}
+
+// webhookRequest is the body of the endpoints creating or updating a webhook
+type webhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+	Secret string   `json:"secret"`
+	Active *bool    `json:"active"` // Defaults to true
+}
+
+// toDomain returns the webhook described by the request
+func (r webhookRequest) toDomain() domain.Webhook {
+	return domain.Webhook{
+		URL:    r.URL,
+		Events: r.Events,
+		Secret: r.Secret,
+		Active: r.Active == nil || *r.Active,
+	}
+}
+
+// webhookError maps the errors of the webhook usecase to responses
+func webhookError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhookURL), errors.Is(err, domain.ErrInvalidWebhookEvent), errors.Is(err, domain.ErrInvalidQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrWebhookNotFound), errors.Is(err, domain.ErrInvalidWebhookID):
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound), errors.Is(err, domain.ErrInvalidWebhookDeliveryID):
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook delivery not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// GetWebhooks handles GET /webhooks
+// Returns every webhook, without their secrets
+func (wc *WebhookController) GetWebhooks(c *gin.Context) {
+	webhooks, err := wc.WebhookUsecase.FetchAll(c)
+	if err != nil {
+		webhookError(c, err, "failed to fetch webhooks")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": webhooks})
+}
+
+// CreateWebhook handles POST /webhooks
+// The response is the only one holding the secret, generated when the request gives none
+func (wc *WebhookController) CreateWebhook(c *gin.Context) {
+	var body webhookRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "missing user in context"})
+		return
+	}
+
+	webhook := body.toDomain()
+	if err := wc.WebhookUsecase.Create(c, &webhook, actor); err != nil {
+		webhookError(c, err, "failed to create webhook")
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, gin.H{"data": webhook})
+}
+
+// GetWebhook handles GET /webhooks/:id
+func (wc *WebhookController) GetWebhook(c *gin.Context) {
+	webhook, err := wc.WebhookUsecase.FetchByID(c, c.Param("id"))
+	if err != nil {
+		webhookError(c, err, "failed to fetch webhook")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": webhook})
+}
+
+// UpdateWebhook handles PUT /webhooks/:id
+// Replaces the URL, events and state of the webhook; its secret only changes when the request gives one
+func (wc *WebhookController) UpdateWebhook(c *gin.Context) {
+	var body webhookRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	webhook := body.toDomain()
+	webhook.ID = c.Param("id")
+	if err := wc.WebhookUsecase.Update(c, &webhook); err != nil {
+		webhookError(c, err, "failed to update webhook")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": webhook})
+}
+
+// DeleteWebhook handles DELETE /webhooks/:id
+func (wc *WebhookController) DeleteWebhook(c *gin.Context) {
+	if err := wc.WebhookUsecase.Delete(c, c.Param("id")); err != nil {
+		webhookError(c, err, "failed to delete webhook")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"message": "webhook deleted successfully"})
+}
+
+// GetWebhookDeliveries handles GET /webhooks/:id/deliveries
+// Returns the latest deliveries of the webhook with the log of their attempts, newest first
+func (wc *WebhookController) GetWebhookDeliveries(c *gin.Context) {
+	var params struct {
+		Limit int `form:"limit"`
+	}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	deliveries, err := wc.WebhookUsecase.FetchDeliveries(c, c.Param("id"), params.Limit)
+	if err != nil {
+		webhookError(c, err, "failed to fetch webhook deliveries")
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"data": deliveries})
+}
+
+// RedeliverWebhook handles POST /webhooks/:id/deliveries/:deliveryID/redeliver
+// Queues a new delivery of the same payload, sent on the next sweep of the dispatcher
+func (wc *WebhookController) RedeliverWebhook(c *gin.Context) {
+	delivery, err := wc.WebhookUsecase.Redeliver(c, c.Param("id"), c.Param("deliveryID"))
+	if err != nil {
+		webhookError(c, err, "failed to redeliver webhook")
+		return
+	}
+	c.IndentedJSON(http.StatusAccepted, gin.H{"data": delivery})
+}
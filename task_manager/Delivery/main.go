@@ -2,10 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/A2SVTask7/Delivery/routers"
+	domain "github.com/A2SVTask7/Domain"
 	infrastructure "github.com/A2SVTask7/Infrastructure"
+	repositories "github.com/A2SVTask7/Repositories"
+	usecases "github.com/A2SVTask7/Usecases"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,11 +30,164 @@ func main() {
 		_ = db.Client().Disconnect(context.Background()) // Disconnect Mongo client on program exit
 	}()
 
-	router := gin.Default()                            // Create a default Gin router with Logger and Recovery middleware
-	routers.SetUp(config.Timeout, *db, router, config) // Setup all routes with middleware and handlers
+	// Create the indexes the repositories rely on (text search, ...)
+	if err := repositories.EnsureTaskIndexes(context.TODO(), *db, config.CollectionTask); err != nil {
+		log.Fatal("Failed to create task indexes: ", err.Error())
+	}
+	if err := repositories.EnsureReminderIndexes(context.TODO(), *db, config.CollectionReminder); err != nil {
+		log.Fatal("Failed to create reminder indexes: ", err.Error())
+	}
+	if err := repositories.EnsureCommentIndexes(context.TODO(), *db, config.CollectionComment); err != nil {
+		log.Fatal("Failed to create comment indexes: ", err.Error())
+	}
+	if err := repositories.EnsureAttachmentIndexes(context.TODO(), *db, config.CollectionAttachment); err != nil {
+		log.Fatal("Failed to create attachment indexes: ", err.Error())
+	}
+	if err := repositories.EnsureProjectIndexes(context.TODO(), *db, config.CollectionProject); err != nil {
+		log.Fatal("Failed to create project indexes: ", err.Error())
+	}
+	if err := repositories.EnsureCalendarTokenIndexes(context.TODO(), *db, config.CollectionCalendar); err != nil {
+		log.Fatal("Failed to create calendar token indexes: ", err.Error())
+	}
+	if err := repositories.EnsureWebhookDeliveryIndexes(context.TODO(), *db, config.CollectionDelivery); err != nil {
+		log.Fatal("Failed to create webhook delivery indexes: ", err.Error())
+	}
+
+	// Tasks stored before versions existed start at version 1
+	migrated, err := repositories.MigrateTaskVersions(context.TODO(), *db, config.CollectionTask)
+	if err != nil {
+		log.Fatal("Failed to migrate task versions: ", err.Error())
+	}
+	if migrated > 0 {
+		log.Printf("Started %d tasks without a version at version 1", migrated)
+	}
+
+	// Every task belongs to a project; the ones stored before projects existed go to a default project of their owner
+	moved, err := repositories.MoveOrphanTasks(context.TODO(), *db, config.CollectionTask, config.CollectionProject)
+	if err != nil {
+		log.Fatal("Failed to move tasks without a project: ", err.Error())
+	}
+	if moved > 0 {
+		log.Printf("Moved %d tasks without a project to the default projects of their owners", moved)
+	}
+
+	blobs, err := newBlobStore(config)
+	if err != nil {
+		log.Fatal("Failed to set up the blob store: ", err.Error())
+	}
+
+	// Cancelled on SIGINT/SIGTERM, which stops the background jobs and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	taskRepo := repositories.NewTaskRepository(*db, config.CollectionTask)
+	userRepo := repositories.NewUserRepository(*db, config.CollectionUser)
+	projectRepo := repositories.NewProjectRepository(*db, config.CollectionProject)
+	webhookRepo := repositories.NewWebhookRepository(*db, config.CollectionWebhook)
+	deliveryRepo := repositories.NewWebhookDeliveryRepository(*db, config.CollectionDelivery)
+	taskUsecase := usecases.NewTaskUsecase(
+		taskRepo,
+		userRepo,
+		repositories.NewTaskHistoryRepository(*db, config.CollectionHistory),
+		projectRepo,
+		usecases.NewTaskEventPublisher(webhookRepo, deliveryRepo),
+		usecases.NewAttachmentUsecase(
+			repositories.NewAttachmentRepository(*db, config.CollectionAttachment),
+			taskRepo,
+			projectRepo,
+			blobs,
+			config.AttachmentMaxSize,
+			config.AttachmentTypes,
+			config.Timeout,
+		),
+		repositories.NewCommentRepository(*db, config.CollectionComment),
+		infrastructure.NewCursorService(config.CursorSecret),
+		config.TrashRetention,
+		config.Timeout,
+	)
+	clock := infrastructure.NewSystemClock()
+
+	// Mark overdue tasks as missed, generating the next occurrence of recurring ones, send due-date reminders
+	// and deliver webhooks in the background
+	var jobs sync.WaitGroup
+	scheduler := infrastructure.NewOverdueScheduler(taskUsecase, clock, config.OverdueInterval, config.Timeout)
+	reminders := infrastructure.NewReminderJob(
+		taskRepo,
+		userRepo,
+		repositories.NewReminderRepository(*db, config.CollectionReminder),
+		newNotifier(config),
+		clock,
+		config.ReminderLead,
+		config.ReminderInterval,
+		config.Timeout,
+	)
+	webhooks := infrastructure.NewWebhookDispatcher(
+		webhookRepo,
+		deliveryRepo,
+		infrastructure.NewWebhookSender(nil),
+		clock,
+		config.WebhookMaxAttempts,
+		config.WebhookBackoff,
+		config.WebhookInterval,
+		config.Timeout,
+	)
+	for _, run := range []func(context.Context){scheduler.Run, reminders.Run, webhooks.Run} {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			run(ctx)
+		}()
+	}
+
+	router := gin.New()                                                         // Create a Gin router without middleware
+	router.Use(infrastructure.RequestLogger(gin.DefaultWriter), gin.Recovery()) // Log requests with the feed tokens masked, recover from panics
+	routers.SetUp(config.Timeout, *db, router, config, blobs)                   // Setup all routes with middleware and handlers
+
+	server := &http.Server{Addr: ":" + config.Port, Handler: router}
+	go func() {
+		log.Printf("🚀 Server running at http://localhost:%s", config.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) { // Start the HTTP server
+			log.Fatalf("❌ Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down...")
+
+	// Let in-flight requests finish before the background jobs and the db connection go away
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server gracefully: %v", err)
+	}
+	jobs.Wait()
+}
+
+// newNotifier selects the notification channel configured by NOTIFIER
+func newNotifier(config infrastructure.Config) domain.Notifier {
+	switch config.Notifier {
+	case "smtp":
+		return infrastructure.NewSMTPNotifier(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword, config.SMTPFrom)
+	case "log":
+		return infrastructure.NewLogNotifier()
+	default:
+		log.Printf("Unknown notifier %q, falling back to log", config.Notifier)
+		return infrastructure.NewLogNotifier()
+	}
+}
 
-	log.Printf("🚀 Server running at http://localhost:%s", config.Port)
-	if err := router.Run(":" + config.Port); err != nil { // Start the HTTP server
-		log.Fatalf("❌ Failed to start server: %v", err)
+// newBlobStore selects the storage of attached files configured by BLOB_STORE
+func newBlobStore(config infrastructure.Config) (domain.BlobStore, error) {
+	switch config.BlobStore {
+	case "s3":
+		if config.S3Bucket == "" {
+			return nil, errors.New("S3_BUCKET is required when BLOB_STORE=s3")
+		}
+		return infrastructure.NewS3BlobStore(config.S3Endpoint, config.S3Region, config.S3Bucket, config.S3AccessKey, config.S3SecretKey, nil), nil
+	case "local":
+		return infrastructure.NewLocalBlobStore(config.BlobDir)
+	default:
+		log.Printf("Unknown blob store %q, falling back to local", config.BlobStore)
+		return infrastructure.NewLocalBlobStore(config.BlobDir)
 	}
 }
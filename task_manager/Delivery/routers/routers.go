@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/A2SVTask7/Delivery/controllers"
+	domain "github.com/A2SVTask7/Domain"
 	infrastructure "github.com/A2SVTask7/Infrastructure"
 	repositories "github.com/A2SVTask7/Repositories"
 	usecases "github.com/A2SVTask7/Usecases"
@@ -12,13 +13,150 @@ import (
 )
 
 // newTaskRouter sets up routes for task operations accessible by authenticated users
-func newTaskRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config) {
-	tr := repositories.NewTaskRepository(db, config.CollectionTask)
+// What a user may read and change is decided by their role in the project of each task
+func newTaskRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config, blobs domain.BlobStore) {
+	tu := newTaskUsecase(timeout, db, config, blobs)
 	tc := &controllers.TaskController{
-		TaskUsecase: usecases.NewTaskUsecase(tr, timeout),
+		TaskUsecase: tu,
+	}
+	bc := &controllers.BatchController{
+		TaskBatchUsecase: usecases.NewTaskBatchUsecase(tu, infrastructure.NewMongoTransactor(db.Client()), timeout, config.BatchTimeout),
+	}
+	ec := &controllers.ExportController{
+		TaskExportUsecase: usecases.NewTaskExportUsecase(
+			repositories.NewTaskRepository(db, config.CollectionTask),
+			repositories.NewProjectRepository(db, config.CollectionProject),
+			infrastructure.NewTaskExporter(infrastructure.NewSystemClock()),
+			timeout,
+		),
 	}
 	group.GET("/tasks", tc.GetAllTasks)
+	group.GET("/tasks/search", tc.SearchTasks)
+	group.GET("/tasks/export", ec.ExportTasks)
 	group.GET("/tasks/:id", tc.GetTaskByID)
+	group.GET("/tasks/:id/history", tc.GetTaskHistory)
+	group.GET("/tasks/:id/subtasks", tc.GetSubtasks)
+	group.GET("/me/tasks", tc.GetMyTasks)
+	group.GET("/tags", tc.GetTags)
+	group.POST("/tasks", tc.CreateTask)
+	group.POST("/tasks/batch", bc.ExecuteBatch)
+	group.DELETE("/tasks/:id", tc.DeleteTask)
+	group.PUT("/tasks/:id", tc.UpdateTask)
+	group.PATCH("/tasks/:id", tc.PatchTask)
+	group.POST("/tasks/:id/assignees", tc.AssignUser)
+	group.DELETE("/tasks/:id/assignees/:userID", tc.UnassignUser)
+	group.PUT("/tasks/:id/recurrence", tc.SetRecurrence)
+	group.DELETE("/tasks/:id/recurrence", tc.StopRecurrence)
+	group.POST("/tasks/:id/subtasks", tc.CreateSubtask)
+	group.PUT("/tasks/:id/subtasks/order", tc.ReorderSubtasks)
+	group.POST("/tasks/:id/checklist", tc.AddChecklistItem)
+	group.PATCH("/tasks/:id/checklist/:itemID", tc.UpdateChecklistItem)
+	group.DELETE("/tasks/:id/checklist/:itemID", tc.RemoveChecklistItem)
+	group.PUT("/tasks/:id/checklist/order", tc.ReorderChecklist)
+	group.POST("/tasks/:id/dependencies", tc.AddDependency)
+	group.DELETE("/tasks/:id/dependencies/:blockerID", tc.RemoveDependency)
+	group.POST("/tasks/:id/tags", tc.AddTag)
+	group.DELETE("/tasks/:id/tags/:tag", tc.RemoveTag)
+}
+
+// newTaskUsecase builds the task usecase shared by the routers that touch tasks
+func newTaskUsecase(timeout time.Duration, db mongo.Database, config infrastructure.Config, blobs domain.BlobStore) domain.TaskUsecase {
+	tr := repositories.NewTaskRepository(db, config.CollectionTask)
+	ur := repositories.NewUserRepository(db, config.CollectionUser)
+	hr := repositories.NewTaskHistoryRepository(db, config.CollectionHistory)
+	pr := repositories.NewProjectRepository(db, config.CollectionProject)
+	ep := newTaskEventPublisher(db, config)
+	au := newAttachmentUsecase(timeout, db, config, blobs)
+	cr := repositories.NewCommentRepository(db, config.CollectionComment)
+	cs := infrastructure.NewCursorService(config.CursorSecret)
+	return usecases.NewTaskUsecase(tr, ur, hr, pr, ep, au, cr, cs, config.TrashRetention, timeout)
+}
+
+// newTaskEventPublisher builds the publisher queueing webhook deliveries for the changes made to tasks
+func newTaskEventPublisher(db mongo.Database, config infrastructure.Config) domain.TaskEventPublisher {
+	wr := repositories.NewWebhookRepository(db, config.CollectionWebhook)
+	dr := repositories.NewWebhookDeliveryRepository(db, config.CollectionDelivery)
+	return usecases.NewTaskEventPublisher(wr, dr)
+}
+
+// newProjectRouter sets up routes for projects and their members, accessible by authenticated users
+func newProjectRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config) {
+	pr := repositories.NewProjectRepository(db, config.CollectionProject)
+	ur := repositories.NewUserRepository(db, config.CollectionUser)
+	tr := repositories.NewTaskRepository(db, config.CollectionTask)
+	pc := &controllers.ProjectController{
+		ProjectUsecase: usecases.NewProjectUsecase(pr, ur, tr, timeout),
+	}
+	group.GET("/projects", pc.GetProjects)
+	group.POST("/projects", pc.CreateProject)
+	group.GET("/projects/:id", pc.GetProject)
+	group.PUT("/projects/:id", pc.UpdateProject)
+	group.POST("/projects/:id/members", pc.AddProjectMember)
+	group.PATCH("/projects/:id/members/:userID", pc.UpdateProjectMember)
+	group.DELETE("/projects/:id/members/:userID", pc.RemoveProjectMember)
+}
+
+// newCommentRouter sets up routes for commenting on tasks, accessible by authenticated users
+func newCommentRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config) {
+	tr := repositories.NewTaskRepository(db, config.CollectionTask)
+	pr := repositories.NewProjectRepository(db, config.CollectionProject)
+	cr := repositories.NewCommentRepository(db, config.CollectionComment)
+	cs := infrastructure.NewCursorService(config.CursorSecret)
+	cc := &controllers.CommentController{
+		CommentUsecase: usecases.NewCommentUsecase(cr, tr, pr, cs, timeout),
+	}
+	group.GET("/tasks/:id/comments", cc.GetComments)
+	group.POST("/tasks/:id/comments", cc.CreateComment)
+	group.PATCH("/tasks/:id/comments/:commentID", cc.UpdateComment)
+	group.DELETE("/tasks/:id/comments/:commentID", cc.DeleteComment)
+}
+
+// newAttachmentRouter sets up routes for attaching files to tasks, accessible by authenticated users
+func newAttachmentRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config, blobs domain.BlobStore) {
+	ac := &controllers.AttachmentController{
+		AttachmentUsecase: newAttachmentUsecase(timeout, db, config, blobs),
+		MaxUploadSize:     config.AttachmentMaxSize,
+	}
+	group.GET("/tasks/:id/attachments", ac.GetAttachments)
+	group.POST("/tasks/:id/attachments", ac.UploadAttachment)
+	group.GET("/tasks/:id/attachments/:attachmentID", ac.DownloadAttachment)
+	group.DELETE("/tasks/:id/attachments/:attachmentID", ac.DeleteAttachment)
+}
+
+// newAttachmentUsecase builds the attachment usecase shared by the routers that touch attachments
+func newAttachmentUsecase(timeout time.Duration, db mongo.Database, config infrastructure.Config, blobs domain.BlobStore) domain.AttachmentUsecase {
+	ar := repositories.NewAttachmentRepository(db, config.CollectionAttachment)
+	tr := repositories.NewTaskRepository(db, config.CollectionTask)
+	pr := repositories.NewProjectRepository(db, config.CollectionProject)
+	return usecases.NewAttachmentUsecase(ar, tr, pr, blobs, config.AttachmentMaxSize, config.AttachmentTypes, timeout)
+}
+
+// newCalendarRouter sets up routes for managing the calendar feed token of the authenticated user
+func newCalendarRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config) {
+	cc := &controllers.CalendarController{
+		CalendarUsecase: newCalendarUsecase(timeout, db, config),
+	}
+	group.POST("/me/calendar-token", cc.IssueCalendarToken)
+	group.DELETE("/me/calendar-token", cc.RevokeCalendarToken)
+}
+
+// newCalendarFeedRouter sets up the public route of the calendar feeds, authenticated by the token in their path
+func newCalendarFeedRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config) {
+	cc := &controllers.CalendarController{
+		CalendarUsecase: newCalendarUsecase(timeout, db, config),
+	}
+	// the parameter holds "<token>.ics", the router can not split it
+	group.GET("/calendar/:file", cc.GetCalendarFeed)
+}
+
+// newCalendarUsecase builds the calendar usecase shared by the routers of the calendar feeds
+func newCalendarUsecase(timeout time.Duration, db mongo.Database, config infrastructure.Config) domain.CalendarUsecase {
+	cr := repositories.NewCalendarTokenRepository(db, config.CollectionCalendar)
+	ur := repositories.NewUserRepository(db, config.CollectionUser)
+	tr := repositories.NewTaskRepository(db, config.CollectionTask)
+	pr := repositories.NewProjectRepository(db, config.CollectionProject)
+	exporter := infrastructure.NewTaskExporter(infrastructure.NewSystemClock())
+	return usecases.NewCalendarUsecase(cr, ur, tr, pr, exporter, timeout)
 }
 
 // newUserRouter sets up public routes related to user authentication and registration
@@ -26,37 +164,62 @@ func newUserRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGr
 	ur := repositories.NewUserRepository(db, config.CollectionUser)
 	jwt := infrastructure.NewJWTService(config.JWTSecret)
 	pws := infrastructure.NewPasswordService()
+	cs := infrastructure.NewCursorService(config.CursorSecret)
 	uc := &controllers.UserController{
-		UserUsecase: usecases.NewUserUsecase(ur, jwt, pws, timeout),
+		UserUsecase: usecases.NewUserUsecase(ur, jwt, pws, cs, timeout),
 	}
 	group.POST("/login", uc.Login)
 	group.POST("/register", uc.Register)
 }
 
-// newAdminRouter sets up routes for admin-level operations including user management and task CRUD
-func newAdminRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config) {
+// newAdminRouter sets up routes for admin-level operations including user management, the trash, task imports and webhooks
+func newAdminRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, config infrastructure.Config, blobs domain.BlobStore) {
 	ur := repositories.NewUserRepository(db, config.CollectionUser)
 	jwt := infrastructure.NewJWTService(config.JWTSecret)
 	pws := infrastructure.NewPasswordService()
+	cs := infrastructure.NewCursorService(config.CursorSecret)
 	uc := &controllers.UserController{
-		UserUsecase: usecases.NewUserUsecase(ur, jwt, pws, timeout),
+		UserUsecase: usecases.NewUserUsecase(ur, jwt, pws, cs, timeout),
 	}
-
-	tr := repositories.NewTaskRepository(db, config.CollectionTask)
 	tc := &controllers.TaskController{
-		TaskUsecase: usecases.NewTaskUsecase(tr, timeout),
+		TaskUsecase: newTaskUsecase(timeout, db, config, blobs),
+	}
+	ic := &controllers.ImportController{
+		TaskImportUsecase: usecases.NewTaskImportUsecase(
+			repositories.NewTaskRepository(db, config.CollectionTask),
+			repositories.NewTaskHistoryRepository(db, config.CollectionHistory),
+			repositories.NewProjectRepository(db, config.CollectionProject),
+			newTaskEventPublisher(db, config),
+			timeout,
+		),
+	}
+	wc := &controllers.WebhookController{
+		WebhookUsecase: usecases.NewWebhookUsecase(
+			repositories.NewWebhookRepository(db, config.CollectionWebhook),
+			repositories.NewWebhookDeliveryRepository(db, config.CollectionDelivery),
+			timeout,
+		),
 	}
 
 	group.GET("/users", uc.GetAllUsers)
 	group.GET("/users/:id", uc.GetUserByID)
 	group.PATCH("/promote/:id", uc.Promote)
-	group.POST("/tasks", tc.CreateTask)
-	group.DELETE("/tasks/:id", tc.DeleteTask)
-	group.PUT("/tasks/:id", tc.UpdateTask)
+	group.GET("/trash", tc.GetTrash)
+	group.POST("/trash/:id/restore", tc.RestoreTask)
+	group.DELETE("/trash", tc.PurgeTrash)
+	group.POST("/tasks/import", ic.ImportTasks)
+	group.GET("/webhooks", wc.GetWebhooks)
+	group.POST("/webhooks", wc.CreateWebhook)
+	group.GET("/webhooks/:id", wc.GetWebhook)
+	group.PUT("/webhooks/:id", wc.UpdateWebhook)
+	group.DELETE("/webhooks/:id", wc.DeleteWebhook)
+	group.GET("/webhooks/:id/deliveries", wc.GetWebhookDeliveries)
+	group.POST("/webhooks/:id/deliveries/:deliveryID/redeliver", wc.RedeliverWebhook)
 }
 
 // SetUp configures all the route groups and applies middleware for authentication and authorization
-func SetUp(timeout time.Duration, db mongo.Database, router *gin.Engine, config infrastructure.Config) {
+// Attached files are kept in blobs
+func SetUp(timeout time.Duration, db mongo.Database, router *gin.Engine, config infrastructure.Config, blobs domain.BlobStore) {
 	userRepo := repositories.NewUserRepository(db, config.CollectionUser)
 	jwtService := infrastructure.NewJWTService(config.JWTSecret) // Use environment variable for JWT secret
 
@@ -66,14 +229,19 @@ func SetUp(timeout time.Duration, db mongo.Database, router *gin.Engine, config
 	// Public routes without authentication
 	publicRouter := router.Group("")
 	newUserRouter(timeout, db, publicRouter, config)
+	newCalendarFeedRouter(timeout, db, publicRouter, config)
 
 	// Routes requiring authentication
 	authenticatedRouter := router.Group("")
 	authenticatedRouter.Use(authMiddleware)
-	newTaskRouter(timeout, db, authenticatedRouter, config)
+	newTaskRouter(timeout, db, authenticatedRouter, config, blobs)
+	newProjectRouter(timeout, db, authenticatedRouter, config)
+	newCommentRouter(timeout, db, authenticatedRouter, config)
+	newAttachmentRouter(timeout, db, authenticatedRouter, config, blobs)
+	newCalendarRouter(timeout, db, authenticatedRouter, config)
 
 	// Admin-only routes require both authentication and authorization
 	adminRouter := router.Group("")
 	adminRouter.Use(authMiddleware, adminMiddleware)
-	newAdminRouter(timeout, db, adminRouter, config)
+	newAdminRouter(timeout, db, adminRouter, config, blobs)
 }
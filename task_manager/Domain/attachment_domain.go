@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// DefaultMaxAttachmentSize is the largest file accepted as an attachment unless configured otherwise, in bytes
+const DefaultMaxAttachmentSize = 10 << 20
+
+// DefaultAttachmentTypes are the media types accepted as attachments unless configured otherwise
+// A type ending in "/*" accepts every subtype
+var DefaultAttachmentTypes = []string{"image/*", "application/pdf", "text/plain", "application/zip"}
+
+// Attachment is a file uploaded to a task
+type Attachment struct {
+	ID          string    // Unique identifier for the attachment
+	TaskID      string    // ID of the task the file is attached to
+	UploaderID  string    // ID of the user who uploaded the file
+	Filename    string    // Name of the file as uploaded, without directories
+	ContentType string    // Media type detected from the content of the file
+	Size        int64     // Size of the file in bytes
+	StorageKey  string    // Key of the file content in the BlobStore
+	CreatedAt   time.Time // When the file was uploaded
+}
+
+// CanBeDeletedBy reports whether the actor may delete the attachment
+// Only the uploader and admins may
+func (a *Attachment) CanBeDeletedBy(actor Actor) bool {
+	return actor.IsAdmin || a.UploaderID == actor.ID
+}
+
+// AttachmentUpload is a file being attached to a task
+type AttachmentUpload struct {
+	Filename string    // Name of the file given by the client
+	Size     int64     // Size of the file in bytes
+	Content  io.Reader // Content of the file, Size bytes long
+}
+
+// BlobStore stores the content of files under opaque keys
+type BlobStore interface {
+	// Put stores size bytes read from content under key
+	Put(c context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get opens the content stored under key, returning ErrBlobNotFound if there is none
+	// The caller must close the returned reader
+	Get(c context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key; deleting a missing key is not an error
+	Delete(c context.Context, key string) error
+}
+
+// AttachmentRepository defines the interface for the attachment persistence layer
+type AttachmentRepository interface {
+	// Create inserts a new attachment and sets its generated ID
+	Create(c context.Context, attachment *Attachment) error
+	// FetchByID retrieves an attachment by its unique ID
+	FetchByID(c context.Context, attachmentID string) (Attachment, error)
+	// FetchByTaskID retrieves the attachments of a task, oldest first
+	FetchByTaskID(c context.Context, taskID string) ([]Attachment, error)
+	// FetchByTaskIDs retrieves the attachments of every task among taskIDs
+	FetchByTaskIDs(c context.Context, taskIDs []string) ([]Attachment, error)
+	// Delete removes an attachment, returning the number of attachments deleted
+	Delete(c context.Context, attachmentID string) (int, error)
+	// DeleteMany removes the attachments among attachmentIDs, returning the number of attachments deleted
+	DeleteMany(c context.Context, attachmentIDs []string) (int, error)
+}
+
+// AttachmentUsecase defines the business logic layer for attachment-related operations
+type AttachmentUsecase interface {
+	Upload(c context.Context, taskID string, upload AttachmentUpload, actor Actor) (Attachment, error)
+	FetchByTaskID(c context.Context, taskID string, actor Actor) ([]Attachment, error)
+	Open(c context.Context, taskID string, attachmentID string, actor Actor) (Attachment, io.ReadCloser, error)
+	Delete(c context.Context, taskID string, attachmentID string, actor Actor) error
+	DeleteByTaskIDs(c context.Context, taskIDs []string) error
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// MaxBatchSize is the maximum number of operations in a batch
+const MaxBatchSize = 500
+
+// BatchOp is the kind of change made by an operation of a batch
+type BatchOp string
+
+// Supported batch operations
+const (
+	BatchCreate BatchOp = "create"
+	BatchUpdate BatchOp = "update"
+	BatchDelete BatchOp = "delete"
+)
+
+// ParseBatchOp validates an operation name given by a client
+// Matching is case-insensitive and ignores surrounding spaces
+func ParseBatchOp(s string) (BatchOp, error) {
+	switch op := BatchOp(strings.ToLower(strings.TrimSpace(s))); op {
+	case BatchCreate, BatchUpdate, BatchDelete:
+		return op, nil
+	default:
+		return "", ErrInvalidBatchOp
+	}
+}
+
+// BatchOperation is one change of a batch
+type BatchOperation struct {
+	Op      BatchOp
+	TaskID  string    // Task changed by update and delete operations
+	Task    Task      // Task added by create operations
+	Patch   TaskPatch // Fields changed by update operations
+	Version int       // Version expected to be stored by update and delete operations, 0 skips the check
+}
+
+// BatchResult is the outcome of one operation of a batch
+type BatchResult struct {
+	Index  int     // Position of the operation in the batch
+	Op     BatchOp // Kind of the operation
+	TaskID string  // Task created, changed or deleted by the operation
+	Task   *Task   // Task as stored after a create or update, nil otherwise
+	Err    error   // Why the operation was not applied, nil on success
+}
+
+// Transactor runs units of work inside a database transaction
+type Transactor interface {
+	// WithTransaction runs fn in a transaction, committed when fn returns nil and aborted otherwise
+	// fn may run more than once when the transaction hits a transient error
+	// Returns ErrTransactionsUnsupported when the database cannot run transactions
+	WithTransaction(c context.Context, fn func(ctx context.Context) error) error
+}
+
+// TaskBatchUsecase defines the business logic layer for applying many task changes in one request
+type TaskBatchUsecase interface {
+	Execute(c context.Context, operations []BatchOperation, atomic bool, actor Actor) ([]BatchResult, error)
+}
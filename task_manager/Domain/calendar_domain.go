@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CalendarToken is the secret giving access to the calendar feed of a user without logging in
+// Only a hash of the token is stored, the token itself is shown once when it is issued
+type CalendarToken struct {
+	UserID    string    // User whose tasks the feed lists
+	TokenHash string    // Hex encoded SHA-256 of the token
+	CreatedAt time.Time // When the token was issued
+}
+
+// CalendarFeed is the rendered calendar feed of a user
+type CalendarFeed struct {
+	ETag        string // Strong entity tag, changes whenever a task of the feed does
+	Content     []byte // iCalendar document, nil when NotModified
+	NotModified bool   // The client already holds the feed with this ETag, so it was not rendered
+}
+
+// CalendarTokenRepository defines the interface for interacting with the calendar token persistence layer
+type CalendarTokenRepository interface {
+	// Save stores the token of a user, replacing the one they had
+	Save(c context.Context, token *CalendarToken) error
+	// FetchByHash retrieves the token with the given hash
+	// Returns ErrCalendarTokenNotFound when no token has it
+	FetchByHash(c context.Context, tokenHash string) (CalendarToken, error)
+	// DeleteByUserID removes the token of a user and returns the number of tokens deleted
+	DeleteByUserID(c context.Context, userID string) (int, error)
+}
+
+// CalendarUsecase defines the business logic layer for the calendar feeds users subscribe to
+type CalendarUsecase interface {
+	// IssueToken creates a new feed token for the actor, revoking the previous one
+	IssueToken(c context.Context, actor Actor) (string, error)
+	// RevokeToken removes the feed token of the actor
+	// Returns ErrCalendarTokenNotFound when the actor has none
+	RevokeToken(c context.Context, actor Actor) error
+	// FetchFeed renders the feed the token gives access to, unless ifNoneMatch, the If-None-Match header
+	// of the request, lists its current ETag. Returns ErrCalendarTokenNotFound for unknown or revoked tokens
+	FetchFeed(c context.Context, token string, ifNoneMatch string) (CalendarFeed, error)
+}
@@ -0,0 +1,43 @@
+package domain
+
+// ChecklistItem is a lightweight step of a task that is not worth a subtask of its own
+type ChecklistItem struct {
+	ID   string // Unique identifier within the task
+	Text string
+	Done bool
+}
+
+// ChecklistItemPatch holds the fields of a partial checklist item update
+// Nil fields are left untouched
+type ChecklistItemPatch struct {
+	Text *string
+	Done *bool
+}
+
+// IsEmpty reports whether the patch does not change any field
+func (p *ChecklistItemPatch) IsEmpty() bool {
+	return p.Text == nil && p.Done == nil
+}
+
+// TaskProgress computes the percentage of finished subtasks and checklist items of a task
+// Returns nil when the task has neither
+func TaskProgress(task Task, subtasks []Task) *int {
+	total := len(subtasks) + len(task.Checklist)
+	if total == 0 {
+		return nil
+	}
+
+	done := 0
+	for _, subtask := range subtasks {
+		if subtask.Status == StatusCompleted {
+			done++
+		}
+	}
+	for _, item := range task.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+	progress := done * 100 / total
+	return &progress
+}
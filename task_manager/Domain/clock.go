@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// Clock abstracts the passage of time so time-driven code can be tested deterministically
+type Clock interface {
+	Now() time.Time                         // Returns the current time
+	After(d time.Duration) <-chan time.Time // Delivers the current time once d has elapsed
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCommentLength is the maximum number of characters in a comment body
+const MaxCommentLength = 5000
+
+// Comment is a message posted on a task
+type Comment struct {
+	ID        string     // Unique identifier for the comment
+	TaskID    string     // ID of the task the comment belongs to
+	AuthorID  string     // ID of the user who posted the comment
+	Body      string     // Text of the comment
+	CreatedAt time.Time  // When the comment was posted
+	EditedAt  *time.Time // When the body was last changed, nil if it never was
+}
+
+// CanBeChangedBy reports whether the actor may edit or delete the comment
+// Only the author and admins may
+func (c *Comment) CanBeChangedBy(actor Actor) bool {
+	return actor.IsAdmin || c.AuthorID == actor.ID
+}
+
+// CommentQuery describes the pagination of the comments of a task
+// Comments are listed oldest first
+type CommentQuery struct {
+	TaskID string  // Task whose comments are listed
+	Limit  int     // Maximum number of comments to return
+	Cursor string  // Opaque token of the previous page; the page starts after it
+	After  *Cursor // Decoded Cursor handed to the repository
+}
+
+// CommentPage is one page of the comments of a task
+type CommentPage struct {
+	Comments   []Comment
+	NextCursor string // Token for the following page, empty on the last page
+}
+
+// CommentRepository defines the interface for the comment persistence layer
+type CommentRepository interface {
+	// Create inserts a new comment and sets its generated ID
+	Create(c context.Context, comment *Comment) error
+	// FetchByID retrieves a comment by its unique ID
+	FetchByID(c context.Context, commentID string) (Comment, error)
+	// FetchByTaskID retrieves a page of the comments of a task, oldest first
+	FetchByTaskID(c context.Context, query CommentQuery) ([]Comment, error)
+	// UpdateBody replaces the body of a comment and marks it edited at editedAt, returning the number of comments matched
+	UpdateBody(c context.Context, commentID string, body string, editedAt time.Time) (int, error)
+	// Delete removes a comment, returning the number of comments deleted
+	Delete(c context.Context, commentID string) (int, error)
+	// DeleteByTaskIDs removes every comment of the tasks among taskIDs, returning the number of comments deleted
+	DeleteByTaskIDs(c context.Context, taskIDs []string) (int, error)
+}
+
+// CommentUsecase defines the business logic layer for comment-related operations
+type CommentUsecase interface {
+	Create(c context.Context, taskID string, body string, actor Actor) (Comment, error)
+	FetchByTaskID(c context.Context, query CommentQuery, actor Actor) (CommentPage, error)
+	Update(c context.Context, taskID string, commentID string, body string, actor Actor) (Comment, error)
+	Delete(c context.Context, taskID string, commentID string, actor Actor) error
+}
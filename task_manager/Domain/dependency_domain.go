@@ -0,0 +1,17 @@
+package domain
+
+import "fmt"
+
+// BlockedError reports the open tasks that keep a task from being completed
+// It matches ErrTaskBlocked with errors.Is
+type BlockedError struct {
+	Blockers []Task // Tasks listed in BlockedBy that are not completed yet
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s by %d open task(s)", ErrTaskBlocked.Error(), len(e.Blockers))
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrTaskBlocked
+}
@@ -8,6 +8,105 @@ var (
 	ErrInvalidDueDate = errors.New("due date cannot be in the past")
 	ErrTaskNotFound   = errors.New("task not found")
 	ErrNoChangesMade  = errors.New("no changes were made")
+	ErrEmptyTitle     = errors.New("title cannot be empty")
+	ErrInvalidStatus  = errors.New("status must be one of pending, completed or missed")
+	ErrEmptyPatch     = errors.New("patch does not change any field")
+
+	ErrInvalidStatusTransition = errors.New("status transition is not allowed")
+	ErrInvalidPriority         = errors.New("priority must be one of low, medium, high or critical")
+
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+	ErrNotRecurring      = errors.New("task does not recur")
+	ErrOccurrenceExists  = errors.New("occurrence of the series already exists")
+
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrEmptyChecklistItem    = errors.New("checklist item text cannot be empty")
+	ErrInvalidOrder          = errors.New("order must list every item exactly once")
+
+	ErrTaskBlocked        = errors.New("task is blocked")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrDependencyExists   = errors.New("task is already blocked by the given task")
+	ErrDependencyNotFound = errors.New("task is not blocked by the given task")
+
+	ErrInvalidTag  = errors.New("tag must be between 1 and 50 characters")
+	ErrTagExists   = errors.New("task already has the tag")
+	ErrTagNotFound = errors.New("task does not have the tag")
+
+	ErrVersionConflict = errors.New("task was modified since the given version")
+
+	ErrUserAlreadyAssigned = errors.New("user is already assigned to the task")
+	ErrUserNotAssigned     = errors.New("user is not assigned to the task")
+)
+
+var (
+	ErrProjectNotFound     = errors.New("project not found")
+	ErrInvalidProjectID    = errors.New("invalid project id")
+	ErrProjectRequired     = errors.New("task must belong to a project")
+	ErrInvalidProjectName  = errors.New("project name must be between 1 and 100 characters")
+	ErrInvalidProjectRole  = errors.New("role must be one of owner, editor or viewer")
+	ErrProjectMemberExists = errors.New("user is already a member of the project")
+	ErrNotProjectMember    = errors.New("user is not a member of the project")
+	ErrLastProjectOwner    = errors.New("project must keep at least one owner")
+	ErrNotProjectOwner     = errors.New("only a project owner or an admin can manage the project")
+	ErrProjectReadOnly     = errors.New("viewers cannot change the tasks of the project")
+	ErrProjectMismatch     = errors.New("tasks belong to different projects")
+)
+
+var (
+	ErrEmptyBatch              = errors.New("batch must contain at least one operation")
+	ErrBatchTooLarge           = errors.New("batch must contain at most 500 operations")
+	ErrInvalidBatchOp          = errors.New("operation must be one of create, update or delete")
+	ErrBatchRolledBack         = errors.New("batch was rolled back")
+	ErrBatchAborted            = errors.New("operation was not applied because another operation of the batch failed")
+	ErrTransactionsUnsupported = errors.New("database does not support transactions")
+)
+
+var (
+	ErrInvalidImportFormat = errors.New("import format must be csv or json")
+	ErrInvalidImport       = errors.New("invalid import file")
+	ErrEmptyImport         = errors.New("import file must contain at least one task")
+	ErrImportTooLarge      = errors.New("import file must contain at most 1000 tasks")
+	ErrInvalidImportValue  = errors.New("invalid value")
+	ErrInvalidExportFormat = errors.New("export format must be csv, jsonl or ics")
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrInvalidCommentID = errors.New("invalid comment id")
+	ErrEmptyComment     = errors.New("comment cannot be empty")
+	ErrCommentTooLong   = errors.New("comment must be at most 5000 characters")
+	ErrNotCommentAuthor = errors.New("only the author or an admin can change the comment")
+)
+
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrInvalidAttachmentID      = errors.New("invalid attachment id")
+	ErrInvalidFilename          = errors.New("invalid file name")
+	ErrEmptyAttachment          = errors.New("attachment cannot be empty")
+	ErrAttachmentTooLarge       = errors.New("attachment exceeds the maximum size")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+	ErrNotAttachmentUploader    = errors.New("only the uploader or an admin can delete the attachment")
+	ErrBlobNotFound             = errors.New("blob not found")
+	ErrInvalidBlobKey           = errors.New("invalid blob key")
+)
+
+var (
+	ErrCalendarTokenNotFound = errors.New("calendar feed not found")
+)
+
+var (
+	ErrWebhookNotFound          = errors.New("webhook not found")
+	ErrInvalidWebhookID         = errors.New("invalid webhook id")
+	ErrInvalidWebhookURL        = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidWebhookEvent      = errors.New("webhook events must be task.created, task.updated, task.deleted or task.status_changed")
+	ErrWebhookDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrInvalidWebhookDeliveryID = errors.New("invalid webhook delivery id")
+	ErrNoWebhookDeliveryDue     = errors.New("no webhook delivery is due")
+)
+
+var (
+	ErrInvalidQuery  = errors.New("invalid query")
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
 )
 
 var (
@@ -16,3 +115,7 @@ var (
 	ErrInvalidUserID     = errors.New("invalid user id")
 	ErrIncorrectPassword = errors.New("incorrect password")
 )
+
+var (
+	ErrNoContactAddress = errors.New("user has no address to notify")
+)
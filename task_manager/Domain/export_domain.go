@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// ExportFormat is the encoding of an export file
+type ExportFormat string
+
+// Supported export formats
+const (
+	ExportCSV   ExportFormat = "csv"
+	ExportJSONL ExportFormat = "jsonl" // JSON Lines, one task object per line
+	ExportICS   ExportFormat = "ics"   // iCalendar, one VTODO per task
+)
+
+// ParseExportFormat validates a format name given by a client
+// Matching is case-insensitive and ignores surrounding spaces
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch format := ExportFormat(strings.ToLower(strings.TrimSpace(s))); format {
+	case ExportCSV, ExportJSONL, ExportICS:
+		return format, nil
+	default:
+		return "", ErrInvalidExportFormat
+	}
+}
+
+// TaskEncoder writes the tasks of an export one at a time
+type TaskEncoder interface {
+	Encode(task Task) error
+	// Close writes what follows the last task and flushes the output
+	// Must be called once every task was encoded, even when there was none
+	Close() error
+}
+
+// TaskExporter creates the encoders of the export formats
+type TaskExporter interface {
+	// NewEncoder returns an encoder writing tasks to w in the given format
+	// Nothing is written to w before the first task is encoded or the encoder is closed
+	NewEncoder(format ExportFormat, w io.Writer) (TaskEncoder, error)
+	// NewFeedEncoder returns an encoder writing an iCalendar meant for calendar subscriptions to w
+	// Every task is both a VTODO and a VEVENT on its due date, since many calendar apps do not show to-dos
+	NewFeedEncoder(w io.Writer) TaskEncoder
+}
+
+// TaskExportUsecase defines the business logic layer for exporting task lists
+type TaskExportUsecase interface {
+	// Export writes every task matching the filters of the query that the actor can see to w
+	// Tasks are streamed from the data store as they are written; pagination fields of the query are ignored
+	// Nothing is written to w when the query is rejected
+	Export(c context.Context, query TaskQuery, format ExportFormat, w io.Writer, actor Actor) error
+}
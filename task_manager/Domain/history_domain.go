@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded in a task's history
+const (
+	TaskActionCreate  = "create"
+	TaskActionUpdate  = "update"
+	TaskActionDelete  = "delete"
+	TaskActionRestore = "restore"
+)
+
+// FieldChange is the value of a single task field before and after a change
+type FieldChange struct {
+	Field  string // Name of the changed field, e.g. "status"
+	Before any    // Value before the change, nil when the field was unset
+	After  any    // Value after the change
+}
+
+// TaskHistoryEntry records one change made to a task
+type TaskHistoryEntry struct {
+	ID        string        // Unique identifier for the entry
+	TaskID    string        // ID of the changed task
+	ActorID   string        // ID of the user who made the change
+	Action    string        // One of the TaskAction constants
+	Timestamp time.Time     // When the change was made
+	Changes   []FieldChange // Field-level diff of the change, empty for deletes and restores
+}
+
+// TaskHistoryRepository defines the interface for the task history persistence layer
+type TaskHistoryRepository interface {
+	// Append stores a new history entry
+	Append(c context.Context, entry *TaskHistoryEntry) error
+	// FetchByTaskID retrieves the history of a task, oldest entry first
+	FetchByTaskID(c context.Context, taskID string) ([]TaskHistoryEntry, error)
+}
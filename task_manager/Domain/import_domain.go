@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// MaxImportRows is the maximum number of tasks in an import file
+const MaxImportRows = 1000
+
+// ImportFormat is the encoding of an import file
+type ImportFormat string
+
+// Supported import formats
+const (
+	ImportCSV  ImportFormat = "csv"
+	ImportJSON ImportFormat = "json"
+)
+
+// ParseImportFormat validates a format name given by a client
+// Matching is case-insensitive and ignores surrounding spaces
+func ParseImportFormat(s string) (ImportFormat, error) {
+	switch format := ImportFormat(strings.ToLower(strings.TrimSpace(s))); format {
+	case ImportCSV, ImportJSON:
+		return format, nil
+	default:
+		return "", ErrInvalidImportFormat
+	}
+}
+
+// ImportRow is the outcome of one task of an import file
+type ImportRow struct {
+	Row    int    // Position of the task in the file, from 1, not counting the CSV header
+	Title  string // Title given to the task, to help finding the row
+	TaskID string // Task created from the row, empty on dry runs and for invalid rows
+	Err    error  // Why the task cannot be imported, nil for valid rows
+}
+
+// ImportReport sums up the outcome of an import
+type ImportReport struct {
+	DryRun   bool        // Whether the tasks were only validated
+	Valid    int         // Number of rows that can be imported
+	Invalid  int         // Number of rows that cannot be imported
+	Imported int         // Number of tasks created, 0 on dry runs
+	Rows     []ImportRow // Outcome of every row, in file order
+}
+
+// TaskImportUsecase defines the business logic layer for creating tasks from a file
+type TaskImportUsecase interface {
+	// Import validates every task of the file like a single task creation would
+	// Dry runs only report the outcome of each row; otherwise the valid rows are created in one write
+	// Returns ErrInvalidImport when the file itself cannot be read
+	Import(c context.Context, format ImportFormat, file io.Reader, dryRun bool, actor Actor) (ImportReport, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Notification is a message addressed to a single user
+type Notification struct {
+	Recipient User   // User the message is addressed to
+	Subject   string // Short summary of the message
+	Body      string // Plain text content of the message
+}
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	// Notify sends n to its recipient, returning ErrNoContactAddress if the recipient cannot be reached
+	Notify(c context.Context, n Notification) error
+}
+
+// ReminderRepository keeps track of the due-date reminders that were sent
+// A reminder is identified by the task, the user and the due date it was sent for,
+// so moving the due date makes the task eligible for a new reminder
+type ReminderRepository interface {
+	// Claim records a reminder as sent, returning false if it had already been recorded
+	Claim(c context.Context, taskID string, userID string, dueDate time.Time) (bool, error)
+	// Release forgets a claimed reminder so that it is attempted again
+	Release(c context.Context, taskID string, userID string, dueDate time.Time) error
+}
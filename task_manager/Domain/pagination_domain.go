@@ -0,0 +1,22 @@
+package domain
+
+// Limits applied to the page size of paginated listings
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Cursor marks the last item of a page in a keyset-paginated listing
+// The next page starts right after the item identified by SortValue and ID
+type Cursor struct {
+	SortBy    string `json:"sort_by,omitempty"`    // Field the listing was sorted by, empty for insertion order
+	SortDesc  bool   `json:"sort_desc,omitempty"`  // Whether the listing was sorted in descending order
+	SortValue string `json:"sort_value,omitempty"` // Value of the sort field of the last item
+	ID        string `json:"id"`                   // ID of the last item, used as a tie breaker
+}
+
+// CursorService converts cursors to and from opaque, tamper-evident tokens
+type CursorService interface {
+	Encode(cursor Cursor) (string, error) // Encodes and signs a cursor
+	Decode(token string) (Cursor, error)  // Verifies and decodes a token, returning ErrInvalidCursor on failure
+}
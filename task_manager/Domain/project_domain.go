@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// MaxProjectNameLength is the maximum number of characters in a project name
+const MaxProjectNameLength = 100
+
+// ProjectRole is the role of a member within a project
+type ProjectRole string
+
+// Roles a project member can hold, from the most to the least privileged
+const (
+	ProjectRoleOwner  ProjectRole = "owner"  // Manages the project and its members, and changes tasks
+	ProjectRoleEditor ProjectRole = "editor" // Creates and changes tasks
+	ProjectRoleViewer ProjectRole = "viewer" // Reads tasks, comments and attaches files
+)
+
+// ParseProjectRole validates a role given by a client
+// Matching is case-insensitive and ignores surrounding spaces
+func ParseProjectRole(s string) (ProjectRole, error) {
+	switch role := ProjectRole(strings.ToLower(strings.TrimSpace(s))); role {
+	case ProjectRoleOwner, ProjectRoleEditor, ProjectRoleViewer:
+		return role, nil
+	default:
+		return "", ErrInvalidProjectRole
+	}
+}
+
+// CanEditTasks reports whether the role allows creating and changing the tasks of the project
+func (r ProjectRole) CanEditTasks() bool {
+	return r == ProjectRoleOwner || r == ProjectRoleEditor
+}
+
+// CanManage reports whether the role allows renaming the project and managing its members
+func (r ProjectRole) CanManage() bool {
+	return r == ProjectRoleOwner
+}
+
+// ProjectMember is a user taking part in a project
+type ProjectMember struct {
+	UserID string
+	Role   ProjectRole
+}
+
+// Project groups the tasks of a team; only its members see them
+type Project struct {
+	ID          string
+	Name        string
+	Description string
+	Members     []ProjectMember
+	CreatedAt   time.Time
+}
+
+// RoleOf returns the role of a user in the project, ok is false when the user is not a member
+func (p *Project) RoleOf(userID string) (role ProjectRole, ok bool) {
+	for _, member := range p.Members {
+		if member.UserID == userID {
+			return member.Role, true
+		}
+	}
+	return "", false
+}
+
+// RoleOfActor returns the role the actor acts with in the project
+// Admins act as owners of every project
+func (p *Project) RoleOfActor(actor Actor) (ProjectRole, bool) {
+	if actor.IsAdmin {
+		return ProjectRoleOwner, true
+	}
+	return p.RoleOf(actor.ID)
+}
+
+// OwnerCount returns the number of members holding the owner role
+func (p *Project) OwnerCount() int {
+	count := 0
+	for _, member := range p.Members {
+		if member.Role == ProjectRoleOwner {
+			count++
+		}
+	}
+	return count
+}
+
+// ProjectRepository defines the interface for the project persistence layer
+type ProjectRepository interface {
+	// Create inserts a new project and sets its generated ID
+	Create(c context.Context, project *Project) error
+	// FetchByID retrieves a project by its unique ID
+	FetchByID(c context.Context, projectID string) (Project, error)
+	// FetchAll retrieves every project, oldest first
+	FetchAll(c context.Context) ([]Project, error)
+	// FetchByMember retrieves the projects userID is a member of, oldest first
+	FetchByMember(c context.Context, userID string) ([]Project, error)
+	// Update replaces the name and description of a project, returning the number of projects matched
+	Update(c context.Context, project *Project) (int, error)
+	// AddMember adds a member to a project unless the user already is one, returning the number of projects modified
+	AddMember(c context.Context, projectID string, member ProjectMember) (int, error)
+	// UpdateMemberRole changes the role of a member, returning the number of projects matched
+	UpdateMemberRole(c context.Context, projectID string, userID string, role ProjectRole) (int, error)
+	// RemoveMember removes a member from a project, returning the number of projects modified
+	RemoveMember(c context.Context, projectID string, userID string) (int, error)
+}
+
+// ProjectUsecase defines the business logic layer for project-related operations
+type ProjectUsecase interface {
+	Create(c context.Context, project *Project, actor Actor) error
+	FetchByID(c context.Context, projectID string, actor Actor) (Project, error)
+	FetchAll(c context.Context, actor Actor) ([]Project, error)
+	Update(c context.Context, project *Project, actor Actor) error
+	AddMember(c context.Context, projectID string, member ProjectMember, actor Actor) error
+	UpdateMemberRole(c context.Context, projectID string, userID string, role ProjectRole, actor Actor) error
+	RemoveMember(c context.Context, projectID string, userID string, actor Actor) error
+}
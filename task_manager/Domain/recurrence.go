@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported recurrence frequencies
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRecurrenceSteps bounds the search for the next occurrence of rules that rarely match, e.g. BYMONTHDAY=31
+const maxRecurrenceSteps = 1000
+
+// RRule is a parsed RFC 5545 recurrence rule
+// Only the subset useful for due dates is supported: FREQ, INTERVAL, COUNT, UNTIL,
+// BYDAY (weekly rules, without ordinals) and BYMONTHDAY (monthly rules)
+type RRule struct {
+	Freq       string         // One of the Freq constants
+	Interval   int            // Number of periods between two occurrences, at least 1
+	Count      int            // Total number of occurrences, 0 for unlimited
+	Until      time.Time      // Last moment an occurrence may fall on, zero for unlimited
+	ByDay      []time.Weekday // Weekdays of a weekly rule, sorted from Monday
+	ByMonthDay []int          // Days of a monthly rule, negative values count from the end of the month
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ParseRRule parses a recurrence rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"
+// An "RRULE:" prefix is accepted; errors wrap ErrInvalidRecurrence
+func ParseRRule(s string) (RRule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	if s == "" {
+		return RRule{}, fmt.Errorf("%w: rule is empty", ErrInvalidRecurrence)
+	}
+
+	rule := RRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return RRule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrence, part)
+		}
+		if seen[name] {
+			return RRule{}, fmt.Errorf("%w: %s is given twice", ErrInvalidRecurrence, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch value {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+				rule.Freq = value
+			default:
+				err = fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(value)
+		case "COUNT":
+			rule.Count, err = parsePositive(value)
+		case "UNTIL":
+			rule.Until, err = parseRRuleTime(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(value)
+		case "WKST":
+			if value != "MO" {
+				err = fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			err = fmt.Errorf("%s is not supported", name)
+		}
+		if err != nil {
+			return RRule{}, fmt.Errorf("%w: %s", ErrInvalidRecurrence, err.Error())
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return RRule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return RRule{}, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRecurrence)
+	case len(rule.ByDay) > 0 && rule.Freq != FreqWeekly:
+		return RRule{}, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRecurrence)
+	case len(rule.ByMonthDay) > 0 && rule.Freq != FreqMonthly:
+		return RRule{}, fmt.Errorf("%w: BYMONTHDAY is only supported with FREQ=MONTHLY", ErrInvalidRecurrence)
+	}
+	return rule, nil
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive number", value)
+	}
+	return n, nil
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// a date-only UNTIL includes the whole day
+				t = t.Add(24*time.Hour - time.Nanosecond)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("UNTIL %q must be a UTC date-time or a date", value)
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range strings.Split(value, ",") {
+		day, ok := rruleWeekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported BYDAY %q", name)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b time.Weekday) int { return weekdayIndex(a) - weekdayIndex(b) })
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, part := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(part)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("BYMONTHDAY %q must be within 1..31 or -31..-1", part)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// weekdayIndex numbers the weekdays from Monday (0) to Sunday (6)
+func weekdayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String formats the rule in its canonical form
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			names = append(names, strings.ToUpper(day.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next computes the occurrence following prev, keeping its time of day and location
+// occurrence is the 1-based position of prev in the series, used to honour COUNT
+// Returns false once the series is over
+func (r RRule) Next(prev time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	var ok bool
+	switch r.Freq {
+	case FreqDaily:
+		next, ok = prev.AddDate(0, 0, r.Interval), true
+	case FreqWeekly:
+		next, ok = r.nextWeekly(prev), true
+	case FreqMonthly:
+		next, ok = r.nextMonthly(prev)
+	case FreqYearly:
+		next, ok = r.nextYearly(prev)
+	}
+	if !ok || (!r.Until.IsZero() && next.After(r.Until)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r RRule) nextWeekly(prev time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return prev.AddDate(0, 0, 7*r.Interval)
+	}
+
+	// later days of the same week come first, then the first day of the next matching week
+	weekStart := prev.AddDate(0, 0, -weekdayIndex(prev.Weekday()))
+	for _, day := range r.ByDay {
+		if weekdayIndex(day) > weekdayIndex(prev.Weekday()) {
+			return weekStart.AddDate(0, 0, weekdayIndex(day))
+		}
+	}
+	return weekStart.AddDate(0, 0, 7*r.Interval+weekdayIndex(r.ByDay[0]))
+}
+
+func (r RRule) nextMonthly(prev time.Time) (time.Time, bool) {
+	days := r.ByMonthDay
+	if len(days) == 0 {
+		days = []int{prev.Day()}
+	}
+
+	year, month, _ := prev.Date()
+	hour, minute, second := prev.Clock()
+	for step := 0; step < maxRecurrenceSteps; step++ {
+		first := time.Date(year, month+time.Month(step*r.Interval), 1, hour, minute, second, prev.Nanosecond(), prev.Location())
+		length := first.AddDate(0, 1, -1).Day()
+
+		var candidates []int
+		for _, day := range days {
+			if day < 0 {
+				day = length + day + 1
+			}
+			// months too short for the day are skipped, as RFC 5545 requires
+			if day >= 1 && day <= length {
+				candidates = append(candidates, day)
+			}
+		}
+		slices.Sort(candidates)
+
+		for _, day := range candidates {
+			if next := first.AddDate(0, 0, day-1); next.After(prev) {
+				return next, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r RRule) nextYearly(prev time.Time) (time.Time, bool) {
+	year, month, day := prev.Date()
+	hour, minute, second := prev.Clock()
+	for step := 1; step < maxRecurrenceSteps; step++ {
+		next := time.Date(year+step*r.Interval, month, day, hour, minute, second, prev.Nanosecond(), prev.Location())
+		// February 29th only exists in leap years
+		if next.Day() == day {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
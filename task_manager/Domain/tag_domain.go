@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTagLength is the maximum number of characters in a tag
+const MaxTagLength = 50
+
+// TagCount is a tag along with the number of tasks carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// ParseTag normalizes a free-form tag to its stored form
+// Tags are trimmed and compared case-insensitively; returns ErrInvalidTag for an empty or too long tag
+func ParseTag(s string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(s))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", ErrInvalidTag
+	}
+	return tag, nil
+}
+
+// ParseTags normalizes every tag of tags, dropping duplicates while keeping the first occurrence
+func ParseTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	parsed := make([]string, 0, len(tags))
+	for _, s := range tags {
+		tag, err := ParseTag(s)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(parsed, tag) {
+			parsed = append(parsed, tag)
+		}
+	}
+	return parsed, nil
+}
@@ -8,31 +8,224 @@ import (
 // Task represents a task entity in the system
 type Task struct {
 	ID          string
+	ProjectID   string // ID of the project the task belongs to
 	Title       string
 	Description string
 	DueDate     time.Time
-	Status      string
+	Status      TaskStatus
+	Priority    TaskPriority    // Importance of the task, DefaultPriority when not given
+	OwnerID     string          // ID of the user who created the task
+	Assignees   []string        // IDs of the users the task is assigned to
+	Version     int             // Incremented on every change; as input, the version expected to be stored (0 skips the check)
+	DeletedAt   *time.Time      // Set when the task was moved to the trash
+	Recurrence  string          // RFC 5545 RRULE the series repeats on, empty for a one-off task
+	SeriesID    string          // ID shared by every occurrence of a recurring task
+	Occurrence  int             // 1-based position of the task in its series
+	ParentID    string          // ID of the task this one is a subtask of, empty for a top-level task
+	Position    int             // Order of a subtask among the subtasks of its parent
+	Checklist   []ChecklistItem // Lightweight steps of the task, in order
+	BlockedBy   []string        // IDs of the tasks that must be completed before this one
+	Tags        []string        // Free-form labels, normalized by ParseTag
+	Progress    *int            // Percentage of finished subtasks and checklist items, only computed when fetching a single task
+	Urgency     float64         // Score of UrgencyAt when the task was fetched, higher is more pressing
+}
+
+// TaskPatch holds the fields of a partial task update
+// Nil fields are left untouched
+type TaskPatch struct {
+	Title       *string
+	Description *string
+	DueDate     *time.Time
+	Status      *TaskStatus
+	Priority    *TaskPriority
+	Version     int // Version expected to be stored, 0 skips the check
+}
+
+// IsEmpty reports whether the patch does not change any field
+func (p *TaskPatch) IsEmpty() bool {
+	return p.Title == nil && p.Description == nil && p.DueDate == nil && p.Status == nil && p.Priority == nil
+}
+
+// Apply returns task with the fields present in the patch set
+func (p *TaskPatch) Apply(task Task) Task {
+	if p.Title != nil {
+		task.Title = *p.Title
+	}
+	if p.Description != nil {
+		task.Description = *p.Description
+	}
+	if p.DueDate != nil {
+		task.DueDate = *p.DueDate
+	}
+	if p.Status != nil {
+		task.Status = *p.Status
+	}
+	if p.Priority != nil {
+		task.Priority = *p.Priority
+	}
+	return task
+}
+
+// Fields a task listing can be sorted by
+const (
+	TaskSortByDueDate = "due_date"
+	TaskSortByTitle   = "title"
+	TaskSortByStatus  = "status"
+	TaskSortByUrgency = "urgency" // Most urgent first in ascending order, see Task.UrgentAt
+)
+
+// TaskQuery describes the filtering, sorting and pagination of a task listing
+type TaskQuery struct {
+	ProjectID  string     // Only tasks of this project, as asked for by the client
+	ProjectIDs []string   // Only tasks of these projects, as handed to the repository; nil means every task
+	Status     TaskStatus // Exact status to match
+	DueAfter   time.Time  // Lower bound (inclusive) of the due date, ignored when zero
+	DueBefore  time.Time  // Upper bound (inclusive) of the due date, ignored when zero
+	Title      string     // Case-insensitive substring of the title
+	Tags       []string   // Tags a task must all carry
+	AnyTags    []string   // Tags a task must carry at least one of
+	Involving  string     // Only tasks owned by or assigned to this user
+	SortBy     string     // One of the TaskSortBy fields; empty keeps insertion order
+	SortDesc   bool       // Sort in descending order
+	Limit      int        // Maximum number of tasks to return
+	Offset     int        // Number of matching tasks to skip
+	Cursor     string     // Opaque token of the previous page; the page starts after it
+	After      *Cursor    // Decoded Cursor handed to the repository
+}
+
+// TaskPage is one page of a task listing
+type TaskPage struct {
+	Tasks      []Task
+	Total      int // Number of tasks matching the query across all pages
+	Limit      int
+	Offset     int
+	NextCursor string // Token for the following page, empty on the last page
+}
+
+// TaskVersion identifies a task at one of its versions, without its content
+type TaskVersion struct {
+	ID      string
+	Version int
+}
+
+// TaskSearchQuery describes a full-text search over task titles and descriptions
+type TaskSearchQuery struct {
+	Text       string   // Search terms
+	ProjectIDs []string // Only tasks of these projects; nil means every task
+	Limit      int      // Maximum number of results to return
+}
+
+// TaskSearchResult is a task matched by a full-text search
+type TaskSearchResult struct {
+	Task       Task
+	Score      float64           // Relevance of the match, higher is better
+	Highlights map[string]string // Snippets of the matching fields with the terms wrapped in <mark> tags
 }
 
 // TaskRepository defines the interface for interacting with the task persistence layer
 type TaskRepository interface {
 	// Create inserts a new task into the data store
+	// Returns ErrOccurrenceExists if the series already holds a task at the same occurrence
 	Create(c context.Context, task *Task) error
+	// CreateMany inserts new tasks into the data store in a single write, setting their IDs
+	CreateMany(c context.Context, tasks []*Task) error
 	// FetchByTaskID retrieves a task by its unique ID
 	FetchByTaskID(c context.Context, taskID string) (Task, error)
-	// FetchAllTasks retrieves all tasks from the data store
-	FetchAllTasks(c context.Context) ([]Task, error)
-	// DeleteByTaskID removes a task by its ID, returning the number of documents deleted
-	DeleteByTaskID(c context.Context, taskID string) (int, error)
-	// UpdateByTaskID updates an existing task, returning matched and modified counts
-	UpdateByTaskID(c context.Context, task *Task) (int, int, error)
+	// FetchAllTasks retrieves a page of tasks matching the query along with the total match count
+	FetchAllTasks(c context.Context, query TaskQuery) ([]Task, int, error)
+	// StreamTasks calls fn with every task matching the filters of the query, in its sort order, without a page limit
+	// Stops at the first error returned by fn and returns it
+	StreamTasks(c context.Context, query TaskQuery, fn func(task Task) error) error
+	// FetchVersions retrieves the ID and version of every task matching the filters of the query, in ID order,
+	// ignoring its sort and pagination
+	FetchVersions(c context.Context, query TaskQuery) ([]TaskVersion, error)
+	// FetchByTaskIDs retrieves the live tasks among taskIDs, in no particular order
+	FetchByTaskIDs(c context.Context, taskIDs []string) ([]Task, error)
+	// FetchByAssignee retrieves the tasks assigned to userID
+	FetchByAssignee(c context.Context, userID string) ([]Task, error)
+	// DeleteByTaskID moves a task to the trash if its stored version equals version (any version when 0),
+	// returning the number of documents deleted; trashed tasks are excluded from every other method
+	DeleteByTaskID(c context.Context, taskID string, version int) (int, error)
+	// FetchDeleted retrieves the tasks in the trash
+	FetchDeleted(c context.Context) ([]Task, error)
+	// RestoreByTaskID moves a task out of the trash, returning the number of documents restored
+	RestoreByTaskID(c context.Context, taskID string) (int, error)
+	// PurgeDeleted permanently removes the tasks trashed before the given time, returning the IDs of the removed tasks
+	PurgeDeleted(c context.Context, before time.Time) ([]string, error)
+	// MarkMissed moves a task to missed if it is still pending, due before now and its stored version equals version
+	// (any version when 0), returning the number of documents matched
+	MarkMissed(c context.Context, taskID string, version int, now time.Time) (int, error)
+	// FetchPendingDueBetween retrieves the pending tasks due after from and no later than to
+	FetchPendingDueBetween(c context.Context, from time.Time, to time.Time) ([]Task, error)
+	// FetchSubtasks retrieves the live subtasks of a task in their order
+	FetchSubtasks(c context.Context, parentID string) ([]Task, error)
+	// UpdateSubtaskPositions orders the subtasks of parentID as listed in taskIDs,
+	// returning the number of subtasks matched
+	UpdateSubtaskPositions(c context.Context, parentID string, taskIDs []string) (int, error)
+	// UpdateChecklist replaces the checklist of a task if its stored version equals version (any version when 0),
+	// returning the number of documents matched
+	UpdateChecklist(c context.Context, taskID string, version int, checklist []ChecklistItem) (int, error)
+	// UpdateRecurrence sets the recurrence of the task and of every live task of seriesID, joining the task
+	// to the series; an empty recurrence stops the series. Returns the number of documents updated
+	UpdateRecurrence(c context.Context, taskID string, seriesID string, recurrence string) (int, error)
+	// UpdateByTaskID updates an existing task if it is still in status and its stored version equals task.Version
+	// (any version when 0), returning matched and modified counts; the version is incremented when a field changes
+	UpdateByTaskID(c context.Context, task *Task, status TaskStatus) (int, int, error)
+	// PatchByTaskID updates only the fields set in the patch under the same status and version rules as UpdateByTaskID,
+	// returning matched and modified counts
+	PatchByTaskID(c context.Context, taskID string, status TaskStatus, patch TaskPatch) (int, int, error)
+	// AddAssignee adds userID to the task's assignees and increments its version if its stored version equals version
+	// (any version when 0), returning the number of documents matched; the same rules apply to the array methods below
+	AddAssignee(c context.Context, taskID string, version int, userID string) (int, error)
+	// RemoveAssignee removes userID from the task's assignees, returning the number of documents matched
+	RemoveAssignee(c context.Context, taskID string, version int, userID string) (int, error)
+	// AddBlocker adds blockerID to the tasks blocking taskID, returning the number of documents matched
+	AddBlocker(c context.Context, taskID string, version int, blockerID string) (int, error)
+	// RemoveBlocker removes blockerID from the tasks blocking taskID, returning the number of documents matched
+	RemoveBlocker(c context.Context, taskID string, version int, blockerID string) (int, error)
+	// AddTag adds tag to the task's tags, returning the number of documents matched
+	AddTag(c context.Context, taskID string, version int, tag string) (int, error)
+	// RemoveTag removes tag from the task's tags, returning the number of documents matched
+	RemoveTag(c context.Context, taskID string, version int, tag string) (int, error)
+	// CountTags retrieves the tags of the live tasks of projectIDs (every task when nil)
+	// with the number of tasks carrying each, most used first
+	CountTags(c context.Context, projectIDs []string) ([]TagCount, error)
+	// UnassignFromProject removes userID from the assignees of every task of a project,
+	// returning the number of tasks updated
+	UnassignFromProject(c context.Context, projectID string, userID string) (int, error)
+	// Search retrieves the tasks whose title or description match the query, most relevant first
+	Search(c context.Context, query TaskSearchQuery) ([]TaskSearchResult, error)
 }
 
 // TaskUsecase defines the business logic layer for task-related operations
 type TaskUsecase interface {
-	Create(c context.Context, task *Task) error
-	FetchByTaskID(c context.Context, taskID string) (Task, error)
-	FetchAllTasks(c context.Context) ([]Task, error)
-	DeleteByTaskID(c context.Context, taskID string) error
-	UpdateByTaskID(c context.Context, task *Task) error
+	Create(c context.Context, task *Task, actor Actor) error
+	FetchByTaskID(c context.Context, taskID string, actor Actor) (Task, error)
+	FetchAllTasks(c context.Context, query TaskQuery, actor Actor) (TaskPage, error)
+	FetchHistory(c context.Context, taskID string, actor Actor) ([]TaskHistoryEntry, error)
+	DeleteByTaskID(c context.Context, taskID string, version int, actor Actor) error
+	FetchTrash(c context.Context) ([]Task, error)
+	RestoreByTaskID(c context.Context, taskID string, actor Actor) error
+	PurgeTrash(c context.Context) (int, error)
+	UpdateByTaskID(c context.Context, task *Task, actor Actor) error
+	PatchByTaskID(c context.Context, taskID string, patch TaskPatch, actor Actor) (Task, error)
+	AssignUser(c context.Context, taskID string, userID string, actor Actor) error
+	UnassignUser(c context.Context, taskID string, userID string, actor Actor) error
+	FetchAssignedTasks(c context.Context, userID string) ([]Task, error)
+	Search(c context.Context, query TaskSearchQuery, actor Actor) ([]TaskSearchResult, error)
+	SetRecurrence(c context.Context, taskID string, recurrence string, actor Actor) (Task, error)
+	StopRecurrence(c context.Context, taskID string, actor Actor) error
+	MarkOverdueMissed(c context.Context, now time.Time) (int, error)
+	FetchSubtasks(c context.Context, taskID string, actor Actor) ([]Task, error)
+	CreateSubtask(c context.Context, parentID string, task *Task, actor Actor) error
+	ReorderSubtasks(c context.Context, parentID string, taskIDs []string, actor Actor) ([]Task, error)
+	AddChecklistItem(c context.Context, taskID string, text string, actor Actor) (ChecklistItem, error)
+	UpdateChecklistItem(c context.Context, taskID string, itemID string, patch ChecklistItemPatch, actor Actor) (ChecklistItem, error)
+	RemoveChecklistItem(c context.Context, taskID string, itemID string, actor Actor) error
+	ReorderChecklist(c context.Context, taskID string, itemIDs []string, actor Actor) ([]ChecklistItem, error)
+	AddDependency(c context.Context, taskID string, blockerID string, actor Actor) error
+	RemoveDependency(c context.Context, taskID string, blockerID string, actor Actor) error
+	AddTag(c context.Context, taskID string, tag string, actor Actor) error
+	RemoveTag(c context.Context, taskID string, tag string, actor Actor) error
+	FetchTags(c context.Context, actor Actor) ([]TagCount, error)
 }
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// TaskPriority is how important a task is, regardless of when it is due
+type TaskPriority string
+
+// Known task priorities, from least to most important
+const (
+	PriorityLow      TaskPriority = "low"
+	PriorityMedium   TaskPriority = "medium"
+	PriorityHigh     TaskPriority = "high"
+	PriorityCritical TaskPriority = "critical"
+)
+
+// DefaultPriority is given to tasks created without a priority and assumed for tasks stored before priorities existed
+const DefaultPriority = PriorityMedium
+
+// priorityLeads tells how much earlier than its due date a task of each priority counts as due
+// A critical task due in a week is as urgent as a low priority task due today
+var priorityLeads = map[TaskPriority]time.Duration{
+	PriorityLow:      0,
+	PriorityMedium:   2 * 24 * time.Hour,
+	PriorityHigh:     4 * 24 * time.Hour,
+	PriorityCritical: 7 * 24 * time.Hour,
+}
+
+// ParseTaskPriority normalizes s and checks it is a known priority
+// Returns ErrInvalidPriority for anything else
+func ParseTaskPriority(s string) (TaskPriority, error) {
+	priority := TaskPriority(strings.ToLower(strings.TrimSpace(s)))
+	if !priority.IsValid() {
+		return "", ErrInvalidPriority
+	}
+	return priority, nil
+}
+
+// IsValid reports whether the priority is one of the known priorities
+func (p TaskPriority) IsValid() bool {
+	_, ok := priorityLeads[p]
+	return ok
+}
+
+// Lead returns how much earlier than its due date a task of priority p counts as due
+// Unknown priorities lead like DefaultPriority
+func (p TaskPriority) Lead() time.Duration {
+	lead, ok := priorityLeads[p]
+	if !ok {
+		return priorityLeads[DefaultPriority]
+	}
+	return lead
+}
+
+// PriorityLeads lists the lead of every known priority
+func PriorityLeads() map[TaskPriority]time.Duration {
+	leads := make(map[TaskPriority]time.Duration, len(priorityLeads))
+	for priority, lead := range priorityLeads {
+		leads[priority] = lead
+	}
+	return leads
+}
+
+// UrgentAt returns the due date of the task moved earlier by the lead of its priority
+// Ordering tasks by it is the same as ordering them by urgency, most urgent first, at any point in time
+func (t *Task) UrgentAt() time.Time {
+	return t.DueDate.Add(-t.Priority.Lead())
+}
+
+// UrgencyAt scores how pressing the task is at now, in days
+// The score grows by one every day and is 0 when the task becomes urgent, i.e. its priority lead before the due date
+func (t *Task) UrgencyAt(now time.Time) float64 {
+	days := now.Sub(t.UrgentAt()).Hours() / 24
+	return math.Round(days*100) / 100
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskStatus is the lifecycle state of a task
+type TaskStatus string
+
+// Known task statuses
+const (
+	StatusPending   TaskStatus = "pending"
+	StatusCompleted TaskStatus = "completed"
+	StatusMissed    TaskStatus = "missed"
+)
+
+// taskStatusTransitions lists the statuses each status may move to
+// A completed task has to be reopened (moved back to pending) before it can be marked missed
+var taskStatusTransitions = map[TaskStatus][]TaskStatus{
+	StatusPending:   {StatusCompleted, StatusMissed},
+	StatusMissed:    {StatusPending, StatusCompleted},
+	StatusCompleted: {StatusPending},
+}
+
+// ParseTaskStatus normalizes s and checks it is a known status
+// Returns ErrInvalidStatus for anything else
+func ParseTaskStatus(s string) (TaskStatus, error) {
+	status := TaskStatus(strings.ToLower(strings.TrimSpace(s)))
+	if !status.IsValid() {
+		return "", ErrInvalidStatus
+	}
+	return status, nil
+}
+
+// IsValid reports whether the status is one of the known statuses
+func (s TaskStatus) IsValid() bool {
+	_, ok := taskStatusTransitions[s]
+	return ok
+}
+
+// TransitionTo checks that a task in status s may move to next
+// Staying in the same status is always allowed, and so is leaving an unknown status stored by older versions
+// Returns an error wrapping ErrInvalidStatusTransition otherwise
+func (s TaskStatus) TransitionTo(next TaskStatus) error {
+	if s == next || !s.IsValid() {
+		return nil
+	}
+	for _, allowed := range taskStatusTransitions[s] {
+		if allowed == next {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, s, next)
+}
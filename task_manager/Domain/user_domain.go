@@ -10,6 +10,30 @@ type User struct {
 	Username string // Username of the user
 	Password string // Hashed password (excluded from JSON responses)
 	IsAdmin  bool   // Flag indicating if the user is an admin
+	Email    string // Address notifications are sent to, optional
+}
+
+// Actor identifies the authenticated user on whose behalf an operation runs
+type Actor struct {
+	ID      string // ID of the authenticated user
+	IsAdmin bool   // Whether the user currently holds admin rights
+}
+
+// SystemActor is the actor of the changes background jobs make on their own, such as the overdue sweep
+var SystemActor = Actor{ID: "system", IsAdmin: true}
+
+// UserQuery describes the pagination of a user listing
+// Users are listed in insertion order
+type UserQuery struct {
+	Limit  int     // Maximum number of users to return
+	Cursor string  // Opaque token of the previous page; the page starts after it
+	After  *Cursor // Decoded Cursor handed to the repository
+}
+
+// UserPage is one page of a user listing
+type UserPage struct {
+	Users      []User
+	NextCursor string // Token for the following page, empty on the last page
 }
 
 // UserRepository defines the interface for interacting with the user persistence layer
@@ -20,8 +44,8 @@ type UserRepository interface {
 	FetchByUserID(c context.Context, userID string) (User, error)
 	// FetchByUsername retrieves a user by their username
 	FetchByUsername(c context.Context, username string) (User, error)
-	// FetchAllUsers retrieves all users from the data store
-	FetchAllUsers(c context.Context) ([]User, error)
+	// FetchAllUsers retrieves a page of users from the data store
+	FetchAllUsers(c context.Context, query UserQuery) ([]User, error)
 	// PromoteByUserID sets the IsAdmin flag to true for the specified user
 	PromoteByUserID(c context.Context, userID string) (int, error)
 
@@ -36,7 +60,7 @@ type UserUsecase interface {
 	Create(c context.Context, user *User) error
 	FetchByUserID(c context.Context, userID string) (User, error)
 	FetchByUsername(c context.Context, username string) (User, error)
-	FetchAllUsers(c context.Context) ([]User, error)
+	FetchAllUsers(c context.Context, query UserQuery) (UserPage, error)
 	PromoteByUserID(c context.Context, userID string) error
 	CountUsers(c context.Context) (int, error)
 	CheckIfUsernameExists(c context.Context, username string) (bool, error)
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Task events webhooks can subscribe to
+const (
+	WebhookEventTaskCreated       = "task.created"
+	WebhookEventTaskUpdated       = "task.updated"
+	WebhookEventTaskDeleted       = "task.deleted"
+	WebhookEventTaskStatusChanged = "task.status_changed" // Sent along with task.updated when the status changed
+)
+
+// ParseWebhookEvents validates the event types a webhook subscribes to, dropping duplicates
+// Matching is case-insensitive and ignores surrounding spaces
+func ParseWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, ErrInvalidWebhookEvent
+	}
+	parsed := make([]string, 0, len(events))
+	seen := make(map[string]bool)
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		switch event {
+		case WebhookEventTaskCreated, WebhookEventTaskUpdated, WebhookEventTaskDeleted, WebhookEventTaskStatusChanged:
+		default:
+			return nil, ErrInvalidWebhookEvent
+		}
+		if !seen[event] {
+			seen[event] = true
+			parsed = append(parsed, event)
+		}
+	}
+	return parsed, nil
+}
+
+// Webhook is a URL the task events it subscribes to are posted to
+type Webhook struct {
+	ID        string
+	URL       string
+	Events    []string  // Event types delivered to the URL
+	Secret    string    // Key of the HMAC-SHA256 signature of every delivery, only returned when the webhook is created
+	Active    bool      // Inactive webhooks receive no new deliveries
+	CreatedBy string    // ID of the admin who created the webhook
+	CreatedAt time.Time // When the webhook was created
+}
+
+// TaskEvent is a change made to a task, as published to the webhooks
+type TaskEvent struct {
+	Type       string        // One of the WebhookEvent constants
+	Task       Task          // Task after the change, or before it for deletes
+	ActorID    string        // ID of the user who made the change
+	Changes    []FieldChange // Field-level diff of the change, empty for deletes
+	OccurredAt time.Time     // When the change was made
+}
+
+// TaskEventPublisher hands task events to the webhooks subscribed to them
+type TaskEventPublisher interface {
+	// Publish queues a delivery of the event to every active webhook subscribed to its type
+	Publish(c context.Context, event TaskEvent) error
+}
+
+// WebhookDeliveryStatus is the state of a delivery in the queue
+type WebhookDeliveryStatus string
+
+// States of a delivery
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its next attempt
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded" // The URL answered with a 2xx status
+	DeliveryFailed    WebhookDeliveryStatus = "failed"    // Every attempt failed, or the webhook is gone or inactive
+)
+
+// WebhookAttempt records one request made for a delivery
+type WebhookAttempt struct {
+	At         time.Time     // When the request was sent
+	StatusCode int           // Status of the response, 0 when none was received
+	Error      string        // Why the attempt failed, empty when it succeeded
+	Duration   time.Duration // How long the request took
+}
+
+// WebhookDelivery is the delivery of one event to one webhook, kept as its log
+type WebhookDelivery struct {
+	ID            string
+	WebhookID     string
+	EventID       string          // Identifies the event, shared by its deliveries to every webhook and by redeliveries
+	Event         string          // Type of the event
+	Payload       json.RawMessage // JSON body posted to the URL
+	Status        WebhookDeliveryStatus
+	Attempts      []WebhookAttempt // Requests made so far, oldest first
+	NextAttemptAt time.Time        // When a pending delivery is attempted next
+	RedeliveryOf  string           // ID of the delivery this one repeats, empty for the first delivery of an event
+	CreatedAt     time.Time
+}
+
+// WebhookRepository defines the interface for interacting with the webhook persistence layer
+type WebhookRepository interface {
+	// Create inserts a new webhook and sets its ID
+	Create(c context.Context, webhook *Webhook) error
+	// FetchByID retrieves a webhook by its ID
+	FetchByID(c context.Context, webhookID string) (Webhook, error)
+	// FetchAll retrieves every webhook, oldest first
+	FetchAll(c context.Context) ([]Webhook, error)
+	// FetchActiveByEvent retrieves the active webhooks subscribed to an event type
+	FetchActiveByEvent(c context.Context, event string) ([]Webhook, error)
+	// Update replaces the URL, events, secret and state of a webhook, returning the number of matched webhooks
+	Update(c context.Context, webhook *Webhook) (int, error)
+	// Delete removes a webhook and returns the number of deleted webhooks
+	Delete(c context.Context, webhookID string) (int, error)
+}
+
+// WebhookDeliveryRepository defines the interface for the persisted queue of webhook deliveries
+type WebhookDeliveryRepository interface {
+	// CreateMany inserts new deliveries and sets their IDs
+	CreateMany(c context.Context, deliveries []*WebhookDelivery) error
+	// FetchByID retrieves a delivery by its ID
+	FetchByID(c context.Context, deliveryID string) (WebhookDelivery, error)
+	// FetchByWebhookID retrieves the latest deliveries of a webhook, newest first
+	FetchByWebhookID(c context.Context, webhookID string, limit int) ([]WebhookDelivery, error)
+	// ClaimDue takes the pending delivery due the longest by now, pushing its next attempt back by lease
+	// so no other dispatcher takes it meanwhile; returns ErrNoWebhookDeliveryDue when none is due
+	ClaimDue(c context.Context, now time.Time, lease time.Duration) (WebhookDelivery, error)
+	// RecordAttempt appends an attempt to a delivery and sets its status and next attempt
+	RecordAttempt(c context.Context, deliveryID string, attempt WebhookAttempt, status WebhookDeliveryStatus, nextAttemptAt time.Time) error
+}
+
+// WebhookSender posts deliveries to the URLs of their webhooks
+type WebhookSender interface {
+	// Send posts the payload of the delivery signed with the secret of the webhook
+	// Returns the status of the response, with an error unless it is a 2xx status
+	Send(c context.Context, webhook Webhook, delivery WebhookDelivery) (int, error)
+}
+
+// WebhookUsecase defines the business logic layer for managing webhooks, reserved to admins
+type WebhookUsecase interface {
+	// Create validates and stores a webhook, generating its secret when none is given
+	Create(c context.Context, webhook *Webhook, actor Actor) error
+	FetchAll(c context.Context) ([]Webhook, error)
+	FetchByID(c context.Context, webhookID string) (Webhook, error)
+	// Update replaces the URL, events and state of a webhook, and its secret when one is given
+	Update(c context.Context, webhook *Webhook) error
+	Delete(c context.Context, webhookID string) error
+	// FetchDeliveries retrieves the latest deliveries of a webhook, newest first
+	FetchDeliveries(c context.Context, webhookID string, limit int) ([]WebhookDelivery, error)
+	// Redeliver queues a new delivery of the payload of a past one
+	Redeliver(c context.Context, webhookID string, deliveryID string) (WebhookDelivery, error)
+}
@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// systemClock implements domain.Clock using the wall clock
+type systemClock struct{}
+
+// NewSystemClock returns a domain.Clock backed by the time package
+func NewSystemClock() domain.Clock {
+	return systemClock{}
+}
+
+// Now returns the current local time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for d to elapse and then sends the current time on the returned channel
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
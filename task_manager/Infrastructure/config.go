@@ -3,19 +3,55 @@ package infrastructure
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	domain "github.com/A2SVTask7/Domain"
+
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	MongoURI       string
-	CollectionTask string
-	CollectionUser string
-	JWTSecret      string
-	DBName         string
-	Port           string
-	Timeout        time.Duration
+	MongoURI             string
+	CollectionTask       string
+	CollectionUser       string
+	CollectionHistory    string
+	CollectionReminder   string
+	CollectionComment    string
+	CollectionProject    string
+	CollectionAttachment string
+	CollectionCalendar   string
+	CollectionWebhook    string
+	CollectionDelivery   string
+	JWTSecret            string
+	CursorSecret         string
+	DBName               string
+	Port                 string
+	Timeout              time.Duration
+	BatchTimeout         time.Duration
+	TrashRetention       time.Duration
+	OverdueInterval      time.Duration
+	ReminderLead         time.Duration
+	ReminderInterval     time.Duration
+	WebhookInterval      time.Duration
+	WebhookBackoff       time.Duration
+	WebhookMaxAttempts   int
+	Notifier             string // "log" or "smtp"
+	SMTPHost             string
+	SMTPPort             string
+	SMTPUsername         string
+	SMTPPassword         string
+	SMTPFrom             string
+	BlobStore            string // "local" or "s3"
+	BlobDir              string
+	S3Endpoint           string
+	S3Region             string
+	S3Bucket             string
+	S3AccessKey          string
+	S3SecretKey          string
+	AttachmentMaxSize    int64
+	AttachmentTypes      []string
 }
 
 var AppConfig Config
@@ -27,14 +63,38 @@ func LoadConfig() {
 	}
 
 	AppConfig = Config{
-		MongoURI:       getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		CollectionTask: getEnv("COLLECTION_TASK", "tasks"),
-		CollectionUser: getEnv("COLLECTION_USER", "users"),
-		JWTSecret:      getEnv("JWT_SECRET", "supersecretkey"),
-		DBName:         getEnv("DBName", "managers"),
-		Port:           getEnv("Port", "8080"),
+		MongoURI:             getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		CollectionTask:       getEnv("COLLECTION_TASK", "tasks"),
+		CollectionUser:       getEnv("COLLECTION_USER", "users"),
+		CollectionHistory:    getEnv("COLLECTION_HISTORY", "task_history"),
+		CollectionReminder:   getEnv("COLLECTION_REMINDER", "reminders"),
+		CollectionComment:    getEnv("COLLECTION_COMMENT", "comments"),
+		CollectionProject:    getEnv("COLLECTION_PROJECT", "projects"),
+		CollectionAttachment: getEnv("COLLECTION_ATTACHMENT", "attachments"),
+		CollectionCalendar:   getEnv("COLLECTION_CALENDAR", "calendar_tokens"),
+		CollectionWebhook:    getEnv("COLLECTION_WEBHOOK", "webhooks"),
+		CollectionDelivery:   getEnv("COLLECTION_WEBHOOK_DELIVERY", "webhook_deliveries"),
+		JWTSecret:            getEnv("JWT_SECRET", "supersecretkey"),
+		DBName:               getEnv("DBName", "managers"),
+		Port:                 getEnv("Port", "8080"),
+		Notifier:             getEnv("NOTIFIER", "log"),
+		SMTPHost:             getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:             getEnv("SMTP_PORT", "25"),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:             getEnv("SMTP_FROM", "tasks@localhost"),
+		BlobStore:            getEnv("BLOB_STORE", "local"),
+		BlobDir:              getEnv("BLOB_DIR", "data/attachments"),
+		S3Endpoint:           getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+		S3Region:             getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:             getEnv("S3_BUCKET", ""),
+		S3AccessKey:          getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:          getEnv("S3_SECRET_KEY", ""),
 	}
 
+	// pagination cursors are signed with the JWT secret unless a dedicated one is set
+	AppConfig.CursorSecret = getEnv("CURSOR_SECRET", AppConfig.JWTSecret)
+
 	// set the timeout
 	timeoutStr := getEnv("APP_TIMEOUT", "5s")
 	timeout, err := time.ParseDuration(timeoutStr)
@@ -44,6 +104,84 @@ func LoadConfig() {
 	}
 	AppConfig.Timeout = timeout
 
+	// set the deadline of the transaction of an atomic batch, MongoDB aborts transactions older than a minute by default
+	AppConfig.BatchTimeout = getDuration("BATCH_TIMEOUT", time.Minute)
+
+	// set how long deleted tasks are kept before they can be purged
+	// a zero or negative retention would let the next purge empty the whole trash
+	AppConfig.TrashRetention = getDuration("TRASH_RETENTION", 720*time.Hour)
+
+	// set how often overdue tasks are marked as missed
+	AppConfig.OverdueInterval = getDuration("OVERDUE_INTERVAL", time.Minute)
+
+	// set when and how often due-date reminders are sent
+	AppConfig.ReminderLead = getDuration("REMINDER_LEAD", 24*time.Hour)
+	AppConfig.ReminderInterval = getDuration("REMINDER_INTERVAL", 5*time.Minute)
+
+	// set how often webhook deliveries are sent and how failed ones are retried
+	AppConfig.WebhookInterval = getDuration("WEBHOOK_INTERVAL", 10*time.Second)
+	AppConfig.WebhookBackoff = getDuration("WEBHOOK_BACKOFF", 30*time.Second)
+	AppConfig.WebhookMaxAttempts = getInt("WEBHOOK_MAX_ATTEMPTS", 8)
+
+	// set which files can be attached to tasks
+	AppConfig.AttachmentMaxSize = getSize("ATTACHMENT_MAX_SIZE", domain.DefaultMaxAttachmentSize)
+	AppConfig.AttachmentTypes = getList("ATTACHMENT_TYPES", domain.DefaultAttachmentTypes)
+}
+
+// getSize reads a positive number of bytes from the environment, falling back on a missing or invalid value
+func getSize(key string, fallback int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		log.Printf("Invalid %s %q, defaulting to %d", key, value, fallback)
+		return fallback
+	}
+	return size
+}
+
+// getInt reads a positive integer from the environment, falling back on a missing or invalid value
+func getInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, defaulting to %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getList reads a comma-separated list from the environment, falling back on a missing or empty value
+func getList(key string, fallback []string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	if len(list) == 0 {
+		return fallback
+	}
+	return list
+}
+
+// getDuration reads a positive Go duration from the environment, falling back on a missing or invalid value
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, defaulting to %s", key, value, fallback)
+		return fallback
+	}
+	return d
 }
 
 func getEnv(key, fallback string) string {
@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// cursorService is an HMAC-SHA256 based implementation of domain.CursorService
+// Tokens have the form base64url(payload) "." base64url(signature)
+type cursorService struct {
+	secret []byte // Secret key used to sign and verify cursors
+}
+
+// NewCursorService creates a new instance of cursorService using the provided secret
+func NewCursorService(secret string) domain.CursorService {
+	return &cursorService{
+		secret: []byte(secret),
+	}
+}
+
+// Encode serializes and signs a cursor into an opaque token
+func (cs *cursorService) Encode(cursor domain.Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(cs.sign(encoded)), nil
+}
+
+// Decode verifies the signature of a token and deserializes the cursor it carries
+func (cs *cursorService) Decode(token string) (domain.Cursor, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return domain.Cursor{}, domain.ErrInvalidCursor
+	}
+
+	// reject tokens that were not issued by us or were altered
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, cs.sign(encoded)) {
+		return domain.Cursor{}, domain.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return domain.Cursor{}, domain.ErrInvalidCursor
+	}
+
+	var cursor domain.Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID == "" {
+		return domain.Cursor{}, domain.ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// sign computes the HMAC of the encoded payload
+func (cs *cursorService) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, cs.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,99 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// localBlobStore keeps blobs as files below a root directory, one file per key
+type localBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore returns a BlobStore writing below root, creating the directory if needed
+func NewLocalBlobStore(root string) (domain.BlobStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &localBlobStore{root: root}, nil
+}
+
+// Put writes the content to a temporary file first so a failed upload never replaces a complete blob
+func (s *localBlobStore) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	written, err := io.Copy(tmp, io.LimitReader(content, size))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return fmt.Errorf("blob %s: expected %d bytes, got %d", key, size, written)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get opens the file of a blob, returning ErrBlobNotFound if there is none
+func (s *localBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	return file, err
+}
+
+// Delete removes the file of a blob, succeeding if it is already gone
+func (s *localBlobStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// drop the directory of the task once its last blob is gone; fails harmlessly while it is not empty
+	if dir := filepath.Dir(name); dir != filepath.Clean(s.root) {
+		_ = os.Remove(dir)
+	}
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would escape it
+func (s *localBlobStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return "", domain.ErrInvalidBlobKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." || strings.HasPrefix(segment, ".upload-") {
+			return "", domain.ErrInvalidBlobKey
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,23 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// logNotifier implements domain.Notifier by writing notifications to the standard logger
+// It is meant for development and for deployments without a mail server
+type logNotifier struct{}
+
+// NewLogNotifier creates a new instance of logNotifier
+func NewLogNotifier() domain.Notifier {
+	return logNotifier{}
+}
+
+// Notify logs the notification together with its recipient
+func (logNotifier) Notify(_ context.Context, n domain.Notification) error {
+	log.Printf("notification for user %s (%s): %s\n%s", n.Recipient.ID, n.Recipient.Username, n.Subject, n.Body)
+	return nil
+}
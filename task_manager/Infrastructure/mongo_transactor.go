@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoTransactor is a MongoDB session based implementation of domain.Transactor
+// Repositories join the transaction through the context handed to the unit of work
+type mongoTransactor struct {
+	client *mongo.Client // Client the sessions are started from
+
+	mu        sync.Mutex // Guards checked and supported
+	checked   bool       // Whether the deployment was inspected already
+	supported bool       // Whether the deployment can run transactions
+}
+
+// NewMongoTransactor creates a new instance of mongoTransactor
+func NewMongoTransactor(client *mongo.Client) domain.Transactor {
+	return &mongoTransactor{
+		client: client,
+	}
+}
+
+// WithTransaction runs fn in a transaction of a new session
+// Transient errors restart the whole transaction, as the driver recommends
+func (mt *mongoTransactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	supported, err := mt.supportsTransactions(c)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return domain.ErrTransactionsUnsupported
+	}
+
+	session, err := mt.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(c)
+
+	_, err = session.WithTransaction(c, func(ctx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
+
+// supportsTransactions reports whether the deployment is a replica set or a sharded cluster
+// Standalone servers reject transactions; the answer is cached once the server was reached
+func (mt *mongoTransactor) supportsTransactions(ctx context.Context) (bool, error) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	if mt.checked {
+		return mt.supported, nil
+	}
+
+	var hello struct {
+		SetName string `bson:"setName"` // Set by replica set members
+		Msg     string `bson:"msg"`     // "isdbgrid" on mongos routers
+	}
+	if err := mt.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	mt.checked = true
+	mt.supported = hello.SetName != "" || hello.Msg == "isdbgrid"
+	return mt.supported, nil
+}
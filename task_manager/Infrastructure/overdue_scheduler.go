@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// OverdueScheduler periodically marks the pending tasks whose due date has passed as missed
+type OverdueScheduler struct {
+	taskUsecase domain.TaskUsecase // Usecase marking overdue tasks and generating the next occurrence of recurring ones
+	clock       domain.Clock       // Source of the current time and of the ticks
+	interval    time.Duration      // Time between two sweeps
+	timeout     time.Duration      // Timeout of a single sweep
+}
+
+// NewOverdueScheduler creates a new OverdueScheduler
+func NewOverdueScheduler(taskUsecase domain.TaskUsecase, clock domain.Clock, interval time.Duration, timeout time.Duration) *OverdueScheduler {
+	return &OverdueScheduler{
+		taskUsecase: taskUsecase,
+		clock:       clock,
+		interval:    interval,
+		timeout:     timeout,
+	}
+}
+
+// Run sweeps once immediately and then every interval until ctx is cancelled
+// Failed sweeps are logged and retried on the next tick
+func (s *OverdueScheduler) Run(ctx context.Context) {
+	runPeriodically(ctx, s.clock, s.interval, "marking overdue tasks as missed", func(ctx context.Context) error {
+		_, err := s.RunOnce(ctx)
+		return err
+	})
+}
+
+// RunOnce marks every pending task due before the current time as missed
+// Returns the number of tasks updated
+func (s *OverdueScheduler) RunOnce(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, s.timeout)
+	defer cancel()
+
+	count, err := s.taskUsecase.MarkOverdueMissed(ctx, s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		log.Printf("marked %d overdue task(s) as missed", count)
+	}
+	return count, nil
+}
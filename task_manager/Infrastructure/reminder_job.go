@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// ReminderJob periodically notifies the owner and the assignees of pending tasks
+// that are due within the lead time
+// Every reminder is claimed in the ReminderRepository before it is sent, so none is sent twice
+type ReminderJob struct {
+	taskRepository     domain.TaskRepository     // Repository used to find the tasks due soon
+	userRepository     domain.UserRepository     // Repository used to look up the recipients
+	reminderRepository domain.ReminderRepository // Repository tracking the reminders already sent
+	notifier           domain.Notifier           // Channel the reminders are delivered through
+	clock              domain.Clock              // Source of the current time and of the ticks
+	leadTime           time.Duration             // How long before the due date reminders go out
+	interval           time.Duration             // Time between two sweeps
+	timeout            time.Duration             // Timeout of each repository call and each notification
+}
+
+// NewReminderJob creates a new ReminderJob
+func NewReminderJob(taskRepository domain.TaskRepository, userRepository domain.UserRepository, reminderRepository domain.ReminderRepository, notifier domain.Notifier, clock domain.Clock, leadTime time.Duration, interval time.Duration, timeout time.Duration) *ReminderJob {
+	return &ReminderJob{
+		taskRepository:     taskRepository,
+		userRepository:     userRepository,
+		reminderRepository: reminderRepository,
+		notifier:           notifier,
+		clock:              clock,
+		leadTime:           leadTime,
+		interval:           interval,
+		timeout:            timeout,
+	}
+}
+
+// Run sends reminders once immediately and then every interval until ctx is cancelled
+func (j *ReminderJob) Run(ctx context.Context) {
+	runPeriodically(ctx, j.clock, j.interval, "sending due-date reminders", func(ctx context.Context) error {
+		_, err := j.RunOnce(ctx)
+		return err
+	})
+}
+
+// RunOnce sends the reminders of every pending task due within the lead time
+// A failed notification is released so it is attempted again on the next run
+// Returns the number of reminders sent along with the failures joined together
+func (j *ReminderJob) RunOnce(ctx context.Context) (int, error) {
+	now := j.clock.Now()
+
+	fetchCtx, cancel := context.WithTimeout(ctx, j.timeout)
+	tasks, err := j.taskRepository.FetchPendingDueBetween(fetchCtx, now, now.Add(j.leadTime))
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, task := range tasks {
+		for _, userID := range reminderRecipients(task) {
+			ok, err := j.remind(ctx, task, userID, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("task %s, user %s: %w", task.ID, userID, err))
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// remind sends the reminder of task to a single user unless it was already sent
+// Returns whether a notification went out
+func (j *ReminderJob) remind(c context.Context, task domain.Task, userID string, now time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(c, j.timeout)
+	defer cancel()
+
+	claimed, err := j.reminderRepository.Claim(ctx, task.ID, userID, task.DueDate)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	user, err := j.userRepository.FetchByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrInvalidUserID) {
+		// nobody to remind, keep the claim so the lookup is not repeated
+		return false, nil
+	}
+	if err != nil {
+		return false, j.release(ctx, task, userID, err)
+	}
+
+	err = j.notifier.Notify(ctx, reminderNotification(task, user, now))
+	if errors.Is(err, domain.ErrNoContactAddress) {
+		log.Printf("user %s has no address, skipping reminder for task %s", userID, task.ID)
+		return false, nil
+	}
+	if err != nil {
+		return false, j.release(ctx, task, userID, err)
+	}
+	return true, nil
+}
+
+// release gives up the claim of a reminder that could not be sent and returns the cause
+func (j *ReminderJob) release(ctx context.Context, task domain.Task, userID string, cause error) error {
+	if err := j.reminderRepository.Release(ctx, task.ID, userID, task.DueDate); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to release reminder: %w", err))
+	}
+	return cause
+}
+
+// reminderRecipients lists the owner and the assignees of a task without duplicates
+func reminderRecipients(task domain.Task) []string {
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, id := range append([]string{task.OwnerID}, task.Assignees...) {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		recipients = append(recipients, id)
+	}
+	return recipients
+}
+
+// reminderNotification builds the reminder sent to user for task
+func reminderNotification(task domain.Task, user domain.User, now time.Time) domain.Notification {
+	left := task.DueDate.Sub(now).Round(time.Minute)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.Username)
+	fmt.Fprintf(&body, "The task %q is due on %s, in %s.\n", task.Title, task.DueDate.Format(time.RFC1123), left)
+	if task.Description != "" {
+		fmt.Fprintf(&body, "\n%s\n", task.Description)
+	}
+
+	return domain.Notification{
+		Recipient: user,
+		Subject:   fmt.Sprintf("Reminder: %s is due soon", task.Title),
+		Body:      body.String(),
+	}
+}
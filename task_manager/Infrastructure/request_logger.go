@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// calendarFeedPrefix starts the path of every calendar feed, whose last segment is the secret token of the feed
+const calendarFeedPrefix = "/calendar/"
+
+// RequestLogger logs every request to out in the format of gin's default logger,
+// with the token of the calendar feed paths masked so that access logs do not leak it
+func RequestLogger(out io.Writer) gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Output: out,
+		Formatter: func(params gin.LogFormatterParams) string {
+			return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+				params.TimeStamp.Format("2006/01/02 - 15:04:05"),
+				params.StatusCode,
+				params.Latency,
+				params.ClientIP,
+				params.Method,
+				redactPath(params.Path),
+				params.ErrorMessage,
+			)
+		},
+	})
+}
+
+// redactPath replaces the token of a calendar feed path, query included, with a placeholder
+func redactPath(path string) string {
+	if strings.HasPrefix(path, calendarFeedPrefix) {
+		return calendarFeedPrefix + "[redacted]"
+	}
+	return path
+}
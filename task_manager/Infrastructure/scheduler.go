@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// runPeriodically calls job once immediately and then every interval until ctx is cancelled
+// Failed runs are logged under name and retried on the next tick
+func runPeriodically(ctx context.Context, clock domain.Clock, interval time.Duration, name string, job func(context.Context) error) {
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("%s failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(interval):
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// smtpNotifier implements domain.Notifier by sending plain text emails through an SMTP server
+type smtpNotifier struct {
+	host string    // Host name of the SMTP server
+	addr string    // host:port of the SMTP server
+	from string    // Sender address of every email
+	auth smtp.Auth // Credentials for the server, nil when it accepts unauthenticated mail
+}
+
+// NewSMTPNotifier creates a new instance of smtpNotifier
+// Authentication is only attempted when username is set; STARTTLS is used whenever the server offers it
+func NewSMTPNotifier(host string, port string, username string, password string, from string) domain.Notifier {
+	n := &smtpNotifier{
+		host: host,
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+	if username != "" {
+		n.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return n
+}
+
+// Notify emails the notification to the recipient's address
+// Returns ErrNoContactAddress if the recipient has no email address
+func (n *smtpNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	to := notification.Recipient.Email
+	if to == "" {
+		return domain.ErrNoContactAddress
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if n.auth != nil {
+		if err := client.Auth(n.auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.message(to, notification)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message renders the notification as an RFC 5322 email
+func (n *smtpNotifier) message(to string, notification domain.Notification) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(notification.Body)
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
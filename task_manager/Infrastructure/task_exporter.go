@@ -0,0 +1,274 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// icsProductID identifies this application in the PRODID of iCalendar exports
+const icsProductID = "-//A2SV//Task Manager//EN"
+
+// icsUIDDomain makes the UID of exported tasks globally unique, as RFC 5545 asks
+const icsUIDDomain = "task-manager"
+
+// icsTimeFormat is the UTC form of the iCalendar DATE-TIME value type
+const icsTimeFormat = "20060102T150405Z"
+
+// icsFeedRefresh is how often subscribed calendar apps are asked to poll a feed
+const icsFeedRefresh = "PT15M"
+
+// icsLineLength is the longest iCalendar content line in octets, longer lines are folded
+const icsLineLength = 75
+
+// csvExportColumns are the columns of CSV exports; the columns shared with imports use the same names
+var csvExportColumns = []string{"id", "project_id", "title", "description", "due_date", "status", "priority", "rrule", "tags", "owner_id", "assignees", "parent_id", "version"}
+
+// icsStatuses maps task statuses to the STATUS of a VTODO
+// A missed task is still to be done, which iCalendar has no better status for
+var icsStatuses = map[domain.TaskStatus]string{
+	domain.StatusPending:   "NEEDS-ACTION",
+	domain.StatusCompleted: "COMPLETED",
+	domain.StatusMissed:    "NEEDS-ACTION",
+}
+
+// icsPriorities maps task priorities to the PRIORITY of a VTODO, where 1 is the highest and 9 the lowest
+var icsPriorities = map[domain.TaskPriority]int{
+	domain.PriorityCritical: 1,
+	domain.PriorityHigh:     3,
+	domain.PriorityMedium:   5,
+	domain.PriorityLow:      9,
+}
+
+// taskExporter implements domain.TaskExporter for CSV, JSON Lines and iCalendar
+type taskExporter struct {
+	clock domain.Clock // Gives the DTSTAMP of iCalendar exports
+}
+
+// NewTaskExporter creates a new instance of taskExporter
+func NewTaskExporter(clock domain.Clock) domain.TaskExporter {
+	return &taskExporter{
+		clock: clock,
+	}
+}
+
+// NewEncoder returns an encoder writing tasks to w in the given format
+func (te *taskExporter) NewEncoder(format domain.ExportFormat, w io.Writer) (domain.TaskEncoder, error) {
+	switch format {
+	case domain.ExportCSV:
+		return &csvTaskEncoder{writer: csv.NewWriter(w)}, nil
+	case domain.ExportJSONL:
+		return &jsonlTaskEncoder{encoder: json.NewEncoder(w)}, nil
+	case domain.ExportICS:
+		return &icsTaskEncoder{writer: bufio.NewWriter(w), stamp: te.clock.Now().UTC().Format(icsTimeFormat)}, nil
+	default:
+		return nil, domain.ErrInvalidExportFormat
+	}
+}
+
+// NewFeedEncoder returns an encoder writing a calendar feed to w
+func (te *taskExporter) NewFeedEncoder(w io.Writer) domain.TaskEncoder {
+	return &icsTaskEncoder{writer: bufio.NewWriter(w), stamp: te.clock.Now().UTC().Format(icsTimeFormat), feed: true}
+}
+
+// csvTaskEncoder writes one CSV record per task after a header naming the columns
+type csvTaskEncoder struct {
+	writer  *csv.Writer
+	started bool // Whether the header was written
+}
+
+// start writes the header before the first record
+func (e *csvTaskEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.writer.Write(csvExportColumns)
+}
+
+// Encode writes the record of a task
+func (e *csvTaskEncoder) Encode(task domain.Task) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	record := []string{
+		task.ID,
+		task.ProjectID,
+		task.Title,
+		task.Description,
+		task.DueDate.UTC().Format(time.RFC3339),
+		string(task.Status),
+		string(task.Priority),
+		task.Recurrence,
+		strings.Join(task.Tags, ","),
+		task.OwnerID,
+		strings.Join(task.Assignees, ","),
+		task.ParentID,
+		strconv.Itoa(task.Version),
+	}
+	for i, cell := range record {
+		record[i] = csvCell(cell)
+	}
+	return e.writer.Write(record)
+}
+
+// csvCell keeps spreadsheet apps from running a cell as a formula by prefixing it with a quote
+// when it starts with one of the characters that start a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// Close writes the header of an empty export and flushes the records
+func (e *csvTaskEncoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// jsonlTaskEncoder writes every task as a JSON object on a line of its own, in the form of the API responses
+type jsonlTaskEncoder struct {
+	encoder *json.Encoder
+}
+
+// Encode writes the line of a task
+func (e *jsonlTaskEncoder) Encode(task domain.Task) error {
+	return e.encoder.Encode(task)
+}
+
+// Close has nothing to write, every line is complete
+func (e *jsonlTaskEncoder) Close() error {
+	return nil
+}
+
+// icsTaskEncoder writes an iCalendar object holding a VTODO per task
+type icsTaskEncoder struct {
+	writer  *bufio.Writer
+	stamp   string // DTSTAMP of every component, the time of the export
+	feed    bool   // Whether the calendar is a subscription feed, which also gets a VEVENT per task
+	started bool   // Whether the start of the calendar was written
+	err     error  // First write error, which stops the export
+}
+
+// start writes the beginning of the calendar before the first task
+func (e *icsTaskEncoder) start() {
+	if e.started {
+		return
+	}
+	e.started = true
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + icsProductID)
+	e.line("CALSCALE:GREGORIAN")
+	if e.feed {
+		e.line("X-WR-CALNAME:Tasks")
+		e.line("REFRESH-INTERVAL;VALUE=DURATION:" + icsFeedRefresh)
+		e.line("X-PUBLISHED-TTL:" + icsFeedRefresh)
+	}
+}
+
+// Encode writes the VTODO of a task
+// The recurrence rule is left out: every occurrence of a series is a task of its own and is exported as such
+func (e *icsTaskEncoder) Encode(task domain.Task) error {
+	e.start()
+	e.line("BEGIN:VTODO")
+	e.line("UID:" + task.ID + "@" + icsUIDDomain)
+	e.line("DTSTAMP:" + e.stamp)
+	e.line("SUMMARY:" + icsEscape(task.Title))
+	if task.Description != "" {
+		e.line("DESCRIPTION:" + icsEscape(task.Description))
+	}
+	e.line("DUE:" + task.DueDate.UTC().Format(icsTimeFormat))
+	if status, ok := icsStatuses[task.Status]; ok {
+		e.line("STATUS:" + status)
+	}
+	if priority, ok := icsPriorities[task.Priority]; ok {
+		e.line("PRIORITY:" + strconv.Itoa(priority))
+	}
+	if len(task.Tags) > 0 {
+		categories := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			categories = append(categories, icsEscape(tag))
+		}
+		e.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+	if task.ParentID != "" {
+		e.line("RELATED-TO;RELTYPE=PARENT:" + task.ParentID + "@" + icsUIDDomain)
+	}
+	e.line("END:VTODO")
+	if e.feed {
+		e.event(task)
+	}
+	return e.err
+}
+
+// event writes the VEVENT of a task, a zero-length event at its due date that does not block time
+func (e *icsTaskEncoder) event(task domain.Task) {
+	due := task.DueDate.UTC().Format(icsTimeFormat)
+	e.line("BEGIN:VEVENT")
+	// the UID must differ from the one of the VTODO
+	e.line("UID:" + task.ID + "-due@" + icsUIDDomain)
+	e.line("DTSTAMP:" + e.stamp)
+	e.line("DTSTART:" + due)
+	e.line("DTEND:" + due)
+	e.line("SUMMARY:" + icsEscape(task.Title))
+	if task.Description != "" {
+		e.line("DESCRIPTION:" + icsEscape(task.Description))
+	}
+	e.line("TRANSP:TRANSPARENT")
+	e.line("RELATED-TO:" + task.ID + "@" + icsUIDDomain)
+	e.line("END:VEVENT")
+}
+
+// Close ends the calendar and flushes it
+func (e *icsTaskEncoder) Close() error {
+	e.start()
+	e.line("END:VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.writer.Flush()
+}
+
+// line writes a content line, folded so no line is longer than icsLineLength octets
+func (e *icsTaskEncoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+	var folded strings.Builder
+	limit := icsLineLength
+	for len(s) > limit {
+		// never split a multi-byte character
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		folded.WriteString(s[:cut])
+		folded.WriteString("\r\n ")
+		s = s[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = icsLineLength - 1
+	}
+	folded.WriteString(s)
+	folded.WriteString("\r\n")
+	_, e.err = e.writer.WriteString(folded.String())
+}
+
+// icsEscaper escapes the characters RFC 5545 reserves in TEXT values
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icsEscape escapes a TEXT value
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// maxDeliveriesPerRun bounds the deliveries attempted by a single sweep, so a long queue does not delay shutdown
+const maxDeliveriesPerRun = 100
+
+// maxRetryDelay caps the backoff between two attempts of a delivery
+const maxRetryDelay = 24 * time.Hour
+
+// WebhookDispatcher periodically sends the due deliveries of the webhook queue
+// A failed delivery is retried with exponential backoff until it runs out of attempts
+type WebhookDispatcher struct {
+	webhookRepository  domain.WebhookRepository         // Repository the webhooks of the deliveries are looked up in
+	deliveryRepository domain.WebhookDeliveryRepository // Persisted queue of the deliveries
+	sender             domain.WebhookSender             // Posts the deliveries
+	clock              domain.Clock                     // Source of the current time and of the ticks
+	maxAttempts        int                              // Attempts made before a delivery fails for good
+	backoff            time.Duration                    // Delay before the first retry, doubled for every later one
+	interval           time.Duration                    // Time between two sweeps
+	timeout            time.Duration                    // Timeout of each repository call and each request
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher
+func NewWebhookDispatcher(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, sender domain.WebhookSender, clock domain.Clock, maxAttempts int, backoff time.Duration, interval time.Duration, timeout time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		sender:             sender,
+		clock:              clock,
+		maxAttempts:        maxAttempts,
+		backoff:            backoff,
+		interval:           interval,
+		timeout:            timeout,
+	}
+}
+
+// Run sends the due deliveries once immediately and then every interval until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	runPeriodically(ctx, d.clock, d.interval, "delivering webhooks", func(ctx context.Context) error {
+		_, err := d.RunOnce(ctx)
+		return err
+	})
+}
+
+// RunOnce claims and attempts due deliveries until none is left or the run limit is reached
+// Returns the number of deliveries that succeeded along with the failures to update the queue
+func (d *WebhookDispatcher) RunOnce(ctx context.Context) (int, error) {
+	sent := 0
+	var errs []error
+	for i := 0; i < maxDeliveriesPerRun && ctx.Err() == nil; i++ {
+		claimCtx, cancel := context.WithTimeout(ctx, d.timeout)
+		// the claim lasts until the request and the recording of its result have timed out
+		delivery, err := d.deliveryRepository.ClaimDue(claimCtx, d.clock.Now(), 3*d.timeout)
+		cancel()
+		if errors.Is(err, domain.ErrNoWebhookDeliveryDue) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		ok, err := d.deliver(ctx, delivery)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delivery %s: %w", delivery.ID, err))
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// deliver attempts a claimed delivery and records the outcome
+// Returns whether the webhook accepted it; the error is only set when the outcome could not be recorded
+func (d *WebhookDispatcher) deliver(c context.Context, delivery domain.WebhookDelivery) (bool, error) {
+	ctx, cancel := context.WithTimeout(c, d.timeout)
+	defer cancel()
+
+	start := d.clock.Now()
+	attempt := domain.WebhookAttempt{At: start}
+
+	webhook, err := d.webhookRepository.FetchByID(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound) || errors.Is(err, domain.ErrInvalidWebhookID):
+		// nowhere to send it anymore
+		attempt.Error = "webhook was deleted"
+		return false, d.record(c, delivery, attempt, domain.DeliveryFailed, start)
+	case err != nil:
+		return false, err
+	case !webhook.Active:
+		attempt.Error = "webhook is inactive"
+		return false, d.record(c, delivery, attempt, domain.DeliveryFailed, start)
+	}
+
+	attempt.StatusCode, err = d.sender.Send(ctx, webhook, delivery)
+	attempt.Duration = d.clock.Now().Sub(start)
+	if err == nil {
+		return true, d.record(c, delivery, attempt, domain.DeliverySucceeded, start)
+	}
+
+	attempt.Error = err.Error()
+	attempts := len(delivery.Attempts) + 1
+	if attempts >= d.maxAttempts {
+		return false, d.record(c, delivery, attempt, domain.DeliveryFailed, start)
+	}
+	return false, d.record(c, delivery, attempt, domain.DeliveryPending, start.Add(d.retryDelay(attempts)))
+}
+
+// record stores the outcome of an attempt with a timeout of its own, the request may have used up the previous one
+func (d *WebhookDispatcher) record(c context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt, status domain.WebhookDeliveryStatus, next time.Time) error {
+	ctx, cancel := context.WithTimeout(c, d.timeout)
+	defer cancel()
+	return d.deliveryRepository.RecordAttempt(ctx, delivery.ID, attempt, status, next)
+}
+
+// retryDelay returns how long to wait after the given number of failed attempts: backoff, then twice as long every time
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
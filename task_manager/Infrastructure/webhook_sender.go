@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// Headers set on every webhook request
+const (
+	WebhookEventHeader     = "X-Webhook-Event"     // Type of the event
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"  // ID of the delivery, new for every redelivery
+	WebhookSignatureHeader = "X-Webhook-Signature" // "sha256=" followed by the hex HMAC-SHA256 of the body keyed with the secret
+)
+
+// webhookSender implements the domain.WebhookSender interface over HTTP
+type webhookSender struct {
+	client *http.Client
+}
+
+// NewWebhookSender returns a sender posting with client, or with a default client when it is nil
+// Redirects are not followed: a webhook answering with one is misconfigured
+func NewWebhookSender(client *http.Client) domain.WebhookSender {
+	if client == nil {
+		client = &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		}
+	}
+	return &webhookSender{client: client}
+}
+
+// SignWebhookPayload returns the value of the signature header of a payload
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the payload of the delivery to the URL of the webhook
+func (s *webhookSender) Send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-manager-webhooks")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
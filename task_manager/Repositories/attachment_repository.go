@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Attachment represents the metadata of a file attached to a task in the database
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID      string             `bson:"task_id"`
+	UploaderID  string             `bson:"uploader_id"`
+	Filename    string             `bson:"filename"`
+	ContentType string             `bson:"content_type"`
+	Size        int64              `bson:"size"`
+	StorageKey  string             `bson:"storage_key"`
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+func (a *Attachment) toDomain() domain.Attachment {
+	return domain.Attachment{
+		ID:          a.ID.Hex(),
+		TaskID:      a.TaskID,
+		UploaderID:  a.UploaderID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		StorageKey:  a.StorageKey,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// attachmentRepository implements the domain.AttachmentRepository interface
+type attachmentRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the attachments collection
+}
+
+// NewAttachmentRepository returns a new attachmentRepository instance
+func NewAttachmentRepository(db mongo.Database, collection string) domain.AttachmentRepository {
+	return &attachmentRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// EnsureAttachmentIndexes creates the index serving the listing of the attachments of a task
+func EnsureAttachmentIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	attachments := db.Collection(collection)
+	_, err := attachments.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "task_id", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+		Options: options.Index().SetName("attachment_task"),
+	})
+	return err
+}
+
+// Create inserts a new attachment and sets its generated ID
+func (ar *attachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	attachments := ar.database.Collection(ar.collection)
+
+	result, err := attachments.InsertOne(ctx, Attachment{
+		TaskID:      attachment.TaskID,
+		UploaderID:  attachment.UploaderID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		StorageKey:  attachment.StorageKey,
+		CreatedAt:   attachment.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		attachment.ID = objID.Hex()
+	}
+	return nil
+}
+
+// FetchByID retrieves an attachment by its ID
+func (ar *attachmentRepository) FetchByID(ctx context.Context, attachmentID string) (domain.Attachment, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return domain.Attachment{}, domain.ErrInvalidAttachmentID
+	}
+
+	attachments := ar.database.Collection(ar.collection)
+	result := attachments.FindOne(ctx, bson.D{{Key: "_id", Value: objID}})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return domain.Attachment{}, domain.ErrAttachmentNotFound
+		}
+		return domain.Attachment{}, result.Err()
+	}
+
+	var attachment Attachment
+	if err := result.Decode(&attachment); err != nil {
+		return domain.Attachment{}, err
+	}
+	return attachment.toDomain(), nil
+}
+
+// FetchByTaskID retrieves the attachments of a task in the order they were uploaded
+func (ar *attachmentRepository) FetchByTaskID(ctx context.Context, taskID string) ([]domain.Attachment, error) {
+	filter := bson.D{{Key: "task_id", Value: taskID}}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	return ar.findAttachments(ctx, filter, opts)
+}
+
+// FetchByTaskIDs retrieves the attachments of every task among taskIDs
+func (ar *attachmentRepository) FetchByTaskIDs(ctx context.Context, taskIDs []string) ([]domain.Attachment, error) {
+	filter := bson.D{{Key: "task_id", Value: bson.D{{Key: "$in", Value: taskIDs}}}}
+	return ar.findAttachments(ctx, filter, options.Find())
+}
+
+// Delete removes an attachment
+// Returns the number of deleted documents
+func (ar *attachmentRepository) Delete(ctx context.Context, attachmentID string) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return 0, domain.ErrInvalidAttachmentID
+	}
+
+	attachments := ar.database.Collection(ar.collection)
+	result, err := attachments.DeleteOne(ctx, bson.D{{Key: "_id", Value: objID}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
+
+// DeleteMany removes the attachments among attachmentIDs
+// Returns the number of deleted documents
+func (ar *attachmentRepository) DeleteMany(ctx context.Context, attachmentIDs []string) (int, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(attachmentIDs))
+	for _, id := range attachmentIDs {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return 0, domain.ErrInvalidAttachmentID
+		}
+		objIDs = append(objIDs, objID)
+	}
+
+	attachments := ar.database.Collection(ar.collection)
+	result, err := attachments.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: objIDs}}}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
+
+// findAttachments runs a find query and decodes the matching attachments
+func (ar *attachmentRepository) findAttachments(ctx context.Context, filter bson.D, opts *options.FindOptions) ([]domain.Attachment, error) {
+	attachments := ar.database.Collection(ar.collection)
+
+	results := []domain.Attachment{}
+	cursor, err := attachments.Find(ctx, filter, opts)
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var attachment Attachment
+		if err := cursor.Decode(&attachment); err != nil {
+			log.Println("Failed to decode attachment")
+			continue
+		}
+		results = append(results, attachment.toDomain())
+	}
+
+	return results, cursor.Err()
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CalendarToken represents the calendar feed token of a user in the database
+type CalendarToken struct {
+	UserID    string    `bson:"user_id"`
+	TokenHash string    `bson:"token_hash"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (t *CalendarToken) toDomain() domain.CalendarToken {
+	return domain.CalendarToken{
+		UserID:    t.UserID,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// calendarTokenRepository implements the domain.CalendarTokenRepository interface
+type calendarTokenRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the calendar tokens collection
+}
+
+// NewCalendarTokenRepository returns a new calendarTokenRepository instance
+func NewCalendarTokenRepository(db mongo.Database, collection string) domain.CalendarTokenRepository {
+	return &calendarTokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// EnsureCalendarTokenIndexes creates the indexes keeping a single token per user and looking tokens up by hash
+func EnsureCalendarTokenIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	tokens := db.Collection(collection)
+	_, err := tokens.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("calendar_token_user").SetUnique(true),
+		},
+		{
+			// every feed request looks its token up
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetName("calendar_token_hash").SetUnique(true),
+		},
+	})
+	return err
+}
+
+// Save replaces the token of the user, inserting it when the user had none
+func (cr *calendarTokenRepository) Save(ctx context.Context, token *domain.CalendarToken) error {
+	tokens := cr.database.Collection(cr.collection)
+
+	document := CalendarToken{
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		CreatedAt: token.CreatedAt,
+	}
+	filter := bson.D{{Key: "user_id", Value: token.UserID}}
+	_, err := tokens.ReplaceOne(ctx, filter, document, options.Replace().SetUpsert(true))
+	return err
+}
+
+// FetchByHash retrieves the token with the given hash
+// Returns ErrCalendarTokenNotFound if no document is found
+func (cr *calendarTokenRepository) FetchByHash(ctx context.Context, tokenHash string) (domain.CalendarToken, error) {
+	tokens := cr.database.Collection(cr.collection)
+
+	var token CalendarToken
+	err := tokens.FindOne(ctx, bson.D{{Key: "token_hash", Value: tokenHash}}).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.CalendarToken{}, domain.ErrCalendarTokenNotFound
+	}
+	if err != nil {
+		return domain.CalendarToken{}, err
+	}
+	return token.toDomain(), nil
+}
+
+// DeleteByUserID removes the token of the user
+// Returns the number of deleted documents
+func (cr *calendarTokenRepository) DeleteByUserID(ctx context.Context, userID string) (int, error) {
+	tokens := cr.database.Collection(cr.collection)
+
+	result, err := tokens.DeleteOne(ctx, bson.D{{Key: "user_id", Value: userID}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
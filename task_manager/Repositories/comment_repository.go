@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Comment represents a task comment in the database
+type Comment struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID    string             `bson:"task_id"`
+	AuthorID  string             `bson:"author_id"`
+	Body      string             `bson:"body"`
+	CreatedAt time.Time          `bson:"created_at"`
+	EditedAt  *time.Time         `bson:"edited_at,omitempty"`
+}
+
+func (c *Comment) toDomain() domain.Comment {
+	return domain.Comment{
+		ID:        c.ID.Hex(),
+		TaskID:    c.TaskID,
+		AuthorID:  c.AuthorID,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		EditedAt:  c.EditedAt,
+	}
+}
+
+// commentRepository implements the domain.CommentRepository interface
+type commentRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the comments collection
+}
+
+// NewCommentRepository returns a new commentRepository instance
+func NewCommentRepository(db mongo.Database, collection string) domain.CommentRepository {
+	return &commentRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// EnsureCommentIndexes creates the index serving the paginated listing of the comments of a task
+func EnsureCommentIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	comments := db.Collection(collection)
+	_, err := comments.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "task_id", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+		Options: options.Index().SetName("comment_task"),
+	})
+	return err
+}
+
+// Create inserts a new comment and sets its generated ID
+func (cr *commentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	comments := cr.database.Collection(cr.collection)
+
+	result, err := comments.InsertOne(ctx, Comment{
+		TaskID:    comment.TaskID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		comment.ID = objID.Hex()
+	}
+	return nil
+}
+
+// FetchByID retrieves a comment by its ID
+func (cr *commentRepository) FetchByID(ctx context.Context, commentID string) (domain.Comment, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return domain.Comment{}, domain.ErrInvalidCommentID
+	}
+
+	comments := cr.database.Collection(cr.collection)
+	result := comments.FindOne(ctx, bson.D{{Key: "_id", Value: objID}})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return domain.Comment{}, domain.ErrCommentNotFound
+		}
+		return domain.Comment{}, result.Err()
+	}
+
+	var comment Comment
+	if err := result.Decode(&comment); err != nil {
+		return domain.Comment{}, err
+	}
+	return comment.toDomain(), nil
+}
+
+// FetchByTaskID retrieves a page of the comments of a task in the order they were posted
+func (cr *commentRepository) FetchByTaskID(ctx context.Context, query domain.CommentQuery) ([]domain.Comment, error) {
+	comments := cr.database.Collection(cr.collection)
+
+	filter := bson.D{{Key: "task_id", Value: query.TaskID}}
+	if query.After != nil {
+		afterID, err := primitive.ObjectIDFromHex(query.After.ID)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: afterID}}})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(query.Limit))
+
+	results := []domain.Comment{}
+	cursor, err := comments.Find(ctx, filter, opts)
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var comment Comment
+		if err := cursor.Decode(&comment); err != nil {
+			log.Println("Failed to decode comment in FetchByTaskID")
+			continue
+		}
+		results = append(results, comment.toDomain())
+	}
+
+	return results, cursor.Err()
+}
+
+// UpdateBody replaces the body of a comment and records when it was edited
+// Returns the number of matched documents
+func (cr *commentRepository) UpdateBody(ctx context.Context, commentID string, body string, editedAt time.Time) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return 0, domain.ErrInvalidCommentID
+	}
+
+	comments := cr.database.Collection(cr.collection)
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "body", Value: body},
+		{Key: "edited_at", Value: editedAt},
+	}}}
+	result, err := comments.UpdateOne(ctx, bson.D{{Key: "_id", Value: objID}}, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// Delete removes a comment
+// Returns the number of deleted documents
+func (cr *commentRepository) Delete(ctx context.Context, commentID string) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return 0, domain.ErrInvalidCommentID
+	}
+
+	comments := cr.database.Collection(cr.collection)
+	result, err := comments.DeleteOne(ctx, bson.D{{Key: "_id", Value: objID}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
+
+// DeleteByTaskIDs removes the comments of every task among taskIDs
+// Returns the number of deleted documents
+func (cr *commentRepository) DeleteByTaskIDs(ctx context.Context, taskIDs []string) (int, error) {
+	comments := cr.database.Collection(cr.collection)
+	result, err := comments.DeleteMany(ctx, bson.D{{Key: "task_id", Value: bson.D{{Key: "$in", Value: taskIDs}}}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
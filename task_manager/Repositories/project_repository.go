@@ -0,0 +1,320 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Project represents a project in the database
+type Project struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description"`
+	Members     []ProjectMember    `bson:"members"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	DefaultOf   string             `bson:"default_of,omitempty"` // Set to the owner of the tasks created before projects existed that the project holds
+}
+
+// ProjectMember is the DTO of a member embedded in a project document
+type ProjectMember struct {
+	UserID string `bson:"user_id"`
+	Role   string `bson:"role"`
+}
+
+func (p *Project) toDomain() domain.Project {
+	members := make([]domain.ProjectMember, 0, len(p.Members))
+	for _, member := range p.Members {
+		members = append(members, domain.ProjectMember{UserID: member.UserID, Role: domain.ProjectRole(member.Role)})
+	}
+	return domain.Project{
+		ID:          p.ID.Hex(),
+		Name:        p.Name,
+		Description: p.Description,
+		Members:     members,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+// projectRepository implements the domain.ProjectRepository interface
+type projectRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the projects collection
+}
+
+// NewProjectRepository returns a new projectRepository instance
+func NewProjectRepository(db mongo.Database, collection string) domain.ProjectRepository {
+	return &projectRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// EnsureProjectIndexes creates the indexes the project repository relies on
+func EnsureProjectIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	projects := db.Collection(collection)
+	_, err := projects.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// the projects of a member are looked up on every task listing
+			Keys:    bson.D{{Key: "members.user_id", Value: 1}},
+			Options: options.Index().SetName("project_members"),
+		},
+		{
+			// there is a single default project per owner, even when several instances start at once
+			Keys: bson.D{{Key: "default_of", Value: 1}},
+			Options: options.Index().
+				SetName("project_default_of").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "default_of", Value: bson.D{{Key: "$exists", Value: true}}}}),
+		},
+	})
+	return err
+}
+
+// MoveOrphanTasks puts the tasks stored before projects existed into a default project per owner,
+// creating it if needed; the owner becomes owner of the project and the assignees of their tasks editors,
+// so everyone keeps seeing the tasks they saw before. Tasks without an owner share a project without members
+// Returns the number of tasks moved
+func MoveOrphanTasks(ctx context.Context, db mongo.Database, taskCollection string, projectCollection string) (int, error) {
+	tasks := db.Collection(taskCollection)
+	orphans := bson.D{{Key: "project_id", Value: bson.D{{Key: "$in", Value: bson.A{nil, ""}}}}}
+	owners, err := tasks.Distinct(ctx, "owner_id", orphans)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	projects := db.Collection(projectCollection)
+	for _, owner := range owners {
+		ownerID, ok := owner.(string)
+		if !ok || ownerID == "" {
+			continue
+		}
+		count, err := moveOrphanTasksOf(ctx, tasks, projects, orphans, ownerID)
+		if err != nil {
+			return moved, err
+		}
+		moved += count
+	}
+
+	// whatever is left has no owner
+	ownerless, err := tasks.CountDocuments(ctx, orphans)
+	if err != nil || ownerless == 0 {
+		return moved, err
+	}
+	count, err := moveOrphanTasksOf(ctx, tasks, projects, orphans, "")
+	return moved + count, err
+}
+
+// moveOrphanTasksOf moves the orphan tasks of ownerID into the default project of ownerID;
+// an empty ownerID moves every orphan task left. Returns the number of tasks moved
+func moveOrphanTasksOf(ctx context.Context, tasks *mongo.Collection, projects *mongo.Collection, orphans bson.D, ownerID string) (int, error) {
+	owned := orphans
+	if ownerID != "" {
+		owned = append(bson.D{{Key: "owner_id", Value: ownerID}}, orphans...)
+	}
+
+	members := bson.A{}
+	if ownerID != "" {
+		members = append(members, ProjectMember{UserID: ownerID, Role: string(domain.ProjectRoleOwner)})
+	}
+	upsert := bson.D{{Key: "$setOnInsert", Value: bson.D{
+		{Key: "name", Value: "Default"},
+		{Key: "description", Value: "Tasks created before projects were introduced"},
+		{Key: "members", Value: members},
+		{Key: "created_at", Value: time.Now()},
+	}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var project Project
+	if err := projects.FindOneAndUpdate(ctx, bson.D{{Key: "default_of", Value: ownerID}}, upsert, opts).Decode(&project); err != nil {
+		return 0, err
+	}
+
+	assignees, err := tasks.Distinct(ctx, "assignees", owned)
+	if err != nil {
+		return 0, err
+	}
+	for _, assignee := range assignees {
+		userID, ok := assignee.(string)
+		if !ok || userID == "" {
+			continue
+		}
+		// members added since, the owner included, keep the role they were given
+		filter := bson.D{{Key: "_id", Value: project.ID}, {Key: "members.user_id", Value: bson.D{{Key: "$ne", Value: userID}}}}
+		update := bson.D{{Key: "$push", Value: bson.D{{Key: "members", Value: ProjectMember{UserID: userID, Role: string(domain.ProjectRoleEditor)}}}}}
+		if _, err := projects.UpdateOne(ctx, filter, update); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tasks.UpdateMany(ctx, owned, bson.D{{Key: "$set", Value: bson.D{{Key: "project_id", Value: project.ID.Hex()}}}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// Create inserts a new project and sets its generated ID
+func (pr *projectRepository) Create(ctx context.Context, project *domain.Project) error {
+	projects := pr.database.Collection(pr.collection)
+
+	members := make([]ProjectMember, 0, len(project.Members))
+	for _, member := range project.Members {
+		members = append(members, ProjectMember{UserID: member.UserID, Role: string(member.Role)})
+	}
+	result, err := projects.InsertOne(ctx, Project{
+		Name:        project.Name,
+		Description: project.Description,
+		Members:     members,
+		CreatedAt:   project.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		project.ID = objID.Hex()
+	}
+	return nil
+}
+
+// FetchByID retrieves a project by its ID
+func (pr *projectRepository) FetchByID(ctx context.Context, projectID string) (domain.Project, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return domain.Project{}, domain.ErrInvalidProjectID
+	}
+
+	projects := pr.database.Collection(pr.collection)
+	result := projects.FindOne(ctx, bson.D{{Key: "_id", Value: objID}})
+	if result.Err() != nil {
+		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+			return domain.Project{}, domain.ErrProjectNotFound
+		}
+		return domain.Project{}, result.Err()
+	}
+
+	var project Project
+	if err := result.Decode(&project); err != nil {
+		return domain.Project{}, err
+	}
+	return project.toDomain(), nil
+}
+
+// FetchAll retrieves every project in the order they were created
+func (pr *projectRepository) FetchAll(ctx context.Context) ([]domain.Project, error) {
+	return pr.findProjects(ctx, bson.D{})
+}
+
+// FetchByMember retrieves the projects whose members include userID, in the order they were created
+func (pr *projectRepository) FetchByMember(ctx context.Context, userID string) ([]domain.Project, error) {
+	return pr.findProjects(ctx, bson.D{{Key: "members.user_id", Value: userID}})
+}
+
+// Update replaces the name and description of a project
+// Returns the number of matched documents
+func (pr *projectRepository) Update(ctx context.Context, project *domain.Project) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(project.ID)
+	if err != nil {
+		return 0, domain.ErrInvalidProjectID
+	}
+
+	projects := pr.database.Collection(pr.collection)
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "name", Value: project.Name},
+		{Key: "description", Value: project.Description},
+	}}}
+	result, err := projects.UpdateOne(ctx, bson.D{{Key: "_id", Value: objID}}, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// AddMember appends a member to a project that does not count the user among its members yet
+// Returns the number of modified documents
+func (pr *projectRepository) AddMember(ctx context.Context, projectID string, member domain.ProjectMember) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return 0, domain.ErrInvalidProjectID
+	}
+
+	projects := pr.database.Collection(pr.collection)
+	filter := bson.D{{Key: "_id", Value: objID}, {Key: "members.user_id", Value: bson.D{{Key: "$ne", Value: member.UserID}}}}
+	update := bson.D{{Key: "$push", Value: bson.D{{Key: "members", Value: ProjectMember{UserID: member.UserID, Role: string(member.Role)}}}}}
+	result, err := projects.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// UpdateMemberRole sets the role of a member of a project
+// Returns the number of matched documents
+func (pr *projectRepository) UpdateMemberRole(ctx context.Context, projectID string, userID string, role domain.ProjectRole) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return 0, domain.ErrInvalidProjectID
+	}
+
+	projects := pr.database.Collection(pr.collection)
+	filter := bson.D{{Key: "_id", Value: objID}, {Key: "members.user_id", Value: userID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "members.$.role", Value: string(role)}}}}
+	result, err := projects.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// RemoveMember removes a member from a project
+// Returns the number of modified documents
+func (pr *projectRepository) RemoveMember(ctx context.Context, projectID string, userID string) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return 0, domain.ErrInvalidProjectID
+	}
+
+	projects := pr.database.Collection(pr.collection)
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "members", Value: bson.D{{Key: "user_id", Value: userID}}}}}}
+	result, err := projects.UpdateOne(ctx, bson.D{{Key: "_id", Value: objID}}, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// findProjects runs a query against the collection and decodes the matching projects, oldest first
+func (pr *projectRepository) findProjects(ctx context.Context, filter bson.D) ([]domain.Project, error) {
+	projects := pr.database.Collection(pr.collection)
+
+	results := []domain.Project{}
+	cursor, err := projects.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var project Project
+		if err := cursor.Decode(&project); err != nil {
+			log.Println("Failed to decode project in findProjects")
+			continue
+		}
+		results = append(results, project.toDomain())
+	}
+
+	return results, cursor.Err()
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Reminder represents a sent due-date reminder in the database
+type Reminder struct {
+	TaskID  string    `bson:"task_id"`
+	UserID  string    `bson:"user_id"`
+	DueDate time.Time `bson:"due_date"`
+	SentAt  time.Time `bson:"sent_at"`
+}
+
+// reminderRepository implements the domain.ReminderRepository interface
+type reminderRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the reminders collection
+}
+
+// NewReminderRepository returns a new reminderRepository instance
+func NewReminderRepository(db mongo.Database, collection string) domain.ReminderRepository {
+	return &reminderRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// EnsureReminderIndexes creates the unique index that makes Claim safe across restarts and instances
+func EnsureReminderIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	reminders := db.Collection(collection)
+	_, err := reminders.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "task_id", Value: 1},
+			{Key: "user_id", Value: 1},
+			{Key: "due_date", Value: 1},
+		},
+		Options: options.Index().SetName("reminder_unique").SetUnique(true),
+	})
+	return err
+}
+
+// Claim inserts the reminder, relying on the unique index to detect reminders that were already sent
+func (rr *reminderRepository) Claim(ctx context.Context, taskID string, userID string, dueDate time.Time) (bool, error) {
+	reminders := rr.database.Collection(rr.collection)
+
+	_, err := reminders.InsertOne(ctx, Reminder{
+		TaskID:  taskID,
+		UserID:  userID,
+		DueDate: dueDate,
+		SentAt:  time.Now(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Release deletes a claimed reminder
+func (rr *reminderRepository) Release(ctx context.Context, taskID string, userID string, dueDate time.Time) error {
+	reminders := rr.database.Collection(rr.collection)
+
+	filter := bson.D{
+		{Key: "task_id", Value: taskID},
+		{Key: "user_id", Value: userID},
+		{Key: "due_date", Value: dueDate},
+	}
+	_, err := reminders.DeleteOne(ctx, filter)
+	return err
+}
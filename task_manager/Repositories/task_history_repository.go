@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FieldChange represents a single field-level change as stored in the history collection
+type FieldChange struct {
+	Field  string `bson:"field"`
+	Before any    `bson:"before"`
+	After  any    `bson:"after"`
+}
+
+// TaskHistoryEntry represents a task history entry in the database
+type TaskHistoryEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID    string             `bson:"task_id"`
+	ActorID   string             `bson:"actor_id"`
+	Action    string             `bson:"action"`
+	Timestamp time.Time          `bson:"timestamp"`
+	Changes   []FieldChange      `bson:"changes"`
+}
+
+func (e *TaskHistoryEntry) toDomain() domain.TaskHistoryEntry {
+	changes := make([]domain.FieldChange, 0, len(e.Changes))
+	for _, change := range e.Changes {
+		changes = append(changes, domain.FieldChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return domain.TaskHistoryEntry{
+		ID:        e.ID.Hex(),
+		TaskID:    e.TaskID,
+		ActorID:   e.ActorID,
+		Action:    e.Action,
+		Timestamp: e.Timestamp,
+		Changes:   changes,
+	}
+}
+
+func fromDomainToHistoryEntry(e *domain.TaskHistoryEntry) TaskHistoryEntry {
+	changes := make([]FieldChange, 0, len(e.Changes))
+	for _, change := range e.Changes {
+		changes = append(changes, FieldChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return TaskHistoryEntry{
+		TaskID:    e.TaskID,
+		ActorID:   e.ActorID,
+		Action:    e.Action,
+		Timestamp: e.Timestamp,
+		Changes:   changes,
+	}
+}
+
+// taskHistoryRepository implements the domain.TaskHistoryRepository interface
+type taskHistoryRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the task history collection
+}
+
+// NewTaskHistoryRepository returns a new taskHistoryRepository instance
+func NewTaskHistoryRepository(db mongo.Database, collection string) domain.TaskHistoryRepository {
+	return &taskHistoryRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// Append inserts a new history entry and sets its generated ID
+func (hr *taskHistoryRepository) Append(ctx context.Context, entry *domain.TaskHistoryEntry) error {
+	history := hr.database.Collection(hr.collection)
+
+	entity := fromDomainToHistoryEntry(entry)
+	result, err := history.InsertOne(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = objID.Hex()
+	}
+	return nil
+}
+
+// FetchByTaskID retrieves the history entries of a task in the order they were recorded
+func (hr *taskHistoryRepository) FetchByTaskID(ctx context.Context, taskID string) ([]domain.TaskHistoryEntry, error) {
+	history := hr.database.Collection(hr.collection)
+
+	filter := bson.D{{Key: "task_id", Value: taskID}}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}})
+
+	entries := []domain.TaskHistoryEntry{}
+	cursor, err := history.Find(ctx, filter, opts)
+	if err != nil {
+		return entries, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entry TaskHistoryEntry
+		if err := cursor.Decode(&entry); err != nil {
+			log.Println("Failed to decode history entry in FetchByTaskID")
+			continue
+		}
+		entries = append(entries, entry.toDomain())
+	}
+
+	return entries, cursor.Err()
+}
@@ -5,49 +5,145 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
 	"time"
 
 	domain "github.com/A2SVTask7/Domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // DTO used only inside repository
 type Task struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	ProjectID   string             `bson:"project_id"`
 	Title       string             `bson:"title"`
 	Description string             `bson:"description"`
 	DueDate     time.Time          `bson:"due_date"`
 	Status      string             `bson:"status"`
+	Priority    string             `bson:"priority,omitempty"`
+	OwnerID     string             `bson:"owner_id"`
+	Assignees   []string           `bson:"assignees"`
+	Version     int                `bson:"version"`
+	DeletedAt   *time.Time         `bson:"deleted_at,omitempty"`
+	Recurrence  string             `bson:"recurrence,omitempty"`
+	SeriesID    string             `bson:"series_id,omitempty"`
+	Occurrence  int                `bson:"occurrence,omitempty"`
+	ParentID    string             `bson:"parent_id,omitempty"`
+	Position    int                `bson:"position,omitempty"`
+	Checklist   []ChecklistItem    `bson:"checklist,omitempty"`
+	BlockedBy   []string           `bson:"blocked_by,omitempty"`
+	Tags        []string           `bson:"tags,omitempty"`
+}
+
+// ChecklistItem is the DTO of a checklist item embedded in a task document
+type ChecklistItem struct {
+	ID   string `bson:"id"`
+	Text string `bson:"text"`
+	Done bool   `bson:"done"`
+}
+
+// fromDomainToChecklist converts domain checklist items to their DTOs
+func fromDomainToChecklist(items []domain.ChecklistItem) []ChecklistItem {
+	if items == nil {
+		return nil
+	}
+	checklist := make([]ChecklistItem, 0, len(items))
+	for _, item := range items {
+		checklist = append(checklist, ChecklistItem(item))
+	}
+	return checklist
+}
+
+// checklistToDomain converts checklist DTOs to domain checklist items
+func checklistToDomain(items []ChecklistItem) []domain.ChecklistItem {
+	if items == nil {
+		return nil
+	}
+	checklist := make([]domain.ChecklistItem, 0, len(items))
+	for _, item := range items {
+		checklist = append(checklist, domain.ChecklistItem(item))
+	}
+	return checklist
 }
 
 // Convert domain.Task → repositories.Task
+// An empty ID is left as the zero ObjectID so that Mongo generates one on insert
 func fromDomainToTask(t *domain.Task) (Task, error) {
-	objID, err := primitive.ObjectIDFromHex(t.ID)
-	if err != nil {
-		return Task{}, err
+	var objID primitive.ObjectID
+	if t.ID != "" {
+		var err error
+		objID, err = primitive.ObjectIDFromHex(t.ID)
+		if err != nil {
+			return Task{}, domain.ErrInvalidTaskID
+		}
+	}
+	// store an empty array rather than null so $addToSet keeps working
+	assignees := t.Assignees
+	if assignees == nil {
+		assignees = []string{}
 	}
 	return Task{
 		ID:          objID,
+		ProjectID:   t.ProjectID,
 		Title:       t.Title,
 		Description: t.Description,
 		DueDate:     t.DueDate,
-		Status:      t.Status,
+		Status:      string(t.Status),
+		Priority:    string(t.Priority),
+		OwnerID:     t.OwnerID,
+		Assignees:   assignees,
+		Version:     t.Version,
+		Recurrence:  t.Recurrence,
+		SeriesID:    t.SeriesID,
+		Occurrence:  t.Occurrence,
+		ParentID:    t.ParentID,
+		Position:    t.Position,
+		Checklist:   fromDomainToChecklist(t.Checklist),
+		BlockedBy:   t.BlockedBy,
+		Tags:        t.Tags,
 	}, nil
 }
 
 // Convert repositories.Task → domain.Task
+// Tasks stored before priorities existed get the default priority
 func (t *Task) toDomain() domain.Task {
+	priority := domain.TaskPriority(t.Priority)
+	if priority == "" {
+		priority = domain.DefaultPriority
+	}
 	return domain.Task{
 		ID:          t.ID.Hex(),
+		ProjectID:   t.ProjectID,
 		Title:       t.Title,
 		Description: t.Description,
 		DueDate:     t.DueDate,
-		Status:      t.Status,
+		Status:      domain.TaskStatus(t.Status),
+		Priority:    priority,
+		OwnerID:     t.OwnerID,
+		Assignees:   t.Assignees,
+		Version:     t.Version,
+		DeletedAt:   t.DeletedAt,
+		Recurrence:  t.Recurrence,
+		SeriesID:    t.SeriesID,
+		Occurrence:  t.Occurrence,
+		ParentID:    t.ParentID,
+		Position:    t.Position,
+		Checklist:   checklistToDomain(t.Checklist),
+		BlockedBy:   t.BlockedBy,
+		Tags:        t.Tags,
 	}
 }
 
+// taskSearchHit is a task decoded together with its text search score
+type taskSearchHit struct {
+	Task  `bson:",inline"`
+	Score float64 `bson:"score"`
+}
+
 // taskRepository implements the domain.TaskRepository interface
 type taskRepository struct {
 	database   mongo.Database // MongoDB database instance
@@ -62,9 +158,74 @@ func NewTaskRepository(db mongo.Database, collection string) domain.TaskReposito
 	}
 }
 
+// EnsureTaskIndexes creates the indexes the task repository relies on
+// It is safe to call on every start-up since existing indexes are left untouched
+func EnsureTaskIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	tasks := db.Collection(collection)
+	_, err := tasks.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// full-text search over title and description, titles weigh more
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "description", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("task_text").
+				SetWeights(bson.D{{Key: "title", Value: 3}, {Key: "description", Value: 1}}),
+		},
+		{
+			// a series holds at most one task per occurrence, so the next one is only generated once
+			Keys: bson.D{
+				{Key: "series_id", Value: 1},
+				{Key: "occurrence", Value: 1},
+			},
+			Options: options.Index().
+				SetName("task_occurrence").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.D{{Key: "series_id", Value: bson.D{{Key: "$type", Value: "string"}}}}),
+		},
+		{
+			// subtasks are listed by parent in their order
+			Keys: bson.D{
+				{Key: "parent_id", Value: 1},
+				{Key: "position", Value: 1},
+			},
+			Options: options.Index().
+				SetName("task_parent").
+				SetSparse(true),
+		},
+		{
+			// multikey index serving the tag filters and the tag counts
+			Keys:    bson.D{{Key: "tags", Value: 1}},
+			Options: options.Index().SetName("task_tags"),
+		},
+		{
+			// every listing of a member is scoped to the projects they belong to
+			Keys:    bson.D{{Key: "project_id", Value: 1}},
+			Options: options.Index().SetName("task_project"),
+		},
+	})
+	return err
+}
+
+// MigrateTaskVersions starts the tasks stored before versions existed at version 1,
+// so their entity tag can be sent back in If-Match like any other
+// Returns the number of tasks migrated
+func MigrateTaskVersions(ctx context.Context, db mongo.Database, collection string) (int, error) {
+	tasks := db.Collection(collection)
+	legacy := bson.D{{Key: "version", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}}
+	result, err := tasks.UpdateMany(ctx, legacy, bson.D{{Key: "$set", Value: bson.D{{Key: "version", Value: 1}}}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
 // UpdateByTaskID updates a task in the collection using its ID
+// Only a document still in status is updated, so that the status transition checked by the caller holds;
+// when task.Version is set, the document must also still hold that version
 // Returns the number of matched and modified documents
-func (tr *taskRepository) UpdateByTaskID(ctx context.Context, task *domain.Task) (int, int, error) {
+func (tr *taskRepository) UpdateByTaskID(ctx context.Context, task *domain.Task, status domain.TaskStatus) (int, int, error) {
 
 	taskEntity, err := fromDomainToTask(task)
 	if err != nil {
@@ -73,15 +234,14 @@ func (tr *taskRepository) UpdateByTaskID(ctx context.Context, task *domain.Task)
 
 	tasks := tr.database.Collection(tr.collection)
 	// prepare filter and update
-	filter := bson.D{{Key: "_id", Value: taskEntity.ID}}
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "title", Value: taskEntity.Title},
-			{Key: "description", Value: taskEntity.Description},
-			{Key: "due_date", Value: taskEntity.DueDate},
-			{Key: "status", Value: taskEntity.Status},
-		}},
-	}
+	filter := append(versionFilter(taskEntity.ID, task.Version), bson.E{Key: "status", Value: string(status)})
+	update := versionedSet(bson.D{
+		{Key: "title", Value: taskEntity.Title},
+		{Key: "description", Value: taskEntity.Description},
+		{Key: "due_date", Value: taskEntity.DueDate},
+		{Key: "status", Value: taskEntity.Status},
+		{Key: "priority", Value: taskEntity.Priority},
+	})
 
 	// execute update command
 	result, err := tasks.UpdateOne(ctx, filter, update)
@@ -91,9 +251,112 @@ func (tr *taskRepository) UpdateByTaskID(ctx context.Context, task *domain.Task)
 	return int(result.MatchedCount), int(result.ModifiedCount), nil
 }
 
-// DeleteByTaskID deletes a task by its ID
+// PatchByTaskID sets only the fields present in the patch
+// Only a document still in status is updated; when patch.Version is set, it must also still hold that version
+// Returns the number of matched and modified documents
+func (tr *taskRepository) PatchByTaskID(ctx context.Context, taskID string, status domain.TaskStatus, patch domain.TaskPatch) (int, int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return 0, 0, domain.ErrInvalidTaskID
+	}
+
+	fields := bson.D{}
+	if patch.Title != nil {
+		fields = append(fields, bson.E{Key: "title", Value: *patch.Title})
+	}
+	if patch.Description != nil {
+		fields = append(fields, bson.E{Key: "description", Value: *patch.Description})
+	}
+	if patch.DueDate != nil {
+		fields = append(fields, bson.E{Key: "due_date", Value: *patch.DueDate})
+	}
+	if patch.Status != nil {
+		fields = append(fields, bson.E{Key: "status", Value: string(*patch.Status)})
+	}
+	if patch.Priority != nil {
+		fields = append(fields, bson.E{Key: "priority", Value: string(*patch.Priority)})
+	}
+
+	tasks := tr.database.Collection(tr.collection)
+	filter := append(versionFilter(objID, patch.Version), bson.E{Key: "status", Value: string(status)})
+	result, err := tasks.UpdateOne(ctx, filter, versionedSet(fields))
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(result.MatchedCount), int(result.ModifiedCount), nil
+}
+
+// notDeleted matches the tasks that are not in the trash
+var notDeleted = bson.E{Key: "deleted_at", Value: nil}
+
+// versionFilter matches a live task by ID and, when version is non-zero, by its stored version
+func versionFilter(id primitive.ObjectID, version int) bson.D {
+	filter := bson.D{{Key: "_id", Value: id}, notDeleted}
+	if version > 0 {
+		filter = append(filter, bson.E{Key: "version", Value: version})
+	}
+	return filter
+}
+
+// versionedSet builds an update pipeline that sets the fields and increments
+// the version only if one of them actually changes, so that an update
+// writing the stored values still reports zero modified documents
+func versionedSet(fields bson.D) mongo.Pipeline {
+	changed := bson.A{}
+	set := bson.D{}
+	for _, field := range fields {
+		// $literal keeps values such as "$title" from being read as field paths
+		value := bson.D{{Key: "$literal", Value: field.Value}}
+		changed = append(changed, bson.D{{Key: "$ne", Value: bson.A{"$" + field.Key, value}}})
+		set = append(set, bson.E{Key: field.Key, Value: value})
+	}
+
+	version := bson.D{{Key: "$ifNull", Value: bson.A{"$version", 0}}}
+	set = append(set, bson.E{Key: "version", Value: bson.D{{Key: "$cond", Value: bson.A{
+		bson.D{{Key: "$or", Value: changed}},
+		bson.D{{Key: "$add", Value: bson.A{version, 1}}},
+		version,
+	}}}})
+
+	return mongo.Pipeline{{{Key: "$set", Value: set}}}
+}
+
+// DeleteByTaskID moves a task to the trash by setting its deleted_at marker
+// When version is set, only a document still holding that version is deleted
 // Returns the number of documents deleted
-func (tr *taskRepository) DeleteByTaskID(ctx context.Context, taskID string) (int, error) {
+func (tr *taskRepository) DeleteByTaskID(ctx context.Context, taskID string, version int) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return 0, domain.ErrInvalidTaskID
+	}
+
+	tasks := tr.database.Collection(tr.collection)
+
+	filter := versionFilter(objID, version)
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "deleted_at", Value: time.Now()}}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := tasks.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// FetchDeleted retrieves the tasks in the trash, most recently deleted first
+func (tr *taskRepository) FetchDeleted(ctx context.Context) ([]domain.Task, error) {
+	filter := bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$ne", Value: nil}}}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+	return tr.findTasks(ctx, filter, opts)
+}
+
+// RestoreByTaskID clears the deleted_at marker of a trashed task
+// Returns the number of documents restored
+func (tr *taskRepository) RestoreByTaskID(ctx context.Context, taskID string) (int, error) {
 	// check for valid ID
 	objID, err := primitive.ObjectIDFromHex(taskID)
 	if err != nil {
@@ -102,17 +365,206 @@ func (tr *taskRepository) DeleteByTaskID(ctx context.Context, taskID string) (in
 
 	tasks := tr.database.Collection(tr.collection)
 
-	filter := bson.D{{Key: "_id", Value: objID}}
+	filter := bson.D{
+		{Key: "_id", Value: objID},
+		{Key: "deleted_at", Value: bson.D{{Key: "$ne", Value: nil}}},
+	}
+	update := bson.D{
+		{Key: "$unset", Value: bson.D{{Key: "deleted_at", Value: ""}}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
 
-	result, err := tasks.DeleteOne(ctx, filter)
+	result, err := tasks.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return 0, err
 	}
-	return int(result.DeletedCount), nil
+	return int(result.ModifiedCount), nil
+}
+
+// PurgeDeleted permanently deletes the tasks trashed before the given time
+// Returns the IDs of the deleted tasks
+func (tr *taskRepository) PurgeDeleted(ctx context.Context, before time.Time) ([]string, error) {
+	tasks := tr.database.Collection(tr.collection)
+
+	trashed := bson.E{Key: "deleted_at", Value: bson.D{{Key: "$lte", Value: before}}}
+	ids, err := tasks.Distinct(ctx, "_id", bson.D{trashed})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []string{}, nil
+	}
+
+	inIDs := bson.E{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}
+	result, err := tasks.DeleteMany(ctx, bson.D{inIDs, trashed})
+	if err != nil {
+		return nil, err
+	}
+
+	// tasks restored since they were listed are still there
+	kept := map[primitive.ObjectID]bool{}
+	if int(result.DeletedCount) < len(ids) {
+		remaining, err := tasks.Distinct(ctx, "_id", bson.D{inIDs})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range remaining {
+			if objID, ok := id.(primitive.ObjectID); ok {
+				kept[objID] = true
+			}
+		}
+	}
+
+	purged := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if objID, ok := id.(primitive.ObjectID); ok && !kept[objID] {
+			purged = append(purged, objID.Hex())
+		}
+	}
+	return purged, nil
+}
+
+// MarkMissed sets the status of a live task to missed if it is still pending, due before now and at version
+// Returns the number of documents matched
+func (tr *taskRepository) MarkMissed(ctx context.Context, taskID string, version int, now time.Time) (int, error) {
+	tasks := tr.database.Collection(tr.collection)
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return 0, domain.ErrInvalidTaskID
+	}
+
+	filter := append(versionFilter(objID, version),
+		bson.E{Key: "status", Value: string(domain.StatusPending)},
+		bson.E{Key: "due_date", Value: bson.D{{Key: "$lt", Value: now}}},
+	)
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "status", Value: string(domain.StatusMissed)}}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := tasks.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// FetchPendingDueBetween retrieves the live pending tasks with from < due_date <= to
+func (tr *taskRepository) FetchPendingDueBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Task, error) {
+	filter := bson.D{
+		notDeleted,
+		{Key: "status", Value: string(domain.StatusPending)},
+		{Key: "due_date", Value: bson.D{{Key: "$gt", Value: from}, {Key: "$lte", Value: to}}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}})
+	return tr.findTasks(ctx, filter, opts)
+}
+
+// FetchSubtasks retrieves the live subtasks of a task ordered by position
+func (tr *taskRepository) FetchSubtasks(ctx context.Context, parentID string) ([]domain.Task, error) {
+	filter := bson.D{{Key: "parent_id", Value: parentID}, notDeleted}
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "_id", Value: 1}})
+	return tr.findTasks(ctx, filter, opts)
+}
+
+// UpdateSubtaskPositions sets the position of every listed subtask of parentID to its index in taskIDs
+// Returns the number of subtasks matched
+func (tr *taskRepository) UpdateSubtaskPositions(ctx context.Context, parentID string, taskIDs []string) (int, error) {
+	if len(taskIDs) == 0 {
+		return 0, nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(taskIDs))
+	for position, taskID := range taskIDs {
+		// check for valid ID
+		objID, err := primitive.ObjectIDFromHex(taskID)
+		if err != nil {
+			return 0, domain.ErrInvalidTaskID
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: objID}, {Key: "parent_id", Value: parentID}, notDeleted}).
+			SetUpdate(versionedSet(bson.D{{Key: "position", Value: position}})))
+	}
+
+	tasks := tr.database.Collection(tr.collection)
+	result, err := tasks.BulkWrite(ctx, models)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// UpdateChecklist replaces the checklist of a task
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) UpdateChecklist(ctx context.Context, taskID string, version int, checklist []domain.ChecklistItem) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return 0, domain.ErrInvalidTaskID
+	}
+
+	items := fromDomainToChecklist(checklist)
+	if items == nil {
+		items = []ChecklistItem{}
+	}
+
+	tasks := tr.database.Collection(tr.collection)
+	update := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "checklist", Value: items}}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := tasks.UpdateOne(ctx, versionFilter(objID, version), update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// UpdateRecurrence sets the recurrence of a task and of the live tasks of its series
+// The task joins seriesID, starting at occurrence 1 if it had none; an empty recurrence is removed
+// Returns the number of documents updated
+func (tr *taskRepository) UpdateRecurrence(ctx context.Context, taskID string, seriesID string, recurrence string) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return 0, domain.ErrInvalidTaskID
+	}
+
+	tasks := tr.database.Collection(tr.collection)
+
+	filter := bson.D{
+		notDeleted,
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "_id", Value: objID}},
+			bson.D{{Key: "series_id", Value: seriesID}},
+		}},
+	}
+
+	set := bson.D{
+		{Key: "series_id", Value: seriesID},
+		{Key: "occurrence", Value: bson.D{{Key: "$max", Value: bson.A{"$occurrence", 1}}}},
+		{Key: "version", Value: bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$version", 0}}}, 1}}}},
+	}
+	if recurrence != "" {
+		set = append(set, bson.E{Key: "recurrence", Value: bson.D{{Key: "$literal", Value: recurrence}}})
+	}
+	update := mongo.Pipeline{{{Key: "$set", Value: set}}}
+	if recurrence == "" {
+		update = append(update, bson.D{{Key: "$unset", Value: "recurrence"}})
+	}
+
+	result, err := tasks.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
 }
 
 // Create inserts a new task into the collection
 // Assigns the generated ObjectID back to the task
+// Returns ErrOccurrenceExists when the occurrence index rejects the task
 func (tr *taskRepository) Create(ctx context.Context, task *domain.Task) error {
 	taskEntity, err := fromDomainToTask(task)
 	if err != nil {
@@ -121,8 +573,13 @@ func (tr *taskRepository) Create(ctx context.Context, task *domain.Task) error {
 
 	tasks := tr.database.Collection(tr.collection)
 
+	// every task starts at version 1
+	taskEntity.Version = 1
 	result, err := tasks.InsertOne(ctx, taskEntity)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrOccurrenceExists
+		}
 		return err
 	}
 	objID, ok := result.InsertedID.(primitive.ObjectID)
@@ -130,6 +587,43 @@ func (tr *taskRepository) Create(ctx context.Context, task *domain.Task) error {
 		return fmt.Errorf("unexpected InsertedID type: %T", result.InsertedID)
 	}
 	task.ID = objID.Hex()
+	task.Version = taskEntity.Version
+	return nil
+}
+
+// CreateMany inserts new tasks into the collection in a single write
+// Assigns the generated ObjectIDs back to the tasks
+func (tr *taskRepository) CreateMany(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		taskEntity, err := fromDomainToTask(task)
+		if err != nil {
+			return err
+		}
+		// every task starts at version 1
+		taskEntity.Version = 1
+		documents = append(documents, taskEntity)
+	}
+
+	result, err := tr.database.Collection(tr.collection).InsertMany(ctx, documents)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrOccurrenceExists
+		}
+		return err
+	}
+	for i, insertedID := range result.InsertedIDs {
+		objID, ok := insertedID.(primitive.ObjectID)
+		if !ok {
+			return fmt.Errorf("unexpected InsertedID type: %T", insertedID)
+		}
+		tasks[i].ID = objID.Hex()
+		tasks[i].Version = 1
+	}
 	return nil
 }
 
@@ -139,13 +633,13 @@ func (tr *taskRepository) FetchByTaskID(ctx context.Context, taskID string) (dom
 	// check for valid ID
 	objID, err := primitive.ObjectIDFromHex(taskID)
 	if err != nil {
-		return domain.Task{}, domain.ErrInvalidUserID
+		return domain.Task{}, domain.ErrInvalidTaskID
 	}
 
 	tasks := tr.database.Collection(tr.collection)
 
 	// prepare filter
-	filter := bson.D{{Key: "_id", Value: objID}}
+	filter := bson.D{{Key: "_id", Value: objID}, notDeleted}
 
 	// fetch the result
 	result := tasks.FindOne(ctx, filter)
@@ -165,24 +659,485 @@ func (tr *taskRepository) FetchByTaskID(ctx context.Context, taskID string) (dom
 	return task.toDomain(), nil
 }
 
-// FetchAllTasks retrieves all tasks from the collection
-func (tr *taskRepository) FetchAllTasks(ctx context.Context) ([]domain.Task, error) {
+// FetchAllTasks retrieves a page of tasks matching the query
+// Returns the tasks of the requested page and the total number of matching tasks
+func (tr *taskRepository) FetchAllTasks(ctx context.Context, query domain.TaskQuery) ([]domain.Task, int, error) {
+	tasks := tr.database.Collection(tr.collection)
+	filter := taskQueryFilter(query)
+
+	total, err := tasks.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// continue after the cursor, on top of the filters used for the total
+	pageFilter := filter
+	if query.After != nil {
+		keyset, err := taskKeysetFilter(query)
+		if err != nil {
+			return nil, 0, err
+		}
+		pageFilter = append(bson.D{{Key: "$and", Value: bson.A{keyset}}}, filter...)
+	}
+
+	// the urgency is computed on the fly, which a plain find cannot sort by
+	if query.SortBy == domain.TaskSortByUrgency {
+		pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}, urgentAtStage()}
+		if query.After != nil {
+			keyset, err := taskKeysetFilter(query)
+			if err != nil {
+				return nil, 0, err
+			}
+			pipeline = append(pipeline, bson.D{{Key: "$match", Value: keyset}})
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: taskQuerySort(query)}})
+		if query.Offset > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$skip", Value: query.Offset}})
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+
+		results, err := tr.aggregateTasks(ctx, pipeline)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results, int(total), nil
+	}
+
+	opts := options.Find().
+		SetSort(taskQuerySort(query)).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+
+	results, err := tr.findTasks(ctx, pageFilter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, int(total), nil
+}
+
+// StreamTasks calls fn with every task matching the filters of the query, in its sort order
+// Tasks are decoded one at a time from the cursor rather than loaded all at once; pagination fields are ignored
+// Stops at the first error returned by fn and returns it
+func (tr *taskRepository) StreamTasks(ctx context.Context, query domain.TaskQuery, fn func(task domain.Task) error) error {
+	tasks := tr.database.Collection(tr.collection)
+	filter := taskQueryFilter(query)
+
+	var cursor *mongo.Cursor
+	var err error
+	// the urgency is computed on the fly, which a plain find cannot sort by
+	if query.SortBy == domain.TaskSortByUrgency {
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			urgentAtStage(),
+			{{Key: "$sort", Value: taskQuerySort(query)}},
+		}
+		cursor, err = tasks.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	} else {
+		opts := options.Find().SetSort(taskQuerySort(query)).SetAllowDiskUse(true)
+		cursor, err = tasks.Find(ctx, filter, opts)
+	}
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var task Task
+		if err := cursor.Decode(&task); err != nil {
+			log.Println("Failed to decode tasks in StreamTasks")
+			continue
+		}
+		if err := fn(task.toDomain()); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// FetchVersions retrieves the ID and version of the live tasks matching the filters of the query, in ID order
+// Only those two fields are read, the rest of the documents stays on the server
+func (tr *taskRepository) FetchVersions(ctx context.Context, query domain.TaskQuery) ([]domain.TaskVersion, error) {
+	tasks := tr.database.Collection(tr.collection)
+	opts := options.Find().
+		SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "version", Value: 1}}).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := tasks.Find(ctx, taskQueryFilter(query), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := []domain.TaskVersion{}
+	for cursor.Next(ctx) {
+		var row struct {
+			ID      primitive.ObjectID `bson:"_id"`
+			Version int                `bson:"version"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		versions = append(versions, domain.TaskVersion{ID: row.ID.Hex(), Version: row.Version})
+	}
+	return versions, cursor.Err()
+}
+
+// urgentAtField holds the due date moved earlier by the priority lead, see domain.Task.UrgentAt
+const urgentAtField = "urgent_at"
+
+// urgentAtStage computes the urgentAtField of every task in an aggregation
+func urgentAtStage() bson.D {
+	leads := domain.PriorityLeads()
+	priorities := make([]string, 0, len(leads))
+	for priority := range leads {
+		priorities = append(priorities, string(priority))
+	}
+	// keep the pipeline stable from one call to the next
+	slices.Sort(priorities)
+
+	branches := bson.A{}
+	for _, priority := range priorities {
+		branches = append(branches, bson.D{
+			{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{"$priority", priority}}}},
+			{Key: "then", Value: leads[domain.TaskPriority(priority)].Milliseconds()},
+		})
+	}
+	lead := bson.D{{Key: "$switch", Value: bson.D{
+		{Key: "branches", Value: branches},
+		{Key: "default", Value: domain.DefaultPriority.Lead().Milliseconds()},
+	}}}
+
+	return bson.D{{Key: "$addFields", Value: bson.D{
+		{Key: urgentAtField, Value: bson.D{{Key: "$subtract", Value: bson.A{"$due_date", lead}}}},
+	}}}
+}
+
+// taskSortField returns the document field a task listing is sorted by
+func taskSortField(sortBy string) string {
+	if sortBy == domain.TaskSortByUrgency {
+		return urgentAtField
+	}
+	return sortBy
+}
+
+// taskQueryFilter translates the filters of a task query into a Mongo filter
+func taskQueryFilter(query domain.TaskQuery) bson.D {
+	filter := bson.D{notDeleted}
+	// an empty, non-nil list matches no task at all
+	if query.ProjectIDs != nil {
+		filter = append(filter, bson.E{Key: "project_id", Value: bson.D{{Key: "$in", Value: query.ProjectIDs}}})
+	}
+	if query.Status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: string(query.Status)})
+	}
+
+	dueDate := bson.D{}
+	if !query.DueAfter.IsZero() {
+		dueDate = append(dueDate, bson.E{Key: "$gte", Value: query.DueAfter})
+	}
+	if !query.DueBefore.IsZero() {
+		dueDate = append(dueDate, bson.E{Key: "$lte", Value: query.DueBefore})
+	}
+	if len(dueDate) > 0 {
+		filter = append(filter, bson.E{Key: "due_date", Value: dueDate})
+	}
+
+	if query.Title != "" {
+		filter = append(filter, bson.E{Key: "title", Value: primitive.Regex{
+			Pattern: regexp.QuoteMeta(query.Title),
+			Options: "i",
+		}})
+	}
+
+	tags := bson.D{}
+	if len(query.Tags) > 0 {
+		tags = append(tags, bson.E{Key: "$all", Value: query.Tags})
+	}
+	if len(query.AnyTags) > 0 {
+		tags = append(tags, bson.E{Key: "$in", Value: query.AnyTags})
+	}
+	if len(tags) > 0 {
+		filter = append(filter, bson.E{Key: "tags", Value: tags})
+	}
+
+	if query.Involving != "" {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "owner_id", Value: query.Involving}},
+			bson.D{{Key: "assignees", Value: query.Involving}},
+		}})
+	}
+	return filter
+}
+
+// taskKeysetFilter matches the tasks that come after query.After in the sort order of the query
+func taskKeysetFilter(query domain.TaskQuery) (bson.D, error) {
+	afterID, err := primitive.ObjectIDFromHex(query.After.ID)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	operator := "$gt"
+	if query.SortDesc {
+		operator = "$lt"
+	}
+	if query.SortBy == "" {
+		return bson.D{{Key: "_id", Value: bson.D{{Key: operator, Value: afterID}}}}, nil
+	}
+
+	field := taskSortField(query.SortBy)
+	var afterValue any = query.After.SortValue
+	if query.SortBy == domain.TaskSortByDueDate || query.SortBy == domain.TaskSortByUrgency {
+		afterValue, err = time.Parse(time.RFC3339Nano, query.After.SortValue)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+
+	// either strictly past the sort value, or equal to it and past the ID
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: field, Value: bson.D{{Key: operator, Value: afterValue}}}},
+		bson.D{
+			{Key: field, Value: afterValue},
+			{Key: "_id", Value: bson.D{{Key: operator, Value: afterID}}},
+		},
+	}}}, nil
+}
+
+// taskQuerySort translates the sort options of a task query into a Mongo sort document
+// The _id is always used as a tie breaker so pages are stable
+func taskQuerySort(query domain.TaskQuery) bson.D {
+	direction := 1
+	if query.SortDesc {
+		direction = -1
+	}
+
+	sort := bson.D{}
+	if query.SortBy != "" {
+		sort = append(sort, bson.E{Key: taskSortField(query.SortBy), Value: direction})
+	}
+	return append(sort, bson.E{Key: "_id", Value: direction})
+}
+
+// FetchByTaskIDs retrieves the live tasks whose ID is listed in taskIDs
+func (tr *taskRepository) FetchByTaskIDs(ctx context.Context, taskIDs []string) ([]domain.Task, error) {
+	objIDs := make(bson.A, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		// check for valid ID
+		objID, err := primitive.ObjectIDFromHex(taskID)
+		if err != nil {
+			return nil, domain.ErrInvalidTaskID
+		}
+		objIDs = append(objIDs, objID)
+	}
+
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: objIDs}}}, notDeleted}
+	return tr.findTasks(ctx, filter)
+}
+
+// FetchByAssignee retrieves the tasks whose assignees contain userID
+func (tr *taskRepository) FetchByAssignee(ctx context.Context, userID string) ([]domain.Task, error) {
+	filter := bson.D{{Key: "assignees", Value: userID}, notDeleted}
+	return tr.findTasks(ctx, filter)
+}
+
+// AddAssignee adds userID to the assignees of a task, ignoring duplicates
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) AddAssignee(ctx context.Context, taskID string, version int, userID string) (int, error) {
+	return tr.updateAssignees(ctx, taskID, version, "$addToSet", userID)
+}
+
+// RemoveAssignee removes userID from the assignees of a task
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) RemoveAssignee(ctx context.Context, taskID string, version int, userID string) (int, error) {
+	return tr.updateAssignees(ctx, taskID, version, "$pull", userID)
+}
+
+// AddBlocker adds blockerID to the blocked_by list of a task, ignoring duplicates
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) AddBlocker(ctx context.Context, taskID string, version int, blockerID string) (int, error) {
+	return tr.updateArray(ctx, taskID, version, "$addToSet", "blocked_by", blockerID)
+}
+
+// RemoveBlocker removes blockerID from the blocked_by list of a task
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) RemoveBlocker(ctx context.Context, taskID string, version int, blockerID string) (int, error) {
+	return tr.updateArray(ctx, taskID, version, "$pull", "blocked_by", blockerID)
+}
+
+// AddTag adds tag to the tags of a task, ignoring duplicates
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) AddTag(ctx context.Context, taskID string, version int, tag string) (int, error) {
+	return tr.updateArray(ctx, taskID, version, "$addToSet", "tags", tag)
+}
+
+// RemoveTag removes tag from the tags of a task
+// When version is set, only a document still holding that version is updated
+// Returns the number of matched documents
+func (tr *taskRepository) RemoveTag(ctx context.Context, taskID string, version int, tag string) (int, error) {
+	return tr.updateArray(ctx, taskID, version, "$pull", "tags", tag)
+}
+
+// CountTags groups the tags of the live tasks of projectIDs with the number of tasks carrying each
+// Tags are sorted by count, most used first, then alphabetically
+func (tr *taskRepository) CountTags(ctx context.Context, projectIDs []string) ([]domain.TagCount, error) {
+	tasks := tr.database.Collection(tr.collection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: taskQueryFilter(domain.TaskQuery{ProjectIDs: projectIDs})}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$tags"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := tasks.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Tag   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make([]domain.TagCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, domain.TagCount{Tag: row.Tag, Count: row.Count})
+	}
+	return counts, nil
+}
+
+// UnassignFromProject removes userID from the assignees of every task of a project, trashed ones included
+// Returns the number of modified documents
+func (tr *taskRepository) UnassignFromProject(ctx context.Context, projectID string, userID string) (int, error) {
+	tasks := tr.database.Collection(tr.collection)
+	filter := bson.D{{Key: "project_id", Value: projectID}, {Key: "assignees", Value: userID}}
+	update := bson.D{{Key: "$pull", Value: bson.D{{Key: "assignees", Value: userID}}}}
+	result, err := tasks.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// updateAssignees applies an array operator to the assignees field of a task
+func (tr *taskRepository) updateAssignees(ctx context.Context, taskID string, version int, operator string, userID string) (int, error) {
+	return tr.updateArray(ctx, taskID, version, operator, "assignees", userID)
+}
+
+// updateArray applies an array operator with value to an array field of a task and increments its version
+// Callers only write changes they checked against the version they read
+func (tr *taskRepository) updateArray(ctx context.Context, taskID string, version int, operator string, field string, value string) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return 0, domain.ErrInvalidTaskID
+	}
+
+	tasks := tr.database.Collection(tr.collection)
+	update := bson.D{
+		{Key: operator, Value: bson.D{
+			{Key: field, Value: value},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+	}
+
+	result, err := tasks.UpdateOne(ctx, versionFilter(objID, version), update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// Search runs a full-text search using the text index on title and description
+// Results are sorted by their text score, most relevant first
+func (tr *taskRepository) Search(ctx context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchResult, error) {
+	tasks := tr.database.Collection(tr.collection)
+
+	filter := taskQueryFilter(domain.TaskQuery{ProjectIDs: query.ProjectIDs})
+	filter = append(filter, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: query.Text}}})
+
+	score := bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}
+	opts := options.Find().
+		SetProjection(score).
+		SetSort(score).
+		SetLimit(int64(query.Limit))
+
+	var results []domain.TaskSearchResult
+	cursor, err := tasks.Find(ctx, filter, opts)
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var hit taskSearchHit
+		if err := cursor.Decode(&hit); err != nil {
+			log.Println("Failed to decode tasks in Search")
+			continue
+		}
+		results = append(results, domain.TaskSearchResult{
+			Task:  hit.Task.toDomain(),
+			Score: hit.Score,
+		})
+	}
+
+	return results, cursor.Err()
+}
+
+// aggregateTasks runs an aggregation against the collection and decodes the resulting tasks
+// Fields added by the pipeline are ignored
+func (tr *taskRepository) aggregateTasks(ctx context.Context, pipeline mongo.Pipeline) ([]domain.Task, error) {
+	tasks := tr.database.Collection(tr.collection)
+
+	var results []domain.Task
+	cursor, err := tasks.Aggregate(ctx, pipeline)
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var task Task
+		if err := cursor.Decode(&task); err != nil {
+			log.Println("Failed to decode tasks in aggregateTasks")
+			continue
+		}
+		results = append(results, task.toDomain())
+	}
+
+	return results, cursor.Err()
+}
+
+// findTasks runs a query against the collection and decodes the matching tasks
+func (tr *taskRepository) findTasks(ctx context.Context, filter bson.D, opts ...*options.FindOptions) ([]domain.Task, error) {
 	tasks := tr.database.Collection(tr.collection)
 
 	var results []domain.Task
-	cursor, err := tasks.Find(ctx, bson.D{})
+	cursor, err := tasks.Find(ctx, filter, opts...)
 	if err != nil {
 		return results, err
 	}
+	defer cursor.Close(ctx)
 
-	for cursor.TryNext(ctx) {
+	for cursor.Next(ctx) {
 		var task Task
 		if err := cursor.Decode(&task); err != nil {
-			log.Println("Failed to decode tasks in GetAllTasks")
+			log.Println("Failed to decode tasks in findTasks")
 			continue
 		}
 		results = append(results, task.toDomain())
 	}
 
-	return results, nil
+	return results, cursor.Err()
 }
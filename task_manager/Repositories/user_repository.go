@@ -10,14 +10,16 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // User represents a user in the system
 type User struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty"` // Unique identifier for the user
-	Username string             `bson:"username"`      // Username of the user
-	Password string             `bson:"password"`      // Hashed password (excluded from JSON responses)
-	IsAdmin  bool               `bson:"is_admin"`      // Flag indicating if the user is an admin
+	ID       primitive.ObjectID `bson:"_id,omitempty"`   // Unique identifier for the user
+	Username string             `bson:"username"`        // Username of the user
+	Password string             `bson:"password"`        // Hashed password (excluded from JSON responses)
+	IsAdmin  bool               `bson:"is_admin"`        // Flag indicating if the user is an admin
+	Email    string             `bson:"email,omitempty"` // Address notifications are sent to
 }
 
 func (u *User) toDomain() domain.User {
@@ -26,6 +28,7 @@ func (u *User) toDomain() domain.User {
 		Username: u.Username,
 		Password: u.Password,
 		IsAdmin:  u.IsAdmin,
+		Email:    u.Email,
 	}
 }
 
@@ -39,6 +42,7 @@ func fromDomainToUser(u *domain.User) (User, error) {
 		Username: u.Username,
 		Password: u.Password,
 		IsAdmin:  u.IsAdmin,
+		Email:    u.Email,
 	}, nil
 }
 
@@ -88,6 +92,7 @@ func (ur *userRepository) Create(ctx context.Context, user *domain.User) error {
 		Username: user.Username,
 		Password: user.Password,
 		IsAdmin:  user.IsAdmin,
+		Email:    user.Email,
 	}
 
 	users := ur.database.Collection(ur.collection)
@@ -131,16 +136,31 @@ func (ur *userRepository) PromoteByUserID(ctx context.Context, userID string) (i
 	return int(result.MatchedCount), err
 }
 
-// FetchAllUsers retrieves all users from the collection
-func (ur *userRepository) FetchAllUsers(ctx context.Context) ([]domain.User, error) {
+// FetchAllUsers retrieves a page of users from the collection in insertion order
+// The page starts after query.After when it is set
+func (ur *userRepository) FetchAllUsers(ctx context.Context, query domain.UserQuery) ([]domain.User, error) {
 	users := ur.database.Collection(ur.collection)
 
+	filter := bson.D{}
+	if query.After != nil {
+		afterID, err := primitive.ObjectIDFromHex(query.After.ID)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: afterID}}})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(query.Limit))
+
 	var results []domain.User
-	cursor, err := users.Find(ctx, bson.D{})
+	cursor, err := users.Find(ctx, filter, opts)
 	if err != nil {
 		return results, err
 	}
-	for cursor.TryNext(ctx) {
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
 		var user User
 		if err := cursor.Decode(&user); err != nil {
 			log.Println("Failed to decode users in GetAllUsers")
@@ -149,7 +169,7 @@ func (ur *userRepository) FetchAllUsers(ctx context.Context) ([]domain.User, err
 		results = append(results, user.toDomain())
 	}
 
-	return results, nil
+	return results, cursor.Err()
 }
 
 // CheckIfUsernameExists query the username and returns true with nil if it does
@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WebhookAttempt represents one request of a webhook delivery in the database
+type WebhookAttempt struct {
+	At         time.Time `bson:"at"`
+	StatusCode int       `bson:"status_code"`
+	Error      string    `bson:"error,omitempty"`
+	DurationMS int64     `bson:"duration_ms"`
+}
+
+// WebhookDelivery represents a queued or past webhook delivery in the database
+type WebhookDelivery struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	WebhookID     string             `bson:"webhook_id"`
+	EventID       string             `bson:"event_id"`
+	Event         string             `bson:"event"`
+	Payload       string             `bson:"payload"` // Kept as sent, so the signature of a redelivery matches
+	Status        string             `bson:"status"`
+	Attempts      []WebhookAttempt   `bson:"attempts"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	RedeliveryOf  string             `bson:"redelivery_of,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
+
+func (d *WebhookDelivery) toDomain() domain.WebhookDelivery {
+	attempts := make([]domain.WebhookAttempt, 0, len(d.Attempts))
+	for _, attempt := range d.Attempts {
+		attempts = append(attempts, domain.WebhookAttempt{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			Duration:   time.Duration(attempt.DurationMS) * time.Millisecond,
+		})
+	}
+	return domain.WebhookDelivery{
+		ID:            d.ID.Hex(),
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		Event:         d.Event,
+		Payload:       json.RawMessage(d.Payload),
+		Status:        domain.WebhookDeliveryStatus(d.Status),
+		Attempts:      attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		RedeliveryOf:  d.RedeliveryOf,
+		CreatedAt:     d.CreatedAt,
+	}
+}
+
+// webhookDeliveryRepository implements the domain.WebhookDeliveryRepository interface
+type webhookDeliveryRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the webhook deliveries collection
+}
+
+// NewWebhookDeliveryRepository returns a new webhookDeliveryRepository instance
+func NewWebhookDeliveryRepository(db mongo.Database, collection string) domain.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// EnsureWebhookDeliveryIndexes creates the indexes serving the dispatcher claiming due deliveries
+// and the delivery logs of a webhook
+func EnsureWebhookDeliveryIndexes(ctx context.Context, db mongo.Database, collection string) error {
+	deliveries := db.Collection(collection)
+	_, err := deliveries.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+			},
+			Options: options.Index().SetName("webhook_delivery_due"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "webhook_id", Value: 1},
+				{Key: "_id", Value: -1},
+			},
+			Options: options.Index().SetName("webhook_delivery_webhook"),
+		},
+	})
+	return err
+}
+
+// CreateMany inserts new deliveries and sets their generated IDs
+func (dr *webhookDeliveryRepository) CreateMany(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		documents = append(documents, WebhookDelivery{
+			WebhookID:     delivery.WebhookID,
+			EventID:       delivery.EventID,
+			Event:         delivery.Event,
+			Payload:       string(delivery.Payload),
+			Status:        string(delivery.Status),
+			Attempts:      []WebhookAttempt{},
+			NextAttemptAt: delivery.NextAttemptAt,
+			RedeliveryOf:  delivery.RedeliveryOf,
+			CreatedAt:     delivery.CreatedAt,
+		})
+	}
+
+	result, err := dr.database.Collection(dr.collection).InsertMany(ctx, documents)
+	if err != nil {
+		return err
+	}
+	for i, insertedID := range result.InsertedIDs {
+		objID, ok := insertedID.(primitive.ObjectID)
+		if !ok {
+			return fmt.Errorf("unexpected InsertedID type: %T", insertedID)
+		}
+		deliveries[i].ID = objID.Hex()
+	}
+	return nil
+}
+
+// FetchByID retrieves a delivery by its ID
+func (dr *webhookDeliveryRepository) FetchByID(ctx context.Context, deliveryID string) (domain.WebhookDelivery, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, domain.ErrInvalidWebhookDeliveryID
+	}
+
+	deliveries := dr.database.Collection(dr.collection)
+	var delivery WebhookDelivery
+	err = deliveries.FindOne(ctx, bson.D{{Key: "_id", Value: objID}}).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return delivery.toDomain(), nil
+}
+
+// FetchByWebhookID retrieves the latest deliveries of a webhook, newest first
+func (dr *webhookDeliveryRepository) FetchByWebhookID(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	deliveries := dr.database.Collection(dr.collection)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	results := []domain.WebhookDelivery{}
+	cursor, err := deliveries.Find(ctx, bson.D{{Key: "webhook_id", Value: webhookID}}, opts)
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var delivery WebhookDelivery
+		if err := cursor.Decode(&delivery); err != nil {
+			log.Println("Failed to decode webhook delivery in FetchByWebhookID")
+			continue
+		}
+		results = append(results, delivery.toDomain())
+	}
+	return results, cursor.Err()
+}
+
+// ClaimDue atomically takes the pending delivery due the longest and pushes its next attempt back by lease
+// A dispatcher that stops before recording the attempt leaves the delivery to be claimed again once the lease ends
+func (dr *webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (domain.WebhookDelivery, error) {
+	deliveries := dr.database.Collection(dr.collection)
+
+	filter := bson.D{
+		{Key: "status", Value: string(domain.DeliveryPending)},
+		{Key: "next_attempt_at", Value: bson.D{{Key: "$lte", Value: now}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "next_attempt_at", Value: now.Add(lease)}}}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery WebhookDelivery
+	err := deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.WebhookDelivery{}, domain.ErrNoWebhookDeliveryDue
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return delivery.toDomain(), nil
+}
+
+// RecordAttempt appends an attempt to the log of a delivery and sets its status and next attempt
+func (dr *webhookDeliveryRepository) RecordAttempt(ctx context.Context, deliveryID string, attempt domain.WebhookAttempt, status domain.WebhookDeliveryStatus, nextAttemptAt time.Time) error {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(deliveryID)
+	if err != nil {
+		return domain.ErrInvalidWebhookDeliveryID
+	}
+
+	deliveries := dr.database.Collection(dr.collection)
+	update := bson.D{
+		{Key: "$push", Value: bson.D{{Key: "attempts", Value: WebhookAttempt{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMS: attempt.Duration.Milliseconds(),
+		}}}},
+		{Key: "$set", Value: bson.D{
+			{Key: "status", Value: string(status)},
+			{Key: "next_attempt_at", Value: nextAttemptAt},
+		}},
+	}
+	result, err := deliveries.UpdateOne(ctx, bson.D{{Key: "_id", Value: objID}}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Webhook represents a webhook subscription in the database
+type Webhook struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	URL       string             `bson:"url"`
+	Events    []string           `bson:"events"`
+	Secret    string             `bson:"secret"`
+	Active    bool               `bson:"active"`
+	CreatedBy string             `bson:"created_by"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+func (w *Webhook) toDomain() domain.Webhook {
+	return domain.Webhook{
+		ID:        w.ID.Hex(),
+		URL:       w.URL,
+		Events:    w.Events,
+		Secret:    w.Secret,
+		Active:    w.Active,
+		CreatedBy: w.CreatedBy,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// webhookRepository implements the domain.WebhookRepository interface
+type webhookRepository struct {
+	database   mongo.Database // MongoDB database instance
+	collection string         // Name of the webhooks collection
+}
+
+// NewWebhookRepository returns a new webhookRepository instance
+func NewWebhookRepository(db mongo.Database, collection string) domain.WebhookRepository {
+	return &webhookRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// Create inserts a new webhook and sets its generated ID
+func (wr *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	webhooks := wr.database.Collection(wr.collection)
+
+	result, err := webhooks.InsertOne(ctx, Webhook{
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Secret:    webhook.Secret,
+		Active:    webhook.Active,
+		CreatedBy: webhook.CreatedBy,
+		CreatedAt: webhook.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		webhook.ID = objID.Hex()
+	}
+	return nil
+}
+
+// FetchByID retrieves a webhook by its ID
+func (wr *webhookRepository) FetchByID(ctx context.Context, webhookID string) (domain.Webhook, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return domain.Webhook{}, domain.ErrInvalidWebhookID
+	}
+
+	webhooks := wr.database.Collection(wr.collection)
+	var webhook Webhook
+	err = webhooks.FindOne(ctx, bson.D{{Key: "_id", Value: objID}}).Decode(&webhook)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	return webhook.toDomain(), nil
+}
+
+// FetchAll retrieves every webhook in the order they were created
+func (wr *webhookRepository) FetchAll(ctx context.Context) ([]domain.Webhook, error) {
+	return wr.find(ctx, bson.D{})
+}
+
+// FetchActiveByEvent retrieves the active webhooks whose events include the given one
+func (wr *webhookRepository) FetchActiveByEvent(ctx context.Context, event string) ([]domain.Webhook, error) {
+	return wr.find(ctx, bson.D{
+		{Key: "active", Value: true},
+		{Key: "events", Value: event},
+	})
+}
+
+// find retrieves the webhooks matching filter in the order they were created
+func (wr *webhookRepository) find(ctx context.Context, filter bson.D) ([]domain.Webhook, error) {
+	webhooks := wr.database.Collection(wr.collection)
+
+	results := []domain.Webhook{}
+	cursor, err := webhooks.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return results, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var webhook Webhook
+		if err := cursor.Decode(&webhook); err != nil {
+			log.Println("Failed to decode webhook")
+			continue
+		}
+		results = append(results, webhook.toDomain())
+	}
+	return results, cursor.Err()
+}
+
+// Update replaces the URL, events, secret and state of a webhook
+// Returns the number of matched documents
+func (wr *webhookRepository) Update(ctx context.Context, webhook *domain.Webhook) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(webhook.ID)
+	if err != nil {
+		return 0, domain.ErrInvalidWebhookID
+	}
+
+	webhooks := wr.database.Collection(wr.collection)
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "url", Value: webhook.URL},
+		{Key: "events", Value: webhook.Events},
+		{Key: "secret", Value: webhook.Secret},
+		{Key: "active", Value: webhook.Active},
+	}}}
+	result, err := webhooks.UpdateOne(ctx, bson.D{{Key: "_id", Value: objID}}, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MatchedCount), nil
+}
+
+// Delete removes a webhook
+// Returns the number of deleted documents
+func (wr *webhookRepository) Delete(ctx context.Context, webhookID string) (int, error) {
+	// check for valid ID
+	objID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return 0, domain.ErrInvalidWebhookID
+	}
+
+	webhooks := wr.database.Collection(wr.collection)
+	result, err := webhooks.DeleteOne(ctx, bson.D{{Key: "_id", Value: objID}})
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
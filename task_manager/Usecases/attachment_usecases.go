@@ -0,0 +1,265 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// maxFilenameLength is the maximum number of characters kept in the name of an attachment
+const maxFilenameLength = 255
+
+// sniffLength is how many leading bytes of a file are inspected to detect its media type
+const sniffLength = 512
+
+// attachmentUsecase implements the domain.AttachmentUsecase interface
+type attachmentUsecase struct {
+	attachmentRepository domain.AttachmentRepository // Repository for attachment metadata
+	taskRepository       domain.TaskRepository       // Repository used to check the task is visible to the actor
+	projectRepository    domain.ProjectRepository    // Repository used to check the actor is a member of the project of the task
+	blobStore            domain.BlobStore            // Store holding the content of the attached files
+	maxSize              int64                       // Largest accepted file, in bytes
+	allowedTypes         []string                    // Accepted media types, "type/*" accepting every subtype
+	contextTimeout       time.Duration               // Timeout duration for each usecase operation
+}
+
+// NewAttachmentUsecase creates a new instance of attachmentUsecase
+func NewAttachmentUsecase(attachmentRepository domain.AttachmentRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, blobStore domain.BlobStore, maxSize int64, allowedTypes []string, timeout time.Duration) domain.AttachmentUsecase {
+	return &attachmentUsecase{
+		attachmentRepository: attachmentRepository,
+		taskRepository:       taskRepository,
+		projectRepository:    projectRepository,
+		blobStore:            blobStore,
+		maxSize:              maxSize,
+		allowedTypes:         allowedTypes,
+		contextTimeout:       timeout,
+	}
+}
+
+// Upload stores a file and attaches it to a task the actor may see
+// The media type is detected from the content of the file rather than trusted from the client
+// Only the metadata calls are bounded by the usecase timeout; the content is streamed for as long as c allows
+func (au *attachmentUsecase) Upload(c context.Context, taskID string, upload domain.AttachmentUpload, actor domain.Actor) (domain.Attachment, error) {
+	filename, err := parseFilename(upload.Filename)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if upload.Size <= 0 {
+		return domain.Attachment{}, domain.ErrEmptyAttachment
+	}
+	if upload.Size > au.maxSize {
+		return domain.Attachment{}, domain.ErrAttachmentTooLarge
+	}
+
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	err = checkTaskVisible(ctx, au.taskRepository, au.projectRepository, taskID, actor)
+	cancel()
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return domain.Attachment{}, err
+	}
+	head = head[:n]
+
+	contentType := detectContentType(head)
+	if !au.typeAllowed(contentType) {
+		return domain.Attachment{}, domain.ErrAttachmentTypeNotAllowed
+	}
+
+	blobID, err := newID()
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	key := taskID + "/" + blobID
+	content := io.MultiReader(bytes.NewReader(head), upload.Content)
+	// a large file on a slow connection can take longer than the timeout to arrive
+	if err := au.blobStore.Put(c, key, content, upload.Size, contentType); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	ctx, cancel = context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	attachment := domain.Attachment{
+		TaskID:      taskID,
+		UploaderID:  actor.ID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        upload.Size,
+		StorageKey:  key,
+		CreatedAt:   time.Now(),
+	}
+	if err := au.attachmentRepository.Create(ctx, &attachment); err != nil {
+		// the content would never be reachable without its metadata
+		if err := au.blobStore.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete blob %s of a failed upload: %v", key, err)
+		}
+		return domain.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// FetchByTaskID retrieves the attachments of a task the actor may see, oldest first
+func (au *attachmentUsecase) FetchByTaskID(c context.Context, taskID string, actor domain.Actor) ([]domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	if err := checkTaskVisible(ctx, au.taskRepository, au.projectRepository, taskID, actor); err != nil {
+		return nil, err
+	}
+	return au.attachmentRepository.FetchByTaskID(ctx, taskID)
+}
+
+// Open retrieves an attachment of a task the actor may see along with its content
+// The caller must close the returned reader
+func (au *attachmentUsecase) Open(c context.Context, taskID string, attachmentID string, actor domain.Actor) (domain.Attachment, io.ReadCloser, error) {
+	attachment, err := au.fetchAttachment(c, taskID, attachmentID, actor)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	// the content is streamed after Open returns, so it is bound to the caller's context rather than the usecase timeout
+	content, err := au.blobStore.Get(c, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			return domain.Attachment{}, nil, domain.ErrAttachmentNotFound
+		}
+		return domain.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// Delete removes an attachment and its content
+// Only the uploader of the file or an admin may delete it
+func (au *attachmentUsecase) Delete(c context.Context, taskID string, attachmentID string, actor domain.Actor) error {
+	attachment, err := au.fetchAttachment(c, taskID, attachmentID, actor)
+	if err != nil {
+		return err
+	}
+	if !attachment.CanBeDeletedBy(actor) {
+		return domain.ErrNotAttachmentUploader
+	}
+
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	// the metadata goes first so a failure never leaves an attachment without content
+	deleted, err := au.attachmentRepository.Delete(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	if err := au.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+		log.Printf("failed to delete blob %s of attachment %s: %v", attachment.StorageKey, attachmentID, err)
+	}
+	return nil
+}
+
+// DeleteByTaskIDs removes the attachments of the given tasks along with their content
+// Attachments whose content cannot be deleted are kept so that nothing stays in the store unreferenced
+func (au *attachmentUsecase) DeleteByTaskIDs(c context.Context, taskIDs []string) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	attachments, err := au.attachmentRepository.FetchByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return err
+	}
+
+	deleted := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if err := au.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+			log.Printf("failed to delete blob %s of attachment %s: %v", attachment.StorageKey, attachment.ID, err)
+			continue
+		}
+		deleted = append(deleted, attachment.ID)
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	_, err = au.attachmentRepository.DeleteMany(ctx, deleted)
+	return err
+}
+
+// fetchAttachment retrieves an attachment of taskID, which the actor must be able to see
+// Returns ErrAttachmentNotFound for an attachment of another task
+func (au *attachmentUsecase) fetchAttachment(c context.Context, taskID string, attachmentID string, actor domain.Actor) (domain.Attachment, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	if err := checkTaskVisible(ctx, au.taskRepository, au.projectRepository, taskID, actor); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	attachment, err := au.attachmentRepository.FetchByID(ctx, attachmentID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if attachment.TaskID != taskID {
+		return domain.Attachment{}, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// typeAllowed reports whether files of the given media type may be attached
+func (au *attachmentUsecase) typeAllowed(contentType string) bool {
+	for _, allowed := range au.allowedTypes {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasSuffix(prefix, "/") {
+			if strings.HasPrefix(contentType, prefix) {
+				return true
+			}
+			continue
+		}
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// detectContentType returns the media type of a file from its leading bytes, without parameters
+func detectContentType(head []byte) string {
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return contentType
+}
+
+// parseFilename keeps the last element of a client-supplied file name, without control characters
+func parseFilename(filename string) (string, error) {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename)
+	filename = strings.TrimSpace(filename)
+
+	if filename == "" || filename == "." || filename == ".." || filename == "/" {
+		return "", domain.ErrInvalidFilename
+	}
+	if utf8.RuneCountInString(filename) > maxFilenameLength {
+		return "", domain.ErrInvalidFilename
+	}
+	return filename, nil
+}
@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// calendarTokenBytes is the number of random bytes in a feed token
+const calendarTokenBytes = 32
+
+// calendarUsecase implements the domain.CalendarUsecase interface
+type calendarUsecase struct {
+	tokenRepository   domain.CalendarTokenRepository // Repository storing the hashes of the feed tokens
+	userRepository    domain.UserRepository          // Repository the owners of the feeds are looked up in
+	taskRepository    domain.TaskRepository          // Repository the tasks of the feeds are streamed from
+	projectRepository domain.ProjectRepository       // Repository used to limit a feed to the projects of its owner
+	exporter          domain.TaskExporter            // Renders the feeds
+	contextTimeout    time.Duration                  // Timeout duration for rendering a feed
+}
+
+// NewCalendarUsecase creates a new instance of calendarUsecase
+func NewCalendarUsecase(tokenRepository domain.CalendarTokenRepository, userRepository domain.UserRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, exporter domain.TaskExporter, timeout time.Duration) domain.CalendarUsecase {
+	return &calendarUsecase{
+		tokenRepository:   tokenRepository,
+		userRepository:    userRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		exporter:          exporter,
+		contextTimeout:    timeout,
+	}
+}
+
+// hashCalendarToken returns the hash a feed token is stored and looked up by
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IssueToken generates a random token for the actor and stores its hash in place of their previous one
+func (cu *calendarUsecase) IssueToken(c context.Context, actor domain.Actor) (string, error) {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	b := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// URL-safe, the token is part of the feed path
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	err := cu.tokenRepository.Save(ctx, &domain.CalendarToken{
+		UserID:    actor.ID,
+		TokenHash: hashCalendarToken(token),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeToken deletes the token of the actor, after which their feed URL stops working
+func (cu *calendarUsecase) RevokeToken(c context.Context, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	deleted, err := cu.tokenRepository.DeleteByUserID(ctx, actor.ID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrCalendarTokenNotFound
+	}
+	return nil
+}
+
+// FetchFeed renders the tasks the owner of the token owns or is assigned to, in the projects they still belong to
+// The entity tag is derived from the ID and version of every task rather than the document, whose DTSTAMP changes
+// on every render, so that a client polling with the current tag is answered without streaming the tasks
+func (cu *calendarUsecase) FetchFeed(c context.Context, token string, ifNoneMatch string) (domain.CalendarFeed, error) {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	stored, err := cu.tokenRepository.FetchByHash(ctx, hashCalendarToken(token))
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+
+	// admin rights are read from the user, they may have changed since the token was issued
+	user, err := cu.userRepository.FetchByUserID(ctx, stored.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.CalendarFeed{}, domain.ErrCalendarTokenNotFound
+	}
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	actor := domain.Actor{ID: user.ID, IsAdmin: user.IsAdmin}
+
+	query := domain.TaskQuery{Involving: user.ID, SortBy: domain.TaskSortByDueDate}
+	if err := normalizeTaskQuery(&query); err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	if err := scopeTaskQuery(ctx, cu.projectRepository, &query, actor); err != nil {
+		return domain.CalendarFeed{}, err
+	}
+
+	// the query holds the projects of the user, leaving one changes the tag even if no task count does
+	versions, err := cu.taskRepository.FetchVersions(ctx, query)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	key, err := json.Marshal(struct {
+		Query    domain.TaskQuery
+		Versions []domain.TaskVersion
+	}{query, versions})
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	sum := sha256.Sum256(key)
+	etag := fmt.Sprintf("%q", hex.EncodeToString(sum[:]))
+	if etagMatches(ifNoneMatch, etag) {
+		return domain.CalendarFeed{ETag: etag, NotModified: true}, nil
+	}
+
+	// a task edited after the summary was read is served under the previous tag, the next poll picks up the new one
+	var content bytes.Buffer
+	encoder := cu.exporter.NewFeedEncoder(&content)
+	err = cu.taskRepository.StreamTasks(ctx, query, func(task domain.Task) error {
+		return encoder.Encode(task)
+	})
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	if err := encoder.Close(); err != nil {
+		return domain.CalendarFeed{}, err
+	}
+
+	return domain.CalendarFeed{ETag: etag, Content: content.Bytes()}, nil
+}
+
+// etagMatches reports whether an If-None-Match header lists the entity tag
+// Comparison is weak, as RFC 9110 requires for If-None-Match
+func etagMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// commentUsecase implements the domain.CommentUsecase interface
+type commentUsecase struct {
+	commentRepository domain.CommentRepository // Repository for comment data operations
+	taskRepository    domain.TaskRepository    // Repository used to check the commented task is visible to the actor
+	projectRepository domain.ProjectRepository // Repository used to check the actor is a member of the project of the task
+	cursorService     domain.CursorService     // Service for encoding and decoding pagination cursors
+	contextTimeout    time.Duration            // Timeout duration for each usecase operation
+}
+
+// NewCommentUsecase creates a new instance of commentUsecase
+func NewCommentUsecase(commentRepository domain.CommentRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, cursorService domain.CursorService, timeout time.Duration) domain.CommentUsecase {
+	return &commentUsecase{
+		commentRepository: commentRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		cursorService:     cursorService,
+		contextTimeout:    timeout,
+	}
+}
+
+// Create posts a comment on a task the actor may see
+func (cu *commentUsecase) Create(c context.Context, taskID string, body string, actor domain.Actor) (domain.Comment, error) {
+	body, err := parseCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	if err := checkTaskVisible(ctx, cu.taskRepository, cu.projectRepository, taskID, actor); err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment := domain.Comment{
+		TaskID:    taskID,
+		AuthorID:  actor.ID,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+	if err := cu.commentRepository.Create(ctx, &comment); err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+// FetchByTaskID retrieves one page of the comments of a task the actor may see, oldest first
+func (cu *commentUsecase) FetchByTaskID(c context.Context, query domain.CommentQuery, actor domain.Actor) (domain.CommentPage, error) {
+	if query.Limit < 0 {
+		return domain.CommentPage{}, domain.ErrInvalidQuery
+	}
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultPageSize
+	}
+	if query.Limit > domain.MaxPageSize {
+		query.Limit = domain.MaxPageSize
+	}
+
+	if query.Cursor != "" {
+		cursor, err := cu.cursorService.Decode(query.Cursor)
+		if err != nil {
+			return domain.CommentPage{}, err
+		}
+		query.After = &cursor
+	}
+
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	if err := checkTaskVisible(ctx, cu.taskRepository, cu.projectRepository, query.TaskID, actor); err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	// fetch one extra comment to find out whether there is a next page
+	limit := query.Limit
+	query.Limit++
+	comments, err := cu.commentRepository.FetchByTaskID(ctx, query)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	page := domain.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		page.NextCursor, err = cu.cursorService.Encode(domain.Cursor{ID: page.Comments[limit-1].ID})
+		if err != nil {
+			return domain.CommentPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// Update replaces the body of a comment
+// Only the author of the comment or an admin may edit it
+func (cu *commentUsecase) Update(c context.Context, taskID string, commentID string, body string, actor domain.Actor) (domain.Comment, error) {
+	body, err := parseCommentBody(body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	comment, err := cu.fetchChangeable(ctx, taskID, commentID, actor)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	editedAt := time.Now()
+	matched, err := cu.commentRepository.UpdateBody(ctx, commentID, body, editedAt)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	// deleted in the meantime
+	if matched == 0 {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+
+	comment.Body = body
+	comment.EditedAt = &editedAt
+	return comment, nil
+}
+
+// Delete removes a comment
+// Only the author of the comment or an admin may delete it
+func (cu *commentUsecase) Delete(c context.Context, taskID string, commentID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	if _, err := cu.fetchChangeable(ctx, taskID, commentID, actor); err != nil {
+		return err
+	}
+
+	deleted, err := cu.commentRepository.Delete(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// fetchChangeable retrieves a comment of taskID the actor is allowed to edit or delete
+// Returns ErrCommentNotFound for a comment of another task, ErrNotCommentAuthor if the actor may not change it
+func (cu *commentUsecase) fetchChangeable(ctx context.Context, taskID string, commentID string, actor domain.Actor) (domain.Comment, error) {
+	if err := checkTaskVisible(ctx, cu.taskRepository, cu.projectRepository, taskID, actor); err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := cu.commentRepository.FetchByID(ctx, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.TaskID != taskID {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+	if !comment.CanBeChangedBy(actor) {
+		return domain.Comment{}, domain.ErrNotCommentAuthor
+	}
+	return comment, nil
+}
+
+// parseCommentBody trims a comment body and checks its length
+func parseCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", domain.ErrEmptyComment
+	}
+	if utf8.RuneCountInString(body) > domain.MaxCommentLength {
+		return "", domain.ErrCommentTooLong
+	}
+	return body, nil
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// snippetRadius is the number of bytes kept on each side of the first match in a snippet
+const snippetRadius = 60
+
+// searchTerms extracts the terms worth highlighting from a text search string
+// Negated terms ("-word") are dropped and quotes are ignored
+func searchTerms(text string) []string {
+	var terms []string
+	for _, field := range strings.Fields(strings.ReplaceAll(text, `"`, " ")) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+// highlight builds a snippet of text around the first occurrence of any term
+// with every occurrence wrapped in <mark> tags; it reports false if no term occurs
+// The text is HTML-escaped, so the <mark> tags are the only markup of the snippet
+func highlight(text string, terms []string) (string, bool) {
+	if text == "" || len(terms) == 0 {
+		return "", false
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	pattern := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+
+	first := pattern.FindStringIndex(text)
+	if first == nil {
+		return "", false
+	}
+
+	// cut a window around the first match without splitting a character
+	start := max(first[0]-snippetRadius, 0)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	end := min(first[1]+snippetRadius, len(text))
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	window := text[start:end]
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(window, -1) {
+		b.WriteString(html.EscapeString(window[last:match[0]]))
+		b.WriteString("<mark>" + html.EscapeString(window[match[0]:match[1]]) + "</mark>")
+		last = match[1]
+	}
+	b.WriteString(html.EscapeString(window[last:]))
+
+	snippet := b.String()
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet, true
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newID generates a random identifier for values that are not documents of their own,
+// such as the series of a recurring task or the items of a checklist
+func newID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
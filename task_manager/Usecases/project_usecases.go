@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// projectUsecase implements the domain.ProjectUsecase interface
+type projectUsecase struct {
+	projectRepository domain.ProjectRepository // Repository for project data operations
+	userRepository    domain.UserRepository    // Repository used to validate new members
+	taskRepository    domain.TaskRepository    // Repository used to unassign removed members from the tasks of the project
+	contextTimeout    time.Duration            // Timeout duration for each usecase operation
+}
+
+// NewProjectUsecase creates a new instance of projectUsecase
+func NewProjectUsecase(projectRepository domain.ProjectRepository, userRepository domain.UserRepository, taskRepository domain.TaskRepository, timeout time.Duration) domain.ProjectUsecase {
+	return &projectUsecase{
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		taskRepository:    taskRepository,
+		contextTimeout:    timeout,
+	}
+}
+
+// Create adds a new project with the actor as its only owner
+func (pu *projectUsecase) Create(c context.Context, project *domain.Project, actor domain.Actor) error {
+	name, err := parseProjectName(project.Name)
+	if err != nil {
+		return err
+	}
+	project.Name = name
+	project.Description = strings.TrimSpace(project.Description)
+	project.Members = []domain.ProjectMember{{UserID: actor.ID, Role: domain.ProjectRoleOwner}}
+	project.CreatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+	return pu.projectRepository.Create(ctx, project)
+}
+
+// FetchByID retrieves a project the actor is a member of
+// Any other project is reported as not found
+func (pu *projectUsecase) FetchByID(c context.Context, projectID string, actor domain.Actor) (domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	project, err := pu.projectRepository.FetchByID(ctx, projectID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if _, ok := project.RoleOfActor(actor); !ok {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// FetchAll retrieves the projects the actor is a member of, every project for admins
+func (pu *projectUsecase) FetchAll(c context.Context, actor domain.Actor) ([]domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	if actor.IsAdmin {
+		return pu.projectRepository.FetchAll(ctx)
+	}
+	return pu.projectRepository.FetchByMember(ctx, actor.ID)
+}
+
+// Update renames a project and replaces its description
+// On success the project is filled in with its members
+func (pu *projectUsecase) Update(c context.Context, project *domain.Project, actor domain.Actor) error {
+	name, err := parseProjectName(project.Name)
+	if err != nil {
+		return err
+	}
+	project.Name = name
+	project.Description = strings.TrimSpace(project.Description)
+
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	before, err := pu.fetchManageable(ctx, project.ID, actor)
+	if err != nil {
+		return err
+	}
+
+	matched, err := pu.projectRepository.Update(ctx, project)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	project.Members = before.Members
+	project.CreatedAt = before.CreatedAt
+	return nil
+}
+
+// AddMember gives an existing user a role in a project
+func (pu *projectUsecase) AddMember(c context.Context, projectID string, member domain.ProjectMember, actor domain.Actor) error {
+	role, err := domain.ParseProjectRole(string(member.Role))
+	if err != nil {
+		return err
+	}
+	member.Role = role
+
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	project, err := pu.fetchManageable(ctx, projectID, actor)
+	if err != nil {
+		return err
+	}
+	if _, ok := project.RoleOf(member.UserID); ok {
+		return domain.ErrProjectMemberExists
+	}
+
+	// make sure the new member exists
+	if _, err := pu.userRepository.FetchByUserID(ctx, member.UserID); err != nil {
+		return err
+	}
+
+	modified, err := pu.projectRepository.AddMember(ctx, projectID, member)
+	if err != nil {
+		return err
+	}
+	// the user was added in the meantime
+	if modified == 0 {
+		return domain.ErrProjectMemberExists
+	}
+	return nil
+}
+
+// UpdateMemberRole changes the role of a member of a project
+// Returns ErrLastProjectOwner rather than leaving the project without an owner
+func (pu *projectUsecase) UpdateMemberRole(c context.Context, projectID string, userID string, role domain.ProjectRole, actor domain.Actor) error {
+	role, err := domain.ParseProjectRole(string(role))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	project, err := pu.fetchManageable(ctx, projectID, actor)
+	if err != nil {
+		return err
+	}
+	current, ok := project.RoleOf(userID)
+	if !ok {
+		return domain.ErrNotProjectMember
+	}
+	if current == domain.ProjectRoleOwner && role != domain.ProjectRoleOwner && project.OwnerCount() == 1 {
+		return domain.ErrLastProjectOwner
+	}
+
+	matched, err := pu.projectRepository.UpdateMemberRole(ctx, projectID, userID, role)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return domain.ErrNotProjectMember
+	}
+	return nil
+}
+
+// RemoveMember takes a user out of a project and off the tasks of the project they were assigned to
+// Owners may remove anyone, every member may leave on their own
+// Returns ErrLastProjectOwner rather than leaving the project without an owner
+func (pu *projectUsecase) RemoveMember(c context.Context, projectID string, userID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	var project domain.Project
+	var err error
+	if userID == actor.ID {
+		project, err = pu.FetchByID(ctx, projectID, actor)
+	} else {
+		project, err = pu.fetchManageable(ctx, projectID, actor)
+	}
+	if err != nil {
+		return err
+	}
+
+	role, ok := project.RoleOf(userID)
+	if !ok {
+		return domain.ErrNotProjectMember
+	}
+	if role == domain.ProjectRoleOwner && project.OwnerCount() == 1 {
+		return domain.ErrLastProjectOwner
+	}
+
+	modified, err := pu.projectRepository.RemoveMember(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if modified == 0 {
+		return domain.ErrNotProjectMember
+	}
+
+	// the member is gone either way, leftover assignments are only logged
+	if _, err := pu.taskRepository.UnassignFromProject(ctx, projectID, userID); err != nil {
+		log.Printf("failed to unassign user %s from the tasks of project %s: %v", userID, projectID, err)
+	}
+	return nil
+}
+
+// fetchManageable retrieves a project the actor may manage
+// Returns ErrProjectNotFound for non-members and ErrNotProjectOwner for members who are not owners
+func (pu *projectUsecase) fetchManageable(ctx context.Context, projectID string, actor domain.Actor) (domain.Project, error) {
+	project, err := pu.projectRepository.FetchByID(ctx, projectID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	role, ok := project.RoleOfActor(actor)
+	if !ok {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	if !role.CanManage() {
+		return domain.Project{}, domain.ErrNotProjectOwner
+	}
+	return project, nil
+}
+
+// parseProjectName trims a project name and checks its length
+func parseProjectName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > domain.MaxProjectNameLength {
+		return "", domain.ErrInvalidProjectName
+	}
+	return name, nil
+}
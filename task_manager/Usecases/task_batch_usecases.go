@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// taskBatchUsecase implements the domain.TaskBatchUsecase interface
+type taskBatchUsecase struct {
+	taskUsecase    domain.TaskUsecase // Usecase applying each operation, with the same checks as the single task endpoints
+	transactor     domain.Transactor  // Runs atomic batches in a database transaction
+	contextTimeout time.Duration      // Time given to each operation of an atomic batch
+	batchTimeout   time.Duration      // Upper bound of the deadline of the transaction of an atomic batch as a whole
+}
+
+// NewTaskBatchUsecase creates a new instance of taskBatchUsecase
+func NewTaskBatchUsecase(taskUsecase domain.TaskUsecase, transactor domain.Transactor, timeout time.Duration, batchTimeout time.Duration) domain.TaskBatchUsecase {
+	return &taskBatchUsecase{
+		taskUsecase:    taskUsecase,
+		transactor:     transactor,
+		contextTimeout: timeout,
+		batchTimeout:   batchTimeout,
+	}
+}
+
+// Execute applies the operations of a batch in order and reports the outcome of each
+// Without atomic, every operation is applied on its own and failures do not stop the batch
+// With atomic, the batch runs in a transaction rolled back as soon as one operation fails;
+// the results then hold the error of that operation and ErrBatchAborted for every other one,
+// and ErrBatchRolledBack is returned. The transaction, retries included, must finish within the usecase timeout
+// per operation, capped by the batch timeout; ErrTransactionsUnsupported is returned on a standalone server,
+// which cannot run transactions
+func (bu *taskBatchUsecase) Execute(c context.Context, operations []domain.BatchOperation, atomic bool, actor domain.Actor) ([]domain.BatchResult, error) {
+	if len(operations) == 0 {
+		return nil, domain.ErrEmptyBatch
+	}
+	if len(operations) > domain.MaxBatchSize {
+		return nil, domain.ErrBatchTooLarge
+	}
+
+	if !atomic {
+		results := make([]domain.BatchResult, len(operations))
+		for i, operation := range operations {
+			results[i] = bu.apply(c, i, operation, actor)
+		}
+		return results, nil
+	}
+
+	// each operation has its own timeout, the transaction holding their locks needs one as a whole
+	ctx, cancel := context.WithTimeout(c, min(bu.contextTimeout*time.Duration(len(operations)), bu.batchTimeout))
+	defer cancel()
+
+	var results []domain.BatchResult
+	failed := -1
+	err := bu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// the transaction starts over on transient errors
+		results = make([]domain.BatchResult, len(operations))
+		failed = -1
+		for i, operation := range operations {
+			results[i] = bu.apply(ctx, i, operation, actor)
+			if results[i].Err != nil {
+				failed = i
+				// returned as is so the transaction is retried when the error is transient
+				return results[i].Err
+			}
+		}
+		return nil
+	})
+	if failed >= 0 {
+		for i, operation := range operations {
+			if i != failed {
+				results[i] = domain.BatchResult{Index: i, Op: operation.Op, TaskID: operation.TaskID, Err: domain.ErrBatchAborted}
+			}
+		}
+		return results, domain.ErrBatchRolledBack
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// apply runs one operation of a batch through the task usecase
+func (bu *taskBatchUsecase) apply(ctx context.Context, index int, operation domain.BatchOperation, actor domain.Actor) domain.BatchResult {
+	result := domain.BatchResult{Index: index, Op: operation.Op, TaskID: operation.TaskID}
+
+	switch operation.Op {
+	case domain.BatchCreate:
+		task := operation.Task
+		if result.Err = bu.taskUsecase.Create(ctx, &task, actor); result.Err == nil {
+			result.TaskID = task.ID
+			result.Task = &task
+		}
+	case domain.BatchUpdate:
+		patch := operation.Patch
+		patch.Version = operation.Version
+		task, err := bu.taskUsecase.PatchByTaskID(ctx, operation.TaskID, patch, actor)
+		// a patch repeating the stored values still succeeds
+		if err == nil || errors.Is(err, domain.ErrNoChangesMade) {
+			result.Task = &task
+		} else {
+			result.Err = err
+		}
+	case domain.BatchDelete:
+		result.Err = bu.taskUsecase.DeleteByTaskID(ctx, operation.TaskID, operation.Version, actor)
+	default:
+		result.Err = domain.ErrInvalidBatchOp
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// AddDependency marks a task as blocked by another one
+// Returns ErrDependencyCycle if the blocker already depends on the task, directly or not
+func (tu *taskUsecase) AddDependency(c context.Context, taskID string, blockerID string, actor domain.Actor) error {
+	if taskID == blockerID {
+		return domain.ErrDependencyCycle
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	return retryEdit(func() error {
+		// keep the current state around for the history diff
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return err
+		}
+		if slices.Contains(before.BlockedBy, blockerID) {
+			return domain.ErrDependencyExists
+		}
+
+		blocker, err := tu.taskRepository.FetchByTaskID(ctx, blockerID)
+		if err != nil {
+			return err
+		}
+		// a blocker from another project would leak into the conflicts reported to its members
+		if blocker.ProjectID != before.ProjectID {
+			return domain.ErrProjectMismatch
+		}
+		cycle, err := tu.dependsOn(ctx, blocker, taskID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return domain.ErrDependencyCycle
+		}
+
+		matched, err := tu.taskRepository.AddBlocker(ctx, taskID, before.Version, blockerID)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return domain.ErrVersionConflict
+		}
+		if err := tu.verifyNoCycle(ctx, taskID, blockerID); err != nil {
+			return err
+		}
+
+		after := before
+		after.BlockedBy = append(slices.Clone(before.BlockedBy), blockerID)
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return nil
+	})
+}
+
+// verifyNoCycle checks again that blockerID does not depend on taskID once the dependency is written
+// Two dependencies closing a loop can pass the check before either is written; whichever is written last
+// sees the other one here and takes its own back, returning ErrDependencyCycle
+func (tu *taskUsecase) verifyNoCycle(ctx context.Context, taskID string, blockerID string) error {
+	blocker, err := tu.taskRepository.FetchByTaskID(ctx, blockerID)
+	cycle := false
+	if err == nil {
+		cycle, err = tu.dependsOn(ctx, blocker, taskID)
+	}
+	if err == nil && !cycle {
+		return nil
+	}
+
+	// the version moved on with the write, and the edge is ours to take back whatever changed since
+	if _, rollbackErr := tu.taskRepository.RemoveBlocker(ctx, taskID, 0, blockerID); rollbackErr != nil {
+		log.Printf("failed to remove dependency of task %s on %s: %v", taskID, blockerID, rollbackErr)
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrDependencyCycle
+}
+
+// RemoveDependency lifts the block of a task by another one
+func (tu *taskUsecase) RemoveDependency(c context.Context, taskID string, blockerID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	return retryEdit(func() error {
+		// keep the current state around for the history diff
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return err
+		}
+		if !slices.Contains(before.BlockedBy, blockerID) {
+			return domain.ErrDependencyNotFound
+		}
+
+		matched, err := tu.taskRepository.RemoveBlocker(ctx, taskID, before.Version, blockerID)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return domain.ErrVersionConflict
+		}
+
+		after := before
+		after.BlockedBy = slices.DeleteFunc(slices.Clone(before.BlockedBy), func(id string) bool { return id == blockerID })
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return nil
+	})
+}
+
+// dependsOn reports whether task is blocked by taskID, directly or through other blockers
+// The dependency graph is walked breadth first, one repository call per level
+func (tu *taskUsecase) dependsOn(ctx context.Context, task domain.Task, taskID string) (bool, error) {
+	seen := map[string]bool{task.ID: true}
+	frontier := task.BlockedBy
+	for len(frontier) > 0 {
+		var unseen []string
+		for _, id := range frontier {
+			if id == taskID {
+				return true, nil
+			}
+			if !seen[id] {
+				seen[id] = true
+				unseen = append(unseen, id)
+			}
+		}
+		if len(unseen) == 0 {
+			break
+		}
+
+		blockers, err := tu.taskRepository.FetchByTaskIDs(ctx, unseen)
+		if err != nil {
+			return false, err
+		}
+		frontier = nil
+		for _, blocker := range blockers {
+			frontier = append(frontier, blocker.BlockedBy...)
+		}
+	}
+	return false, nil
+}
+
+// checkBlockers returns a BlockedError listing the blockers of task that are not completed yet
+// Blockers that have been deleted no longer hold the task back
+func (tu *taskUsecase) checkBlockers(ctx context.Context, task domain.Task) error {
+	if len(task.BlockedBy) == 0 {
+		return nil
+	}
+
+	blockers, err := tu.taskRepository.FetchByTaskIDs(ctx, task.BlockedBy)
+	if err != nil {
+		return err
+	}
+	open := slices.DeleteFunc(blockers, func(blocker domain.Task) bool { return blocker.Status == domain.StatusCompleted })
+	if len(open) > 0 {
+		return &domain.BlockedError{Blockers: open}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// taskEventPublisher implements the domain.TaskEventPublisher interface by queueing webhook deliveries
+type taskEventPublisher struct {
+	webhookRepository  domain.WebhookRepository         // Repository the subscribed webhooks are looked up in
+	deliveryRepository domain.WebhookDeliveryRepository // Persisted queue the deliveries are added to
+}
+
+// NewTaskEventPublisher creates a new instance of taskEventPublisher
+func NewTaskEventPublisher(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository) domain.TaskEventPublisher {
+	return &taskEventPublisher{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+	}
+}
+
+// webhookPayload is the JSON body posted to webhooks
+type webhookPayload struct {
+	ID         string               `json:"id"` // ID of the event, shared by its deliveries
+	Event      string               `json:"event"`
+	OccurredAt time.Time            `json:"occurred_at"`
+	ActorID    string               `json:"actor_id"`
+	Task       domain.Task          `json:"task"`
+	Changes    []domain.FieldChange `json:"changes,omitempty"`
+}
+
+// Publish queues a delivery of the event to each subscribed webhook, due immediately
+// The deliveries are written with ctx, so they belong to the transaction of a batch when there is one
+func (p *taskEventPublisher) Publish(ctx context.Context, event domain.TaskEvent) error {
+	webhooks, err := p.webhookRepository.FetchActiveByEvent(ctx, event.Type)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	eventID, err := newID()
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(webhookPayload{
+		ID:         eventID,
+		Event:      event.Type,
+		OccurredAt: event.OccurredAt,
+		ActorID:    event.ActorID,
+		Task:       event.Task,
+		Changes:    event.Changes,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]*domain.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       eventID,
+			Event:         event.Type,
+			Payload:       payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	return p.deliveryRepository.CreateMany(ctx, deliveries)
+}
+
+// taskEventTypes lists the webhook events a history action is published as
+// Restores and updates changing nothing are not published
+func taskEventTypes(action string, changes []domain.FieldChange) []string {
+	switch action {
+	case domain.TaskActionCreate:
+		return []string{domain.WebhookEventTaskCreated}
+	case domain.TaskActionDelete:
+		return []string{domain.WebhookEventTaskDeleted}
+	case domain.TaskActionUpdate:
+		// saving a task unchanged is not an event
+		if len(changes) == 0 {
+			return nil
+		}
+		types := []string{domain.WebhookEventTaskUpdated}
+		for _, change := range changes {
+			if change.Field == "status" {
+				types = append(types, domain.WebhookEventTaskStatusChanged)
+				break
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
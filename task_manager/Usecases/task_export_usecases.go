@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"io"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// taskExportUsecase implements the domain.TaskExportUsecase interface
+type taskExportUsecase struct {
+	taskRepository    domain.TaskRepository    // Repository the exported tasks are streamed from
+	projectRepository domain.ProjectRepository // Repository used to limit the export to the projects of the actor
+	exporter          domain.TaskExporter      // Encodes the tasks in the requested format
+	contextTimeout    time.Duration            // Timeout duration for checking the query
+}
+
+// NewTaskExportUsecase creates a new instance of taskExportUsecase
+func NewTaskExportUsecase(taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, exporter domain.TaskExporter, timeout time.Duration) domain.TaskExportUsecase {
+	return &taskExportUsecase{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		exporter:          exporter,
+		contextTimeout:    timeout,
+	}
+}
+
+// Export writes the tasks matching the query to w, with the filters and visibility of FetchAllTasks
+// Only checking the query is bound by the usecase timeout; streaming lasts as long as c,
+// since large exports take longer than any single query
+func (eu *taskExportUsecase) Export(c context.Context, query domain.TaskQuery, format domain.ExportFormat, w io.Writer, actor domain.Actor) error {
+	query.Limit, query.Offset, query.Cursor = 0, 0, ""
+	if err := normalizeTaskQuery(&query); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, eu.contextTimeout)
+	err := scopeTaskQuery(ctx, eu.projectRepository, &query, actor)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	encoder, err := eu.exporter.NewEncoder(format, w)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = eu.taskRepository.StreamTasks(c, query, func(task domain.Task) error {
+		task.Urgency = task.UrgencyAt(now)
+		return encoder.Encode(task)
+	})
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// recordHistory appends a history entry for a change made by actor and publishes it to the webhooks
+// A failure to record or publish is logged rather than returned, since the change itself has already been applied
+func (tu *taskUsecase) recordHistory(ctx context.Context, task domain.Task, action string, actor domain.Actor, changes []domain.FieldChange) {
+	appendHistory(ctx, tu.historyRepository, tu.eventPublisher, task, action, actor, changes)
+}
+
+// appendHistory is recordHistory for usecases other than taskUsecase
+func appendHistory(ctx context.Context, historyRepository domain.TaskHistoryRepository, eventPublisher domain.TaskEventPublisher, task domain.Task, action string, actor domain.Actor, changes []domain.FieldChange) {
+	entry := domain.TaskHistoryEntry{
+		TaskID:    task.ID,
+		ActorID:   actor.ID,
+		Action:    action,
+		Timestamp: time.Now(),
+		Changes:   changes,
+	}
+	if err := historyRepository.Append(ctx, &entry); err != nil {
+		log.Printf("failed to record %s of task %s in history: %v", action, task.ID, err)
+	}
+
+	for _, eventType := range taskEventTypes(action, changes) {
+		event := domain.TaskEvent{
+			Type:       eventType,
+			Task:       task,
+			ActorID:    actor.ID,
+			Changes:    changes,
+			OccurredAt: entry.Timestamp,
+		}
+		if err := eventPublisher.Publish(ctx, event); err != nil {
+			log.Printf("failed to publish %s of task %s: %v", eventType, task.ID, err)
+		}
+	}
+}
+
+// diffTasks lists the fields that differ between two versions of a task
+func diffTasks(before, after domain.Task) []domain.FieldChange {
+	var changes []domain.FieldChange
+	add := func(field string, before, after any) {
+		changes = append(changes, domain.FieldChange{Field: field, Before: before, After: after})
+	}
+
+	if before.Title != after.Title {
+		add("title", before.Title, after.Title)
+	}
+	if before.Description != after.Description {
+		add("description", before.Description, after.Description)
+	}
+	if !before.DueDate.Equal(after.DueDate) {
+		add("due_date", before.DueDate, after.DueDate)
+	}
+	if before.Status != after.Status {
+		add("status", string(before.Status), string(after.Status))
+	}
+	if before.Priority != after.Priority {
+		add("priority", string(before.Priority), string(after.Priority))
+	}
+	if before.Recurrence != after.Recurrence {
+		add("recurrence", before.Recurrence, after.Recurrence)
+	}
+	if !slices.Equal(before.Assignees, after.Assignees) {
+		add("assignees", before.Assignees, after.Assignees)
+	}
+	if !slices.Equal(before.Checklist, after.Checklist) {
+		add("checklist", before.Checklist, after.Checklist)
+	}
+	if !slices.Equal(before.BlockedBy, after.BlockedBy) {
+		add("blocked_by", before.BlockedBy, after.BlockedBy)
+	}
+	if !slices.Equal(before.Tags, after.Tags) {
+		add("tags", before.Tags, after.Tags)
+	}
+	return changes
+}
+
+// creationChanges lists the initial value of every field set on a new task
+func creationChanges(task domain.Task) []domain.FieldChange {
+	changes := diffTasks(domain.Task{}, task)
+	for i := range changes {
+		changes[i].Before = nil
+	}
+	return changes
+}
@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// taskImportUsecase implements the domain.TaskImportUsecase interface
+type taskImportUsecase struct {
+	taskRepository    domain.TaskRepository        // Repository the imported tasks are written to
+	historyRepository domain.TaskHistoryRepository // Repository recording the creation of every imported task
+	projectRepository domain.ProjectRepository     // Repository used to check the actor may add tasks to each project
+	eventPublisher    domain.TaskEventPublisher    // Hands the creation of every imported task to the webhooks
+	contextTimeout    time.Duration                // Timeout duration for each usecase operation
+}
+
+// NewTaskImportUsecase creates a new instance of taskImportUsecase
+func NewTaskImportUsecase(taskRepository domain.TaskRepository, historyRepository domain.TaskHistoryRepository, projectRepository domain.ProjectRepository, eventPublisher domain.TaskEventPublisher, timeout time.Duration) domain.TaskImportUsecase {
+	return &taskImportUsecase{
+		taskRepository:    taskRepository,
+		historyRepository: historyRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		contextTimeout:    timeout,
+	}
+}
+
+// importRow is one task of an import file, with the fields of POST /tasks
+type importRow struct {
+	ProjectID   string   `json:"project_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	DueDate     string   `json:"due_date"` // RFC 3339 timestamp, or a YYYY-MM-DD date meaning the end of that day in UTC
+	Status      string   `json:"status"`
+	Priority    string   `json:"priority"`
+	RRule       string   `json:"rrule"`
+	Tags        []string `json:"tags"`
+}
+
+// importColumns are the CSV columns of an import file, in the order of a template file
+var importColumns = []string{"project_id", "title", "description", "due_date", "status", "priority", "rrule", "tags"}
+
+// toTask builds the task described by the row, owned by ownerID
+func (r importRow) toTask(ownerID string) (domain.Task, error) {
+	if strings.TrimSpace(r.Title) == "" {
+		return domain.Task{}, domain.ErrEmptyTitle
+	}
+	dueDate, err := parseImportDueDate(r.DueDate)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return domain.Task{
+		ProjectID:   strings.TrimSpace(r.ProjectID),
+		Title:       r.Title,
+		Description: r.Description,
+		DueDate:     dueDate,
+		Status:      domain.TaskStatus(r.Status),
+		Priority:    domain.TaskPriority(r.Priority),
+		OwnerID:     ownerID,
+		Recurrence:  r.RRule,
+		Tags:        r.Tags,
+	}, nil
+}
+
+// parseImportDueDate reads the due date of a row
+func parseImportDueDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("%w: due_date is required", domain.ErrInvalidImportValue)
+	}
+	if dueDate, err := time.Parse(time.RFC3339, s); err == nil {
+		return dueDate, nil
+	}
+	day, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: due_date %q is neither an RFC 3339 timestamp nor a YYYY-MM-DD date", domain.ErrInvalidImportValue, s)
+	}
+	return day.Add(24*time.Hour - time.Second), nil
+}
+
+// Import validates every row of the file with the rules of Create and creates the valid ones unless dryRun is set
+// Rows are independent: an invalid row is reported and skipped, it does not stop the import
+func (iu *taskImportUsecase) Import(c context.Context, format domain.ImportFormat, file io.Reader, dryRun bool, actor domain.Actor) (domain.ImportReport, error) {
+	var rows []importRow
+	var err error
+	switch format {
+	case domain.ImportCSV:
+		rows, err = readCSVImport(file)
+	case domain.ImportJSON:
+		rows, err = readJSONImport(file)
+	default:
+		return domain.ImportReport{}, domain.ErrInvalidImportFormat
+	}
+	if err != nil {
+		return domain.ImportReport{}, err
+	}
+	if len(rows) == 0 {
+		return domain.ImportReport{}, domain.ErrEmptyImport
+	}
+
+	ctx, cancel := context.WithTimeout(c, iu.contextTimeout)
+	defer cancel()
+
+	report := domain.ImportReport{DryRun: dryRun, Rows: make([]domain.ImportRow, len(rows))}
+	tasks := make([]*domain.Task, 0, len(rows))
+	taskRows := make([]int, 0, len(rows)) // index in report.Rows of each task
+	projects := make(map[string]error)    // outcome of the project check, by project ID
+
+	for i, row := range rows {
+		report.Rows[i] = domain.ImportRow{Row: i + 1, Title: row.Title}
+
+		task, err := row.toTask(actor.ID)
+		if err == nil {
+			err = prepareNewTask(&task)
+		}
+		if err == nil {
+			checked, ok := projects[task.ProjectID]
+			if !ok {
+				checked = iu.checkProject(ctx, task.ProjectID, actor)
+				switch {
+				case checked == nil, errors.Is(checked, domain.ErrProjectNotFound), errors.Is(checked, domain.ErrInvalidProjectID),
+					errors.Is(checked, domain.ErrProjectReadOnly):
+				default:
+					// a failing data store stops the import rather than failing every row
+					return domain.ImportReport{}, checked
+				}
+				projects[task.ProjectID] = checked
+			}
+			err = checked
+		}
+		if err != nil {
+			report.Rows[i].Err = err
+			report.Invalid++
+			continue
+		}
+
+		report.Valid++
+		tasks = append(tasks, &task)
+		taskRows = append(taskRows, i)
+	}
+
+	if dryRun || len(tasks) == 0 {
+		return report, nil
+	}
+
+	if err := iu.taskRepository.CreateMany(ctx, tasks); err != nil {
+		return domain.ImportReport{}, err
+	}
+	for i, task := range tasks {
+		report.Rows[taskRows[i]].TaskID = task.ID
+		appendHistory(ctx, iu.historyRepository, iu.eventPublisher, *task, domain.TaskActionCreate, actor, creationChanges(*task))
+	}
+	report.Imported = len(tasks)
+	return report, nil
+}
+
+// checkProject checks that actor may add tasks to the project, like Create does
+func (iu *taskImportUsecase) checkProject(ctx context.Context, projectID string, actor domain.Actor) error {
+	project, err := iu.projectRepository.FetchByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	role, ok := project.RoleOfActor(actor)
+	if !ok {
+		return domain.ErrProjectNotFound
+	}
+	if !role.CanEditTasks() {
+		return domain.ErrProjectReadOnly
+	}
+	return nil
+}
+
+// readCSVImport reads the rows of a CSV file
+// The first record names the columns, in any order; tags are separated by commas within their cell
+func readCSVImport(file io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domain.ErrEmptyImport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImport, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q, columns are %s", domain.ErrInvalidImport, name, strings.Join(importColumns, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", domain.ErrInvalidImport, name)
+		}
+		columns[name] = i
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImport, err)
+		}
+		if len(rows) == domain.MaxImportRows {
+			return nil, domain.ErrImportTooLarge
+		}
+
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return record[i]
+			}
+			return ""
+		}
+		row := importRow{
+			ProjectID:   cell("project_id"),
+			Title:       cell("title"),
+			Description: cell("description"),
+			DueDate:     cell("due_date"),
+			Status:      cell("status"),
+			Priority:    cell("priority"),
+			RRule:       cell("rrule"),
+		}
+		for _, tag := range strings.Split(cell("tags"), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.Tags = append(row.Tags, tag)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// readJSONImport reads the rows of a JSON file holding an array of tasks
+func readJSONImport(file io.Reader) ([]importRow, error) {
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	var rows []importRow
+	if err := decoder.Decode(&rows); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, domain.ErrEmptyImport
+		}
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImport, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: unexpected data after the array of tasks", domain.ErrInvalidImport)
+	}
+	if len(rows) > domain.MaxImportRows {
+		return nil, domain.ErrImportTooLarge
+	}
+	return rows, nil
+}
@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// SetRecurrence makes a task repeat on the given RRULE, or changes the rule of the series it belongs to
+// Returns the updated task
+func (tu *taskUsecase) SetRecurrence(c context.Context, taskID string, recurrence string, actor domain.Actor) (domain.Task, error) {
+	rule, err := domain.ParseRRule(recurrence)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+		return domain.Task{}, err
+	}
+
+	// a one-off task starts a new series
+	seriesID := before.SeriesID
+	if seriesID == "" {
+		if seriesID, err = newID(); err != nil {
+			return domain.Task{}, err
+		}
+	}
+
+	count, err := tu.taskRepository.UpdateRecurrence(ctx, taskID, seriesID, rule.String())
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if count == 0 {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+
+	task, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	tu.recordHistory(ctx, task, domain.TaskActionUpdate, actor, diffTasks(before, task))
+	return task, nil
+}
+
+// StopRecurrence removes the recurrence of a task and of the rest of its series
+// Existing occurrences are kept, but no further one is generated
+func (tu *taskUsecase) StopRecurrence(c context.Context, taskID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+		return err
+	}
+	if before.Recurrence == "" {
+		return domain.ErrNotRecurring
+	}
+
+	count, err := tu.taskRepository.UpdateRecurrence(ctx, taskID, before.SeriesID, "")
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	after := before
+	after.Recurrence = ""
+	tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+	return nil
+}
+
+// MarkOverdueMissed moves every pending task due before now to missed, recording the change as SystemActor,
+// and generates the next occurrence of the recurring ones
+// Each task is updated on its own, conditional on the version it was read at, so that a task completed,
+// edited or rescheduled while the sweep runs is left alone and nothing is recorded for it
+// Returns the number of tasks marked as missed
+func (tu *taskUsecase) MarkOverdueMissed(c context.Context, now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	count := 0
+	query := domain.TaskQuery{Status: domain.StatusPending, DueBefore: now}
+	err := tu.taskRepository.StreamTasks(ctx, query, func(task domain.Task) error {
+		// the bound of the query is inclusive, a task due right now is not overdue yet
+		if !task.DueDate.Before(now) {
+			return nil
+		}
+		matched, err := tu.taskRepository.MarkMissed(ctx, task.ID, task.Version, now)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return nil // changed since it was read, the next sweep looks at it again
+		}
+		count++
+
+		after := task
+		after.Status = domain.StatusMissed
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, domain.SystemActor, diffTasks(task, after))
+		tu.scheduleNextOccurrence(ctx, after, domain.SystemActor, now)
+		return nil
+	})
+	return count, err
+}
+
+// scheduleNextOccurrence creates the occurrence following task in its series
+// Occurrences whose due date is already past now are skipped, but still count towards COUNT
+// Failures are logged rather than returned, since the change that ended the occurrence has already been applied
+func (tu *taskUsecase) scheduleNextOccurrence(ctx context.Context, task domain.Task, actor domain.Actor, now time.Time) {
+	if task.Recurrence == "" {
+		return
+	}
+	rule, err := domain.ParseRRule(task.Recurrence)
+	if err != nil {
+		log.Printf("failed to parse recurrence of task %s: %v", task.ID, err)
+		return
+	}
+
+	dueDate, occurrence := task.DueDate, task.Occurrence
+	for {
+		next, ok := rule.Next(dueDate, occurrence)
+		if !ok {
+			return // the series is over
+		}
+		dueDate, occurrence = next, occurrence+1
+		if dueDate.After(now) {
+			break
+		}
+	}
+
+	next := domain.Task{
+		ProjectID:   task.ProjectID,
+		Title:       task.Title,
+		Description: task.Description,
+		DueDate:     dueDate,
+		Status:      domain.StatusPending,
+		Priority:    task.Priority,
+		OwnerID:     task.OwnerID,
+		Assignees:   slices.Clone(task.Assignees),
+		Tags:        slices.Clone(task.Tags),
+		Recurrence:  task.Recurrence,
+		SeriesID:    task.SeriesID,
+		Occurrence:  occurrence,
+	}
+	if err := tu.taskRepository.Create(ctx, &next); err != nil {
+		// the occurrence was already generated, e.g. when a missed task is completed afterwards
+		if !errors.Is(err, domain.ErrOccurrenceExists) {
+			log.Printf("failed to create occurrence %d of task %s: %v", occurrence, task.ID, err)
+		}
+		return
+	}
+	tu.recordHistory(ctx, next, domain.TaskActionCreate, actor, creationChanges(next))
+}
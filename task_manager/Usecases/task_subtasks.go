@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// FetchSubtasks retrieves the subtasks of a task the actor may see, in their order
+func (tu *taskUsecase) FetchSubtasks(c context.Context, taskID string, actor domain.Actor) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	// the visibility rules of the parent apply to its subtasks as well
+	parent, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanRead(ctx, tu.projectRepository, parent, actor); err != nil {
+		return nil, err
+	}
+	return tu.taskRepository.FetchSubtasks(ctx, taskID)
+}
+
+// CreateSubtask adds a new task under parentID, after the existing subtasks
+// The subtask belongs to the project of its parent
+func (tu *taskUsecase) CreateSubtask(c context.Context, parentID string, task *domain.Task, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	parent, err := tu.taskRepository.FetchByTaskID(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, parent, actor); err != nil {
+		return err
+	}
+	siblings, err := tu.taskRepository.FetchSubtasks(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	task.ProjectID = parent.ProjectID
+	task.ParentID = parentID
+	task.Position = len(siblings)
+	return tu.Create(ctx, task, actor)
+}
+
+// ReorderSubtasks orders the subtasks of a task as listed in taskIDs
+// taskIDs must list every subtask exactly once; returns the subtasks in their new order
+func (tu *taskUsecase) ReorderSubtasks(c context.Context, parentID string, taskIDs []string, actor domain.Actor) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	parent, err := tu.taskRepository.FetchByTaskID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, parent, actor); err != nil {
+		return nil, err
+	}
+	subtasks, err := tu.taskRepository.FetchSubtasks(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := reorder(subtasks, taskIDs, func(t domain.Task) string { return t.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := tu.taskRepository.UpdateSubtaskPositions(ctx, parentID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	// a subtask was deleted or moved in the meantime
+	if matched != len(taskIDs) {
+		return nil, domain.ErrVersionConflict
+	}
+
+	for i := range ordered {
+		ordered[i].Position = i
+	}
+	return ordered, nil
+}
+
+// AddChecklistItem appends an item to the checklist of a task
+// Returns the created item
+func (tu *taskUsecase) AddChecklistItem(c context.Context, taskID string, text string, actor domain.Actor) (domain.ChecklistItem, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return domain.ChecklistItem{}, domain.ErrEmptyChecklistItem
+	}
+	id, err := newID()
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	item := domain.ChecklistItem{ID: id, Text: text}
+
+	_, err = tu.editChecklist(c, taskID, actor, func(items []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		return append(items, item), nil
+	})
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+// UpdateChecklistItem changes the text or completion state of a checklist item
+// Returns the updated item
+func (tu *taskUsecase) UpdateChecklistItem(c context.Context, taskID string, itemID string, patch domain.ChecklistItemPatch, actor domain.Actor) (domain.ChecklistItem, error) {
+	if patch.IsEmpty() {
+		return domain.ChecklistItem{}, domain.ErrEmptyPatch
+	}
+	if patch.Text != nil {
+		text := strings.TrimSpace(*patch.Text)
+		if text == "" {
+			return domain.ChecklistItem{}, domain.ErrEmptyChecklistItem
+		}
+		patch.Text = &text
+	}
+
+	var updated domain.ChecklistItem
+	_, err := tu.editChecklist(c, taskID, actor, func(items []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		i := slices.IndexFunc(items, func(item domain.ChecklistItem) bool { return item.ID == itemID })
+		if i < 0 {
+			return nil, domain.ErrChecklistItemNotFound
+		}
+		if patch.Text != nil {
+			items[i].Text = *patch.Text
+		}
+		if patch.Done != nil {
+			items[i].Done = *patch.Done
+		}
+		updated = items[i]
+		return items, nil
+	})
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return updated, nil
+}
+
+// RemoveChecklistItem deletes an item from the checklist of a task
+func (tu *taskUsecase) RemoveChecklistItem(c context.Context, taskID string, itemID string, actor domain.Actor) error {
+	_, err := tu.editChecklist(c, taskID, actor, func(items []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		remaining := slices.DeleteFunc(items, func(item domain.ChecklistItem) bool { return item.ID == itemID })
+		if len(remaining) == len(items) {
+			return nil, domain.ErrChecklistItemNotFound
+		}
+		return remaining, nil
+	})
+	return err
+}
+
+// ReorderChecklist orders the checklist of a task as listed in itemIDs
+// itemIDs must list every item exactly once; returns the checklist in its new order
+func (tu *taskUsecase) ReorderChecklist(c context.Context, taskID string, itemIDs []string, actor domain.Actor) ([]domain.ChecklistItem, error) {
+	return tu.editChecklist(c, taskID, actor, func(items []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		return reorder(items, itemIDs, func(item domain.ChecklistItem) string { return item.ID })
+	})
+}
+
+// editChecklist applies edit to the stored checklist of a task and writes the result back
+// The write is conditional on the version read, and starts over if the task changed in between
+// Returns the checklist as written
+func (tu *taskUsecase) editChecklist(c context.Context, taskID string, actor domain.Actor, edit func([]domain.ChecklistItem) ([]domain.ChecklistItem, error)) ([]domain.ChecklistItem, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	for attempt := 0; attempt < maxEditAttempts; attempt++ {
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return nil, err
+		}
+
+		checklist, err := edit(slices.Clone(before.Checklist))
+		if err != nil {
+			return nil, err
+		}
+
+		matched, err := tu.taskRepository.UpdateChecklist(ctx, taskID, before.Version, checklist)
+		if err != nil {
+			return nil, err
+		}
+		if matched == 0 {
+			continue
+		}
+
+		after := before
+		after.Checklist = checklist
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return checklist, nil
+	}
+	return nil, domain.ErrVersionConflict
+}
+
+// reorder returns values in the order of ids
+// Returns ErrInvalidOrder unless ids lists the id of every value exactly once
+func reorder[T any](values []T, ids []string, id func(T) string) ([]T, error) {
+	if len(ids) != len(values) {
+		return nil, domain.ErrInvalidOrder
+	}
+
+	byID := make(map[string]T, len(values))
+	for _, value := range values {
+		byID[id(value)] = value
+	}
+
+	ordered := make([]T, 0, len(ids))
+	for _, key := range ids {
+		value, ok := byID[key]
+		if !ok {
+			return nil, domain.ErrInvalidOrder
+		}
+		// a repeated id would otherwise hide a missing one
+		delete(byID, key)
+		ordered = append(ordered, value)
+	}
+	return ordered, nil
+}
+
+// withProgress computes the progress of a task from its checklist and subtasks
+func (tu *taskUsecase) withProgress(ctx context.Context, task domain.Task) (domain.Task, error) {
+	subtasks, err := tu.taskRepository.FetchSubtasks(ctx, task.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.Progress = domain.TaskProgress(task, subtasks)
+	return task, nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// AddTag labels a task with tag
+func (tu *taskUsecase) AddTag(c context.Context, taskID string, tag string, actor domain.Actor) error {
+	tag, err := domain.ParseTag(tag)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	return retryEdit(func() error {
+		// keep the current state around for the history diff
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return err
+		}
+		if slices.Contains(before.Tags, tag) {
+			return domain.ErrTagExists
+		}
+
+		matched, err := tu.taskRepository.AddTag(ctx, taskID, before.Version, tag)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return domain.ErrVersionConflict
+		}
+
+		after := before
+		after.Tags = append(slices.Clone(before.Tags), tag)
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return nil
+	})
+}
+
+// RemoveTag removes a tag from a task
+func (tu *taskUsecase) RemoveTag(c context.Context, taskID string, tag string, actor domain.Actor) error {
+	tag, err := domain.ParseTag(tag)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	return retryEdit(func() error {
+		// keep the current state around for the history diff
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return err
+		}
+		if !slices.Contains(before.Tags, tag) {
+			return domain.ErrTagNotFound
+		}
+
+		matched, err := tu.taskRepository.RemoveTag(ctx, taskID, before.Version, tag)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return domain.ErrVersionConflict
+		}
+
+		after := before
+		after.Tags = slices.DeleteFunc(slices.Clone(before.Tags), func(t string) bool { return t == tag })
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return nil
+	})
+}
+
+// FetchTags retrieves the tags in use with the number of tasks carrying each
+// Only the tasks visible to the actor are counted
+func (tu *taskUsecase) FetchTags(c context.Context, actor domain.Actor) ([]domain.TagCount, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	projectIDs, err := memberProjectIDs(ctx, tu.projectRepository, actor)
+	if err != nil {
+		return nil, err
+	}
+	return tu.taskRepository.CountTags(ctx, projectIDs)
+}
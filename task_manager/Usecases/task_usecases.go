@@ -2,6 +2,10 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,81 +14,670 @@ import (
 
 // taskUsecase implements the domain.TaskUsecase interface
 type taskUsecase struct {
-	taskRepository domain.TaskRepository // Repository for task data operations
-	contextTimeout time.Duration         // Timeout duration for each usecase operation
+	taskRepository    domain.TaskRepository        // Repository for task data operations
+	userRepository    domain.UserRepository        // Repository used to validate assignees
+	historyRepository domain.TaskHistoryRepository // Repository recording every change made to a task
+	projectRepository domain.ProjectRepository     // Repository used to scope every task to the members of its project
+	eventPublisher    domain.TaskEventPublisher    // Hands every change to the webhooks subscribed to it
+	attachmentUsecase domain.AttachmentUsecase     // Removes the attachments of purged tasks
+	commentRepository domain.CommentRepository     // Removes the comments of purged tasks
+	cursorService     domain.CursorService         // Service for encoding and decoding pagination cursors
+	trashRetention    time.Duration                // How long deleted tasks stay in the trash before they can be purged
+	contextTimeout    time.Duration                // Timeout duration for each usecase operation
 }
 
 // NewTaskUsecase creates a new instance of taskUsecase
-func NewTaskUsecase(taskRepository domain.TaskRepository, timeout time.Duration) domain.TaskUsecase {
+func NewTaskUsecase(taskRepository domain.TaskRepository, userRepository domain.UserRepository, historyRepository domain.TaskHistoryRepository, projectRepository domain.ProjectRepository, eventPublisher domain.TaskEventPublisher, attachmentUsecase domain.AttachmentUsecase, commentRepository domain.CommentRepository, cursorService domain.CursorService, trashRetention time.Duration, timeout time.Duration) domain.TaskUsecase {
 	return &taskUsecase{
-		taskRepository: taskRepository,
-		contextTimeout: timeout,
+		taskRepository:    taskRepository,
+		userRepository:    userRepository,
+		historyRepository: historyRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		attachmentUsecase: attachmentUsecase,
+		commentRepository: commentRepository,
+		cursorService:     cursorService,
+		trashRetention:    trashRetention,
+		contextTimeout:    timeout,
 	}
 }
 
-// Create adds a new task using the repository with a timeout context
-func (tu *taskUsecase) Create(c context.Context, task *domain.Task) error {
+// Create adds a new task to a project the actor may change the tasks of
+func (tu *taskUsecase) Create(c context.Context, task *domain.Task, actor domain.Actor) error {
+	if err := prepareNewTask(task); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	// the project is looked up even for admins, a task cannot belong to a missing project
+	project, err := tu.projectRepository.FetchByID(ctx, task.ProjectID)
+	if err != nil {
+		return err
+	}
+	role, ok := project.RoleOfActor(actor)
+	if !ok {
+		return domain.ErrProjectNotFound
+	}
+	if !role.CanEditTasks() {
+		return domain.ErrProjectReadOnly
+	}
+
+	if err := tu.taskRepository.Create(ctx, task); err != nil {
+		return err
+	}
+
+	tu.recordHistory(ctx, *task, domain.TaskActionCreate, actor, creationChanges(*task))
+	return nil
+}
+
+// prepareNewTask validates a task about to be created and normalizes its fields
+// Shared by every way of creating a task, so they all accept the same tasks
+func prepareNewTask(task *domain.Task) error {
+	if task.ProjectID == "" {
+		return domain.ErrProjectRequired
+	}
+
+	status, err := domain.ParseTaskStatus(string(task.Status))
+	if err != nil {
+		return err
+	}
+	// every task starts pending and reaches the other statuses through the transition graph
+	if status != domain.StatusPending {
+		return fmt.Errorf("%w: a new task starts as %s, not %s", domain.ErrInvalidStatusTransition, domain.StatusPending, status)
+	}
+	task.Status = status
+
+	task.Priority, err = parsePriority(task.Priority, domain.DefaultPriority)
+	if err != nil {
+		return err
+	}
 
 	if task.DueDate.Before(time.Now()) {
 		return domain.ErrInvalidDueDate
 	}
-	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
-	defer cancel()
-	return tu.taskRepository.Create(ctx, task)
+
+	if task.Tags, err = domain.ParseTags(task.Tags); err != nil {
+		return err
+	}
+
+	// a recurring task starts a new series
+	task.SeriesID, task.Occurrence = "", 0
+	if task.Recurrence != "" {
+		rule, err := domain.ParseRRule(task.Recurrence)
+		if err != nil {
+			return err
+		}
+		if task.SeriesID, err = newID(); err != nil {
+			return err
+		}
+		task.Recurrence = rule.String()
+		task.Occurrence = 1
+	}
+	return nil
 }
 
 // UpdateByTaskID updates an existing task using its ID
 // Returns the number of matched and modified documents
-func (tu *taskUsecase) UpdateByTaskID(c context.Context, task *domain.Task) error {
+func (tu *taskUsecase) UpdateByTaskID(c context.Context, task *domain.Task, actor domain.Actor) error {
 	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
 	defer cancel()
 
-	// Normalize status field
-	task.Status = strings.ToLower(strings.TrimSpace(task.Status))
+	status, err := domain.ParseTaskStatus(string(task.Status))
+	if err != nil {
+		return err
+	}
+	task.Status = status
 
 	// Validate due date
 	if task.DueDate.Before(time.Now()) {
 		return domain.ErrInvalidDueDate
 	}
 
-	matched, modified, err := tu.taskRepository.UpdateByTaskID(ctx, task)
+	// keep the current state around for the status check and the history diff
+	before, err := tu.taskRepository.FetchByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+		return err
+	}
+	if err := before.Status.TransitionTo(task.Status); err != nil {
+		return err
+	}
+	// the write only applies to the version the checks and the history diff are based on
+	if task.Version == 0 {
+		task.Version = before.Version
+	}
+
+	// leaving the priority out keeps the current one
+	task.Priority, err = parsePriority(task.Priority, before.Priority)
+	if err != nil {
+		return err
+	}
+	if task.Status == domain.StatusCompleted && before.Status != domain.StatusCompleted {
+		if err := tu.checkBlockers(ctx, before); err != nil {
+			return err
+		}
+	}
+
+	// the write also only applies to the status the transition was checked from
+	matched, modified, err := tu.taskRepository.UpdateByTaskID(ctx, task, before.Status)
 	if err != nil {
 		return err
 	}
 
 	if matched == 0 {
-		return domain.ErrTaskNotFound
+		return tu.missingOrConflict(ctx, task.ID)
 	}
 
 	if modified == 0 {
 		return domain.ErrNoChangesMade
 	}
 
+	// the stored version moved on by one
+	task.Version++
+
+	// a full update only writes these fields, the rest of the task is left as it was read
+	after := before
+	after.Title = task.Title
+	after.Description = task.Description
+	after.DueDate = task.DueDate
+	after.Status = task.Status
+	after.Priority = task.Priority
+	after.Version = task.Version
+	tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+
+	// a missed occurrence already got its successor from the overdue sweep
+	if before.Status == domain.StatusPending && task.Status == domain.StatusCompleted {
+		tu.scheduleNextOccurrence(ctx, after, actor, time.Now())
+	}
 	return nil
 }
 
-// DeleteByTaskID deletes a task using its ID
-// Returns the number of documents deleted
-func (tu *taskUsecase) DeleteByTaskID(c context.Context, taskID string) error {
+// maxEditAttempts bounds how often an edit is retried when the task changes concurrently
+const maxEditAttempts = 3
+
+// retryEdit runs edit, which reads a task and writes it conditionally on the version it read,
+// until it no longer returns ErrVersionConflict, at most maxEditAttempts times
+func retryEdit(edit func() error) error {
+	err := edit()
+	for attempt := 1; attempt < maxEditAttempts && errors.Is(err, domain.ErrVersionConflict); attempt++ {
+		err = edit()
+	}
+	return err
+}
+
+// missingOrConflict explains why a conditional write matched no task
+// Returns ErrVersionConflict if the task exists but changed since it was read, ErrTaskNotFound otherwise
+func (tu *taskUsecase) missingOrConflict(ctx context.Context, taskID string) error {
+	if _, err := tu.taskRepository.FetchByTaskID(ctx, taskID); err != nil {
+		return err
+	}
+	return domain.ErrVersionConflict
+}
+
+// PatchByTaskID applies a partial update to a task and returns the updated task
+// Only the fields present in the patch are validated and written
+func (tu *taskUsecase) PatchByTaskID(c context.Context, taskID string, patch domain.TaskPatch, actor domain.Actor) (domain.Task, error) {
+	if patch.IsEmpty() {
+		return domain.Task{}, domain.ErrEmptyPatch
+	}
+
+	if patch.Title != nil {
+		title := strings.TrimSpace(*patch.Title)
+		if title == "" {
+			return domain.Task{}, domain.ErrEmptyTitle
+		}
+		patch.Title = &title
+	}
+
+	if patch.Status != nil {
+		status, err := domain.ParseTaskStatus(string(*patch.Status))
+		if err != nil {
+			return domain.Task{}, err
+		}
+		patch.Status = &status
+	}
+
+	if patch.Priority != nil {
+		priority, err := domain.ParseTaskPriority(string(*patch.Priority))
+		if err != nil {
+			return domain.Task{}, err
+		}
+		patch.Priority = &priority
+	}
+
+	// Validate due date
+	if patch.DueDate != nil && patch.DueDate.Before(time.Now()) {
+		return domain.Task{}, domain.ErrInvalidDueDate
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	// keep the current state around for the status check and the history diff
+	before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+		return domain.Task{}, err
+	}
+	// the write only applies to the version the checks and the history diff are based on
+	if patch.Version == 0 {
+		patch.Version = before.Version
+	}
+	if patch.Status != nil {
+		if err := before.Status.TransitionTo(*patch.Status); err != nil {
+			return domain.Task{}, err
+		}
+		if *patch.Status == domain.StatusCompleted && before.Status != domain.StatusCompleted {
+			if err := tu.checkBlockers(ctx, before); err != nil {
+				return domain.Task{}, err
+			}
+		}
+	}
+
+	// the write also only applies to the status the transition was checked from
+	matched, modified, err := tu.taskRepository.PatchByTaskID(ctx, taskID, before.Status, patch)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if matched == 0 {
+		return domain.Task{}, tu.missingOrConflict(ctx, taskID)
+	}
+	if modified == 0 {
+		return before, domain.ErrNoChangesMade
+	}
+
+	// nothing else changed the task in between, so the patched copy is what got stored
+	task := patch.Apply(before)
+	task.Version++
+
+	tu.recordHistory(ctx, task, domain.TaskActionUpdate, actor, diffTasks(before, task))
+
+	// a missed occurrence already got its successor from the overdue sweep
+	if before.Status == domain.StatusPending && task.Status == domain.StatusCompleted {
+		tu.scheduleNextOccurrence(ctx, task, actor, time.Now())
+	}
+	return task, nil
+}
+
+// DeleteByTaskID moves a task to the trash using its ID
+// A non-zero version makes the delete conditional on the stored version
+func (tu *taskUsecase) DeleteByTaskID(c context.Context, taskID string, version int, actor domain.Actor) error {
 	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
 	defer cancel()
-	count, err := tu.taskRepository.DeleteByTaskID(ctx, taskID)
+
+	task, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := checkCanEdit(ctx, tu.projectRepository, task, actor); err != nil {
+		return err
+	}
+
+	count, err := tu.taskRepository.DeleteByTaskID(ctx, taskID, version)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return tu.missingOrConflict(ctx, taskID)
+	}
+
+	tu.recordHistory(ctx, task, domain.TaskActionDelete, actor, nil)
+	return nil
+}
+
+// FetchTrash retrieves the deleted tasks that have not been purged yet
+func (tu *taskUsecase) FetchTrash(c context.Context) ([]domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+	return tu.taskRepository.FetchDeleted(ctx)
+}
+
+// RestoreByTaskID moves a task out of the trash
+func (tu *taskUsecase) RestoreByTaskID(c context.Context, taskID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+	count, err := tu.taskRepository.RestoreByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
 	if count == 0 {
 		return domain.ErrTaskNotFound
 	}
-	return err
+
+	// only the ID is known, restores are not published
+	tu.recordHistory(ctx, domain.Task{ID: taskID}, domain.TaskActionRestore, actor, nil)
+	return nil
+}
+
+// PurgeTrash permanently removes the tasks that have been in the trash longer than the retention period,
+// along with their attachments and comments. Returns the number of tasks removed
+func (tu *taskUsecase) PurgeTrash(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+	purged, err := tu.taskRepository.PurgeDeleted(ctx, time.Now().Add(-tu.trashRetention))
+	if err != nil {
+		return 0, err
+	}
+
+	// the tasks are gone either way, leftover attachments and comments are only logged
+	if len(purged) > 0 {
+		if err := tu.attachmentUsecase.DeleteByTaskIDs(ctx, purged); err != nil {
+			log.Printf("failed to delete the attachments of %d purged tasks: %v", len(purged), err)
+		}
+		if _, err := tu.commentRepository.DeleteByTaskIDs(ctx, purged); err != nil {
+			log.Printf("failed to delete the comments of %d purged tasks: %v", len(purged), err)
+		}
+	}
+	return len(purged), nil
+}
+
+// FetchHistory retrieves the recorded changes of a task the actor may see, oldest first
+func (tu *taskUsecase) FetchHistory(c context.Context, taskID string, actor domain.Actor) ([]domain.TaskHistoryEntry, error) {
+	// the visibility rules of the task apply to its history as well
+	if _, err := tu.FetchByTaskID(c, taskID, actor); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+	return tu.historyRepository.FetchByTaskID(ctx, taskID)
 }
 
 // FetchByTaskID retrieves a single task by its ID
-func (tu *taskUsecase) FetchByTaskID(c context.Context, taskID string) (domain.Task, error) {
+// Non-admin actors only see the tasks of the projects they are members of; anything else is reported as not found
+func (tu *taskUsecase) FetchByTaskID(c context.Context, taskID string, actor domain.Actor) (domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	task, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := checkCanRead(ctx, tu.projectRepository, task, actor); err != nil {
+		return domain.Task{}, err
+	}
+	task.Urgency = task.UrgencyAt(time.Now())
+	return tu.withProgress(ctx, task)
+}
+
+// FetchAllTasks retrieves one page of the tasks visible to the actor
+// Admins see every task, other users only the tasks of the projects they are members of
+// Pages can be addressed by offset or by the cursor returned with the previous page
+func (tu *taskUsecase) FetchAllTasks(c context.Context, query domain.TaskQuery, actor domain.Actor) (domain.TaskPage, error) {
+	if err := normalizeTaskQuery(&query); err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	if query.Cursor != "" {
+		cursor, err := tu.cursorService.Decode(query.Cursor)
+		if err != nil {
+			return domain.TaskPage{}, err
+		}
+		// a cursor only makes sense for the ordering it was issued for
+		if cursor.SortBy != query.SortBy || cursor.SortDesc != query.SortDesc {
+			return domain.TaskPage{}, domain.ErrInvalidCursor
+		}
+		query.After = &cursor
+	}
+
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	if err := scopeTaskQuery(ctx, tu.projectRepository, &query, actor); err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	// fetch one extra task to find out whether there is a next page
+	limit := query.Limit
+	query.Limit++
+	tasks, total, err := tu.taskRepository.FetchAllTasks(ctx, query)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	withUrgency(tasks, time.Now())
+	page := domain.TaskPage{
+		Tasks:  tasks,
+		Total:  total,
+		Limit:  limit,
+		Offset: query.Offset,
+	}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		last := page.Tasks[limit-1]
+		page.NextCursor, err = tu.cursorService.Encode(domain.Cursor{
+			SortBy:    query.SortBy,
+			SortDesc:  query.SortDesc,
+			SortValue: taskSortValue(last, query.SortBy),
+			ID:        last.ID,
+		})
+		if err != nil {
+			return domain.TaskPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// taskSortValue returns the value of the sort field of a task as stored in a cursor
+func taskSortValue(task domain.Task, sortBy string) string {
+	switch sortBy {
+	case domain.TaskSortByDueDate:
+		return task.DueDate.UTC().Format(time.RFC3339Nano)
+	case domain.TaskSortByTitle:
+		return task.Title
+	case domain.TaskSortByStatus:
+		return string(task.Status)
+	case domain.TaskSortByUrgency:
+		return task.UrgentAt().UTC().Format(time.RFC3339Nano)
+	default:
+		return ""
+	}
+}
+
+// parsePriority validates a priority given by a client, falling back to fallback when it is left out
+func parsePriority(priority domain.TaskPriority, fallback domain.TaskPriority) (domain.TaskPriority, error) {
+	if priority == "" {
+		return fallback, nil
+	}
+	return domain.ParseTaskPriority(string(priority))
+}
+
+// withUrgency scores the urgency of every task at now
+func withUrgency(tasks []domain.Task, now time.Time) {
+	for i := range tasks {
+		tasks[i].Urgency = tasks[i].UrgencyAt(now)
+	}
+}
+
+// normalizeTaskQuery validates a task query and fills in pagination defaults
+func normalizeTaskQuery(query *domain.TaskQuery) error {
+	if query.Status != "" {
+		status, err := domain.ParseTaskStatus(string(query.Status))
+		if err != nil {
+			return fmt.Errorf("%w: unknown status %q", domain.ErrInvalidQuery, query.Status)
+		}
+		query.Status = status
+	}
+
+	switch query.SortBy {
+	case "", domain.TaskSortByDueDate, domain.TaskSortByTitle, domain.TaskSortByStatus, domain.TaskSortByUrgency:
+	default:
+		return fmt.Errorf("%w: cannot sort by %q", domain.ErrInvalidQuery, query.SortBy)
+	}
+
+	if !query.DueAfter.IsZero() && !query.DueBefore.IsZero() && query.DueAfter.After(query.DueBefore) {
+		return fmt.Errorf("%w: due_after must not be later than due_before", domain.ErrInvalidQuery)
+	}
+
+	var err error
+	if query.Tags, err = domain.ParseTags(query.Tags); err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidQuery, err)
+	}
+	if query.AnyTags, err = domain.ParseTags(query.AnyTags); err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidQuery, err)
+	}
+
+	if query.Limit < 0 || query.Offset < 0 {
+		return fmt.Errorf("%w: limit and offset must not be negative", domain.ErrInvalidQuery)
+	}
+	if query.Cursor != "" && query.Offset != 0 {
+		return fmt.Errorf("%w: cursor and offset cannot be combined", domain.ErrInvalidQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultPageSize
+	}
+	if query.Limit > domain.MaxPageSize {
+		query.Limit = domain.MaxPageSize
+	}
+	return nil
+}
+
+// AssignUser adds a member of the project of a task to its assignees
+func (tu *taskUsecase) AssignUser(c context.Context, taskID string, userID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	// make sure the assignee exists
+	if _, err := tu.userRepository.FetchByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	return retryEdit(func() error {
+		// keep the current state around for the history diff
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return err
+		}
+		if slices.Contains(before.Assignees, userID) {
+			return domain.ErrUserAlreadyAssigned
+		}
+
+		// only members of the project can work on its tasks
+		project, err := tu.projectRepository.FetchByID(ctx, before.ProjectID)
+		if err != nil {
+			return err
+		}
+		if _, ok := project.RoleOf(userID); !ok {
+			return domain.ErrNotProjectMember
+		}
+
+		matched, err := tu.taskRepository.AddAssignee(ctx, taskID, before.Version, userID)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return domain.ErrVersionConflict
+		}
+
+		after := before
+		after.Assignees = append(slices.Clone(before.Assignees), userID)
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return nil
+	})
+}
+
+// UnassignUser removes a user from the assignees of a task
+func (tu *taskUsecase) UnassignUser(c context.Context, taskID string, userID string, actor domain.Actor) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	return retryEdit(func() error {
+		// keep the current state around for the history diff
+		before, err := tu.taskRepository.FetchByTaskID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := checkCanEdit(ctx, tu.projectRepository, before, actor); err != nil {
+			return err
+		}
+		if !slices.Contains(before.Assignees, userID) {
+			return domain.ErrUserNotAssigned
+		}
+
+		matched, err := tu.taskRepository.RemoveAssignee(ctx, taskID, before.Version, userID)
+		if err != nil {
+			return err
+		}
+		if matched == 0 {
+			return domain.ErrVersionConflict
+		}
+
+		after := before
+		after.Assignees = slices.DeleteFunc(slices.Clone(before.Assignees), func(id string) bool { return id == userID })
+		after.Version++
+		tu.recordHistory(ctx, after, domain.TaskActionUpdate, actor, diffTasks(before, after))
+		return nil
+	})
+}
+
+// FetchAssignedTasks retrieves the tasks assigned to a user in the projects they are a member of
+func (tu *taskUsecase) FetchAssignedTasks(c context.Context, userID string) ([]domain.Task, error) {
 	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
 	defer cancel()
-	return tu.taskRepository.FetchByTaskID(ctx, taskID)
+
+	tasks, err := tu.taskRepository.FetchByAssignee(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs, err := memberProjectIDs(ctx, tu.projectRepository, domain.Actor{ID: userID})
+	if err != nil {
+		return nil, err
+	}
+	tasks = slices.DeleteFunc(tasks, func(task domain.Task) bool { return !slices.Contains(projectIDs, task.ProjectID) })
+	withUrgency(tasks, time.Now())
+	return tasks, nil
 }
 
-// FetchAllTasks retrieves all tasks from the repository
-func (tu *taskUsecase) FetchAllTasks(c context.Context) ([]domain.Task, error) {
+// Search runs a full-text search over the tasks visible to the actor
+// Results are ordered by relevance and carry highlighted snippets of the matching fields
+func (tu *taskUsecase) Search(c context.Context, query domain.TaskSearchQuery, actor domain.Actor) ([]domain.TaskSearchResult, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return nil, fmt.Errorf("%w: search text is required", domain.ErrInvalidQuery)
+	}
+	if query.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultPageSize
+	}
+	if query.Limit > domain.MaxPageSize {
+		query.Limit = domain.MaxPageSize
+	}
+
 	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
 	defer cancel()
-	return tu.taskRepository.FetchAllTasks(ctx)
+
+	projectIDs, err := memberProjectIDs(ctx, tu.projectRepository, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.ProjectIDs = projectIDs
+
+	results, err := tu.taskRepository.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := searchTerms(query.Text)
+	for i := range results {
+		results[i].Highlights = map[string]string{}
+		if snippet, ok := highlight(results[i].Task.Title, terms); ok {
+			results[i].Highlights["title"] = snippet
+		}
+		if snippet, ok := highlight(results[i].Task.Description, terms); ok {
+			results[i].Highlights["description"] = snippet
+		}
+	}
+	return results, nil
 }
@@ -14,15 +14,17 @@ type userUsecase struct {
 	userRepository domain.UserRepository   // Repository for user data operations
 	jwtService     domain.JWTService       // jwt services for login
 	hasher         domain.IPasswordService // hasher is a service for hashing and comparing passwords
+	cursorService  domain.CursorService    // Service for encoding and decoding pagination cursors
 	contextTimeout time.Duration           // Timeout duration for usecase operations
 }
 
 // NewUserUsecase creates a new instance of userUsecase
-func NewUserUsecase(userRepository domain.UserRepository, jwtService domain.JWTService, passwordService domain.IPasswordService, timeout time.Duration) domain.UserUsecase {
+func NewUserUsecase(userRepository domain.UserRepository, jwtService domain.JWTService, passwordService domain.IPasswordService, cursorService domain.CursorService, timeout time.Duration) domain.UserUsecase {
 	return &userUsecase{
 		userRepository: userRepository,
 		jwtService:     jwtService,
 		hasher:         passwordService,
+		cursorService:  cursorService,
 		contextTimeout: timeout,
 	}
 }
@@ -121,11 +123,47 @@ func (uu *userUsecase) PromoteByUserID(c context.Context, userID string) error {
 
 }
 
-// FetchAllUsers retrieves all users from the repository
-func (uu *userUsecase) FetchAllUsers(c context.Context) ([]domain.User, error) {
+// FetchAllUsers retrieves one page of users from the repository
+// The next page is addressed by the cursor returned with the previous one
+func (uu *userUsecase) FetchAllUsers(c context.Context, query domain.UserQuery) (domain.UserPage, error) {
+	if query.Limit < 0 {
+		return domain.UserPage{}, domain.ErrInvalidQuery
+	}
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultPageSize
+	}
+	if query.Limit > domain.MaxPageSize {
+		query.Limit = domain.MaxPageSize
+	}
+
+	if query.Cursor != "" {
+		cursor, err := uu.cursorService.Decode(query.Cursor)
+		if err != nil {
+			return domain.UserPage{}, err
+		}
+		query.After = &cursor
+	}
+
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
-	return uu.userRepository.FetchAllUsers(ctx)
+
+	// fetch one extra user to find out whether there is a next page
+	limit := query.Limit
+	query.Limit++
+	users, err := uu.userRepository.FetchAllUsers(ctx, query)
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+
+	page := domain.UserPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor, err = uu.cursorService.Encode(domain.Cursor{ID: page.Users[limit-1].ID})
+		if err != nil {
+			return domain.UserPage{}, err
+		}
+	}
+	return page, nil
 }
 
 // FetchByUserID retrieves a user by their unique ID
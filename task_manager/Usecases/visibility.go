@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// projectRole returns the role the actor acts with in a project, ok is false when the actor is not a member
+// Admins act as owners of every project without the project being looked up
+func projectRole(ctx context.Context, projectRepository domain.ProjectRepository, projectID string, actor domain.Actor) (domain.ProjectRole, bool, error) {
+	if actor.IsAdmin {
+		return domain.ProjectRoleOwner, true, nil
+	}
+	project, err := projectRepository.FetchByID(ctx, projectID)
+	if errors.Is(err, domain.ErrProjectNotFound) || errors.Is(err, domain.ErrInvalidProjectID) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	role, ok := project.RoleOfActor(actor)
+	return role, ok, nil
+}
+
+// checkCanRead returns ErrTaskNotFound unless the actor is a member of the project of the task
+func checkCanRead(ctx context.Context, projectRepository domain.ProjectRepository, task domain.Task, actor domain.Actor) error {
+	_, ok, err := projectRole(ctx, projectRepository, task.ProjectID, actor)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrTaskNotFound
+	}
+	return nil
+}
+
+// checkCanEdit returns ErrTaskNotFound unless the actor is a member of the project of the task,
+// and ErrProjectReadOnly unless their role allows changing tasks
+func checkCanEdit(ctx context.Context, projectRepository domain.ProjectRepository, task domain.Task, actor domain.Actor) error {
+	role, ok, err := projectRole(ctx, projectRepository, task.ProjectID, actor)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrTaskNotFound
+	}
+	if !role.CanEditTasks() {
+		return domain.ErrProjectReadOnly
+	}
+	return nil
+}
+
+// checkTaskVisible returns ErrTaskNotFound unless the task exists and the actor is a member of its project
+// It guards the resources hanging off a task, such as its comments and attachments
+func checkTaskVisible(ctx context.Context, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, taskID string, actor domain.Actor) error {
+	task, err := taskRepository.FetchByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return checkCanRead(ctx, projectRepository, task, actor)
+}
+
+// memberProjectIDs returns the IDs of the projects the actor may read, nil meaning every project
+// The list is empty but not nil for a user outside of every project
+func memberProjectIDs(ctx context.Context, projectRepository domain.ProjectRepository, actor domain.Actor) ([]string, error) {
+	if actor.IsAdmin {
+		return nil, nil
+	}
+	projects, err := projectRepository.FetchByMember(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(projects))
+	for _, project := range projects {
+		ids = append(ids, project.ID)
+	}
+	return ids, nil
+}
+
+// scopeTaskQuery restricts a task query to the projects the actor is a member of
+// A project filter the actor is not a member of is reported as ErrProjectNotFound
+func scopeTaskQuery(ctx context.Context, projectRepository domain.ProjectRepository, query *domain.TaskQuery, actor domain.Actor) error {
+	if query.ProjectID == "" {
+		projectIDs, err := memberProjectIDs(ctx, projectRepository, actor)
+		if err != nil {
+			return err
+		}
+		query.ProjectIDs = projectIDs
+		return nil
+	}
+
+	_, ok, err := projectRole(ctx, projectRepository, query.ProjectID, actor)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrProjectNotFound
+	}
+	query.ProjectIDs = []string{query.ProjectID}
+	return nil
+}
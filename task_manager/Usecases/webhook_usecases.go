@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+)
+
+// webhookSecretBytes is the number of random bytes in a generated webhook secret
+const webhookSecretBytes = 32
+
+// webhookUsecase implements the domain.WebhookUsecase interface
+type webhookUsecase struct {
+	webhookRepository  domain.WebhookRepository         // Repository storing the webhooks
+	deliveryRepository domain.WebhookDeliveryRepository // Persisted queue and log of the deliveries
+	contextTimeout     time.Duration                    // Timeout duration for each usecase operation
+}
+
+// NewWebhookUsecase creates a new instance of webhookUsecase
+func NewWebhookUsecase(webhookRepository domain.WebhookRepository, deliveryRepository domain.WebhookDeliveryRepository, timeout time.Duration) domain.WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepository:  webhookRepository,
+		deliveryRepository: deliveryRepository,
+		contextTimeout:     timeout,
+	}
+}
+
+// validateWebhook checks the URL and normalizes the events of a webhook
+func validateWebhook(webhook *domain.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.ErrInvalidWebhookURL
+	}
+	events, err := domain.ParseWebhookEvents(webhook.Events)
+	if err != nil {
+		return err
+	}
+	webhook.Events = events
+	return nil
+}
+
+// newWebhookSecret generates the signing key of a webhook created without one
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Create stores a webhook created by actor; its secret is kept in the returned webhook only
+func (wu *webhookUsecase) Create(c context.Context, webhook *domain.Webhook, actor domain.Actor) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+	webhook.CreatedBy = actor.ID
+	webhook.CreatedAt = time.Now().UTC()
+
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+	return wu.webhookRepository.Create(ctx, webhook)
+}
+
+// FetchAll retrieves every webhook without its secret
+func (wu *webhookUsecase) FetchAll(c context.Context) ([]domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhooks, err := wu.webhookRepository.FetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+// FetchByID retrieves a webhook without its secret
+func (wu *webhookUsecase) FetchByID(c context.Context, webhookID string) (domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	webhook, err := wu.webhookRepository.FetchByID(ctx, webhookID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Update replaces the URL, events and state of a webhook, keeping its secret unless a new one is given
+// The webhook is filled with the stored values, without its secret
+func (wu *webhookUsecase) Update(c context.Context, webhook *domain.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	stored, err := wu.webhookRepository.FetchByID(ctx, webhook.ID)
+	if err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = stored.Secret
+	}
+	count, err := wu.webhookRepository.Update(ctx, webhook)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	webhook.Secret = ""
+	webhook.CreatedBy = stored.CreatedBy
+	webhook.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// Delete removes a webhook; its pending deliveries fail when they are attempted
+func (wu *webhookUsecase) Delete(c context.Context, webhookID string) error {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	count, err := wu.webhookRepository.Delete(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// FetchDeliveries retrieves the latest deliveries of an existing webhook
+func (wu *webhookUsecase) FetchDeliveries(c context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	if limit < 0 {
+		return nil, domain.ErrInvalidQuery
+	}
+	if limit == 0 {
+		limit = domain.DefaultPageSize
+	}
+	if limit > domain.MaxPageSize {
+		limit = domain.MaxPageSize
+	}
+
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	if _, err := wu.webhookRepository.FetchByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return wu.deliveryRepository.FetchByWebhookID(ctx, webhookID, limit)
+}
+
+// Redeliver queues a copy of a delivery of the webhook, due immediately
+// The payload is sent unchanged, so receivers can recognize the event by its ID
+func (wu *webhookUsecase) Redeliver(c context.Context, webhookID string, deliveryID string) (domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	if _, err := wu.webhookRepository.FetchByID(ctx, webhookID); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	original, err := wu.deliveryRepository.FetchByID(ctx, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if original.WebhookID != webhookID {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now().UTC()
+	delivery := domain.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        domain.DeliveryPending,
+		Attempts:      []domain.WebhookAttempt{},
+		NextAttemptAt: now,
+		RedeliveryOf:  original.ID,
+		CreatedAt:     now,
+	}
+	if err := wu.deliveryRepository.CreateMany(ctx, []*domain.WebhookDelivery{&delivery}); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
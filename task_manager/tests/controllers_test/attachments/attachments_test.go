@@ -0,0 +1,234 @@
+package attachments
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetAttachments is used to test GetAttachments controller
+func (s *SuiteAttachmentUsecase) TestGetAttachments() {
+	tests := []AttachmentTestCase{
+		{
+			Name:     "attachments listed",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, "task1", sampleActor).Return([]domain.Attachment{sampleAttachment}, nil).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, "task1", sampleActor).Return([]domain.Attachment(nil), domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/attachments", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestUploadAttachment is used to test UploadAttachment controller
+func (s *SuiteAttachmentUsecase) TestUploadAttachment() {
+	s.Run("file uploaded", func() {
+		var received []byte
+		s.PrepareTest(AttachmentTestCase{
+			MockSetup: func() {
+				upload := mock.MatchedBy(func(u domain.AttachmentUpload) bool {
+					received, _ = io.ReadAll(u.Content)
+					return u.Filename == "notes.txt" && u.Size == 5
+				})
+				s.mockUsecase.On("Upload", mock.Anything, "task1", upload, sampleActor).Return(sampleAttachment, nil).Once()
+			},
+		})
+
+		body, contentType := multipartBody("file", "notes.txt", []byte("hello"))
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/attachments", body)
+		req.Header.Set("Content-Type", contentType)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusCreated, resp.Code)
+		require.Equal(s.T(), "hello", string(received))
+
+		var response struct {
+			Data domain.Attachment `json:"data"`
+		}
+		require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &response))
+		require.Equal(s.T(), sampleAttachment.ID, response.Data.ID)
+	})
+
+	tests := []struct {
+		Name      string
+		Field     string
+		Content   []byte
+		MockSetup func()
+		Expected  int
+	}{
+		{
+			Name:     "missing file field",
+			Field:    "document",
+			Content:  []byte("hello"),
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "request too large",
+			Field:    "file",
+			Content:  bytes.Repeat([]byte("a"), 2<<20),
+			Expected: http.StatusRequestEntityTooLarge,
+		},
+		{
+			Name:     "file too large",
+			Field:    "file",
+			Content:  bytes.Repeat([]byte("a"), maxUploadSize+1),
+			Expected: http.StatusRequestEntityTooLarge,
+			MockSetup: func() {
+				s.mockUsecase.On("Upload", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Attachment{}, domain.ErrAttachmentTooLarge).Once()
+			},
+		},
+		{
+			Name:     "type not allowed",
+			Field:    "file",
+			Content:  []byte("%PDF-1.7"),
+			Expected: http.StatusUnsupportedMediaType,
+			MockSetup: func() {
+				s.mockUsecase.On("Upload", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Attachment{}, domain.ErrAttachmentTypeNotAllowed).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Field:    "file",
+			Content:  []byte("hello"),
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Upload", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Attachment{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(AttachmentTestCase{MockSetup: tt.MockSetup})
+
+			body, contentType := multipartBody(tt.Field, "notes.txt", tt.Content)
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/attachments", body)
+			req.Header.Set("Content-Type", contentType)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestDownloadAttachment is used to test DownloadAttachment controller
+func (s *SuiteAttachmentUsecase) TestDownloadAttachment() {
+	s.Run("file streamed", func() {
+		s.PrepareTest(AttachmentTestCase{
+			MockSetup: func() {
+				content := io.NopCloser(strings.NewReader("hello"))
+				s.mockUsecase.On("Open", mock.Anything, "task1", "attachment1", sampleActor).Return(sampleAttachment, content, nil).Once()
+			},
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/attachments/attachment1", nil)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusOK, resp.Code)
+		require.Equal(s.T(), "hello", resp.Body.String())
+		require.Equal(s.T(), "text/plain", resp.Header().Get("Content-Type"))
+		require.Equal(s.T(), "5", resp.Header().Get("Content-Length"))
+		require.Equal(s.T(), "attachment; filename=notes.txt", resp.Header().Get("Content-Disposition"))
+		require.Equal(s.T(), "nosniff", resp.Header().Get("X-Content-Type-Options"))
+	})
+
+	tests := []AttachmentTestCase{
+		{
+			Name:     "attachment not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Open", mock.Anything, "task1", "attachment1", sampleActor).Return(domain.Attachment{}, nil, domain.ErrAttachmentNotFound).Once()
+			},
+		},
+		{
+			Name:     "invalid attachment id",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Open", mock.Anything, "task1", "attachment1", sampleActor).Return(domain.Attachment{}, nil, domain.ErrInvalidAttachmentID).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/attachments/attachment1", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestDeleteAttachment is used to test DeleteAttachment controller
+func (s *SuiteAttachmentUsecase) TestDeleteAttachment() {
+	tests := []AttachmentTestCase{
+		{
+			Name:     "attachment deleted",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "task1", "attachment1", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "not the uploader",
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "task1", "attachment1", sampleActor).Return(domain.ErrNotAttachmentUploader).Once()
+			},
+		},
+		{
+			Name:     "attachment not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "task1", "attachment1", sampleActor).Return(domain.ErrAttachmentNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tasks/task1/attachments/attachment1", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
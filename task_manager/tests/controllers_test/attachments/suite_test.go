@@ -0,0 +1,48 @@
+package attachments
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteAttachmentUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockAttachmentUsecase
+}
+
+func (s *SuiteAttachmentUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockAttachmentUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	attachmentController := controllers.AttachmentController{AttachmentUsecase: s.mockUsecase, MaxUploadSize: maxUploadSize}
+	s.router.GET("/tasks/:id/attachments", attachmentController.GetAttachments)
+	s.router.POST("/tasks/:id/attachments", attachmentController.UploadAttachment)
+	s.router.GET("/tasks/:id/attachments/:attachmentID", attachmentController.DownloadAttachment)
+	s.router.DELETE("/tasks/:id/attachments/:attachmentID", attachmentController.DeleteAttachment)
+}
+
+func (s *SuiteAttachmentUsecase) PrepareTest(tt AttachmentTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestAttachmentController(t *testing.T) {
+	suite.Run(t, new(SuiteAttachmentUsecase))
+}
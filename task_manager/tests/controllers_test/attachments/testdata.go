@@ -0,0 +1,53 @@
+package attachments
+
+// this file contains shared data, and struct within the attachments test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated user injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+// largest upload accepted by the controller under test
+const maxUploadSize = 1024
+
+type AttachmentTestCase struct {
+	Name      string // name of the test
+	MockSetup func() // mock setup
+	Expected  int    // expected status
+}
+
+// sample attachment uploaded by sampleUser
+var sampleAttachment = domain.Attachment{
+	ID:          "attachment1",
+	TaskID:      "task1",
+	UploaderID:  "user1",
+	Filename:    "notes.txt",
+	ContentType: "text/plain",
+	Size:        5,
+	StorageKey:  "task1/blob",
+	CreatedAt:   time.Now(),
+}
+
+// multipartBody builds a form with content sent as the given field, returning the body and its content type
+func multipartBody(field, filename string, content []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, filename)
+	_, _ = part.Write(content)
+	_ = writer.Close()
+	return body, writer.FormDataContentType()
+}
@@ -0,0 +1,153 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExecuteBatch is used to test ExecuteBatch controller
+func (s *SuiteBatchUsecase) TestExecuteBatch() {
+	// matches the operations of sampleBatch
+	sampleOperations := mock.MatchedBy(func(operations []domain.BatchOperation) bool {
+		return len(operations) == 3 &&
+			operations[0].Op == domain.BatchCreate && operations[0].Task.Title == "imported" && operations[0].Task.OwnerID == sampleUser.ID &&
+			operations[1].Op == domain.BatchUpdate && operations[1].TaskID == "task1" && operations[1].Version == 3 &&
+			*operations[1].Patch.Status == domain.StatusCompleted &&
+			operations[2].Op == domain.BatchDelete && operations[2].TaskID == "task2"
+	})
+	created := domain.Task{ID: "task3", Title: "imported"}
+
+	tests := []BatchTestCase{
+		{
+			Name:     "each operation reported",
+			Body:     sampleBatch,
+			Expected: http.StatusOK,
+			Statuses: []int{http.StatusCreated, http.StatusConflict, http.StatusNotFound},
+			MockSetup: func() {
+				results := []domain.BatchResult{
+					{Index: 0, Op: domain.BatchCreate, TaskID: "task3", Task: &created},
+					{Index: 1, Op: domain.BatchUpdate, TaskID: "task1", Err: &domain.BlockedError{Blockers: []domain.Task{{ID: "task4"}}}},
+					{Index: 2, Op: domain.BatchDelete, TaskID: "task2", Err: domain.ErrTaskNotFound},
+				}
+				s.mockUsecase.On("Execute", mock.Anything, sampleOperations, false, sampleActor).Return(results, nil).Once()
+			},
+		},
+		{
+			Name:     "status transitions",
+			Body:     sampleBatch,
+			Expected: http.StatusOK,
+			// a new task in another status than pending is invalid, an update is a conflict with the stored status
+			Statuses: []int{http.StatusUnprocessableEntity, http.StatusConflict, http.StatusOK},
+			MockSetup: func() {
+				results := []domain.BatchResult{
+					{Index: 0, Op: domain.BatchCreate, Err: domain.ErrInvalidStatusTransition},
+					{Index: 1, Op: domain.BatchUpdate, TaskID: "task1", Err: domain.ErrInvalidStatusTransition},
+					{Index: 2, Op: domain.BatchDelete, TaskID: "task2"},
+				}
+				s.mockUsecase.On("Execute", mock.Anything, sampleOperations, false, sampleActor).Return(results, nil).Once()
+			},
+		},
+		{
+			Name:     "atomic batch rolled back",
+			Body:     strings.Replace(sampleBatch, "{", `{"atomic": true,`, 1),
+			Expected: http.StatusUnprocessableEntity,
+			Statuses: []int{http.StatusFailedDependency, http.StatusForbidden, http.StatusFailedDependency},
+			MockSetup: func() {
+				results := []domain.BatchResult{
+					{Index: 0, Op: domain.BatchCreate, Err: domain.ErrBatchAborted},
+					{Index: 1, Op: domain.BatchUpdate, TaskID: "task1", Err: domain.ErrProjectReadOnly},
+					{Index: 2, Op: domain.BatchDelete, TaskID: "task2", Err: domain.ErrBatchAborted},
+				}
+				s.mockUsecase.On("Execute", mock.Anything, sampleOperations, true, sampleActor).Return(results, domain.ErrBatchRolledBack).Once()
+			},
+		},
+		{
+			Name:     "atomic batch without transactions",
+			Body:     strings.Replace(sampleBatch, "{", `{"atomic": true,`, 1),
+			Expected: http.StatusNotImplemented,
+			MockSetup: func() {
+				s.mockUsecase.On("Execute", mock.Anything, sampleOperations, true, sampleActor).Return([]domain.BatchResult(nil), domain.ErrTransactionsUnsupported).Once()
+			},
+		},
+		{
+			Name:     "empty batch",
+			Body:     `{"operations": []}`,
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Execute", mock.Anything, []domain.BatchOperation{}, false, sampleActor).Return([]domain.BatchResult(nil), domain.ErrEmptyBatch).Once()
+			},
+		},
+		{
+			Name:     "missing operations",
+			Body:     `{"atomic": true}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "unknown operation",
+			Body:     `{"operations": [{"op": "archive", "id": "task1"}]}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "create without title",
+			Body:     `{"operations": [{"op": "create", "task": {"project_id": "project1", "due_date": "2999-01-01T00:00:00Z", "status": "pending"}}]}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "update with unknown field",
+			Body:     `{"operations": [{"op": "update", "id": "task1", "task": {"owner": "user2"}}]}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "delete without id",
+			Body:     `{"operations": [{"op": "delete"}]}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "internal server error",
+			Body:     sampleBatch,
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("Execute", mock.Anything, sampleOperations, false, sampleActor).Return([]domain.BatchResult(nil), fmt.Errorf("db error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/batch", bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+			if tt.Statuses == nil {
+				return
+			}
+
+			var body struct {
+				Data []struct {
+					Index  int          `json:"index"`
+					Status int          `json:"status"`
+					Task   *domain.Task `json:"task"`
+				} `json:"data"`
+			}
+			require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+			require.Len(s.T(), body.Data, len(tt.Statuses))
+			for i, status := range tt.Statuses {
+				require.Equal(s.T(), i, body.Data[i].Index)
+				require.Equal(s.T(), status, body.Data[i].Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteBatchUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockTaskBatchUsecase
+}
+
+func (s *SuiteBatchUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockTaskBatchUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	batchController := controllers.BatchController{TaskBatchUsecase: s.mockUsecase}
+	s.router.POST("/tasks/batch", batchController.ExecuteBatch)
+}
+
+func (s *SuiteBatchUsecase) PrepareTest(tt BatchTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestBatchController(t *testing.T) {
+	suite.Run(t, new(SuiteBatchUsecase))
+}
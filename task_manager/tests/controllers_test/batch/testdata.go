@@ -0,0 +1,35 @@
+package batch
+
+// this file contains shared data, and struct within the batch test
+
+import (
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated user injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+type BatchTestCase struct {
+	Name      string // name of the test
+	Body      string // payload of the request
+	MockSetup func() // mock setup
+	Expected  int    // expected status
+	Statuses  []int  // expected status of each operation, nil when the response lists none
+}
+
+// batch creating a task in project1, completing task1 and deleting task2
+const sampleBatch = `{
+	"operations": [
+		{"op": "create", "task": {"project_id": "project1", "title": "imported", "due_date": "2999-01-01T00:00:00Z", "status": "pending"}},
+		{"op": "update", "id": "task1", "version": 3, "task": {"status": "completed"}},
+		{"op": "delete", "id": "task2"}
+	]
+}`
@@ -0,0 +1,168 @@
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCalendarToken is used to test IssueCalendarToken and RevokeCalendarToken controllers
+func (s *SuiteCalendarUsecase) TestCalendarToken() {
+	tests := []CalendarTestCase{
+		{
+			Name:     "issue",
+			Method:   http.MethodPost,
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("IssueToken", mock.Anything, sampleActor).Return("secret", nil).Once()
+			},
+		},
+		{
+			Name:     "issue fails",
+			Method:   http.MethodPost,
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("IssueToken", mock.Anything, sampleActor).Return("", fmt.Errorf("db error")).Once()
+			},
+		},
+		{
+			Name:     "revoke",
+			Method:   http.MethodDelete,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("RevokeToken", mock.Anything, sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "revoke without token",
+			Method:   http.MethodDelete,
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("RevokeToken", mock.Anything, sampleActor).Return(domain.ErrCalendarTokenNotFound).Once()
+			},
+		},
+		{
+			Name:     "revoke fails",
+			Method:   http.MethodDelete,
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("RevokeToken", mock.Anything, sampleActor).Return(fmt.Errorf("db error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(tt.Method, "/me/calendar-token", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// Test the issued token comes with the path of the feed
+func (s *SuiteCalendarUsecase) TestIssueCalendarToken_Path() {
+	s.mockUsecase.On("IssueToken", mock.Anything, sampleActor).Return("secret", nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/me/calendar-token", nil)
+	resp := httptest.NewRecorder()
+	s.router.ServeHTTP(resp, req)
+
+	var body struct {
+		Data struct {
+			Token string `json:"token"`
+			Path  string `json:"path"`
+		} `json:"data"`
+	}
+	require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Equal(s.T(), "secret", body.Data.Token)
+	require.Equal(s.T(), "/calendar/secret.ics", body.Data.Path)
+}
+
+// TestGetCalendarFeed is used to test GetCalendarFeed controller
+func (s *SuiteCalendarUsecase) TestGetCalendarFeed() {
+	tests := []CalendarTestCase{
+		{
+			Name:     "feed",
+			Path:     "/calendar/secret.ics",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchFeed", mock.Anything, "secret", "").Return(sampleFeed, nil).Once()
+			},
+		},
+		{
+			Name:        "not modified",
+			Path:        "/calendar/secret.ics",
+			IfNoneMatch: sampleFeed.ETag,
+			Expected:    http.StatusNotModified,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchFeed", mock.Anything, "secret", sampleFeed.ETag).Return(unchangedFeed, nil).Once()
+			},
+		},
+		{
+			Name:        "modified",
+			Path:        "/calendar/secret.ics",
+			IfNoneMatch: `"old"`,
+			Expected:    http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchFeed", mock.Anything, "secret", `"old"`).Return(sampleFeed, nil).Once()
+			},
+		},
+		{
+			Name:     "missing extension",
+			Path:     "/calendar/secret",
+			Expected: http.StatusNotFound,
+		},
+		{
+			Name:     "unknown token",
+			Path:     "/calendar/revoked.ics",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchFeed", mock.Anything, "revoked", "").Return(domain.CalendarFeed{}, domain.ErrCalendarTokenNotFound).Once()
+			},
+		},
+		{
+			Name:     "internal server error",
+			Path:     "/calendar/secret.ics",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchFeed", mock.Anything, "secret", "").Return(domain.CalendarFeed{}, fmt.Errorf("db error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, tt.Path, nil)
+			if tt.IfNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.IfNoneMatch)
+			}
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+			switch tt.Expected {
+			case http.StatusOK:
+				require.Equal(s.T(), "text/calendar; charset=utf-8", resp.Header().Get("Content-Type"))
+				require.Equal(s.T(), sampleFeed.ETag, resp.Header().Get("ETag"))
+				require.Equal(s.T(), string(sampleFeed.Content), resp.Body.String())
+			case http.StatusNotModified:
+				require.Equal(s.T(), sampleFeed.ETag, resp.Header().Get("ETag"))
+				require.Empty(s.T(), resp.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package calendar
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteCalendarUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockCalendarUsecase
+}
+
+func (s *SuiteCalendarUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockCalendarUsecase)
+	s.router = gin.Default()
+	calendarController := controllers.CalendarController{CalendarUsecase: s.mockUsecase}
+
+	// the feed is public
+	s.router.GET("/calendar/:file", calendarController.GetCalendarFeed)
+
+	authenticated := s.router.Group("")
+	authenticated.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+	authenticated.POST("/me/calendar-token", calendarController.IssueCalendarToken)
+	authenticated.DELETE("/me/calendar-token", calendarController.RevokeCalendarToken)
+}
+
+func (s *SuiteCalendarUsecase) PrepareTest(tt CalendarTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestCalendarController(t *testing.T) {
+	suite.Run(t, new(SuiteCalendarUsecase))
+}
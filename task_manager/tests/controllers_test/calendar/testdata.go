@@ -0,0 +1,39 @@
+package calendar
+
+// this file contains shared data, and struct within the calendar test
+
+import (
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated user injected into the token requests
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+// feed returned for the token "secret"
+var sampleFeed = domain.CalendarFeed{
+	ETag:    `"abc123"`,
+	Content: []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"),
+}
+
+// feed returned for the token "secret" when the client already holds sampleFeed
+var unchangedFeed = domain.CalendarFeed{
+	ETag:        sampleFeed.ETag,
+	NotModified: true,
+}
+
+type CalendarTestCase struct {
+	Name        string // name of the test
+	Method      string // method of the request
+	Path        string // path of the request
+	IfNoneMatch string // If-None-Match header, empty to omit it
+	MockSetup   func() // mock setup
+	Expected    int    // expected status
+}
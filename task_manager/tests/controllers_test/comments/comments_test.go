@@ -0,0 +1,238 @@
+package comments
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetComments is used to test GetComments controller
+func (s *SuiteCommentUsecase) TestGetComments() {
+	s.Run("first page", func() {
+		s.PrepareTest(CommentTestCase{
+			MockSetup: func() {
+				query := domain.CommentQuery{TaskID: "task1", Limit: 1}
+				page := domain.CommentPage{Comments: []domain.Comment{sampleComment}, NextCursor: "next"}
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, query, sampleActor).Return(page, nil).Once()
+			},
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/comments?limit=1", nil)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusOK, resp.Code)
+
+		var body struct {
+			Data       []domain.Comment `json:"data"`
+			NextCursor string           `json:"next_cursor"`
+		}
+		require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+		require.Len(s.T(), body.Data, 1)
+		require.Equal(s.T(), sampleComment.Body, body.Data[0].Body)
+		require.Equal(s.T(), "next", body.NextCursor)
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	tests := []CommentTestCase{
+		{
+			Name:     "invalid limit",
+			Query:    "?limit=abc",
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "invalid cursor",
+			Query:    "?cursor=bogus",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				query := domain.CommentQuery{TaskID: "task1", Cursor: "bogus"}
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, query, sampleActor).Return(domain.CommentPage{}, domain.ErrInvalidCursor).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				query := domain.CommentQuery{TaskID: "task1"}
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, query, sampleActor).Return(domain.CommentPage{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/comments"+tt.Query, nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestCreateComment is used to test CreateComment controller
+func (s *SuiteCommentUsecase) TestCreateComment() {
+	tests := []CommentTestCase{
+		{
+			Name:     "comment posted",
+			Body:     map[string]string{"body": "Looks good to me"},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, "task1", "Looks good to me", sampleActor).Return(sampleComment, nil).Once()
+			},
+		},
+		{
+			Name:     "missing body",
+			Body:     map[string]string{},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "blank body",
+			Body:     map[string]string{"body": "   "},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, "task1", "   ", sampleActor).Return(domain.Comment{}, domain.ErrEmptyComment).Once()
+			},
+		},
+		{
+			Name:     "body too long",
+			Body:     map[string]string{"body": strings.Repeat("a", domain.MaxCommentLength+1)},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Comment{}, domain.ErrCommentTooLong).Once()
+			},
+		},
+		{
+			Name:     "task not visible",
+			Body:     map[string]string{"body": "Looks good to me"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, "task1", "Looks good to me", sampleActor).Return(domain.Comment{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/comments", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestUpdateComment is used to test UpdateComment controller
+func (s *SuiteCommentUsecase) TestUpdateComment() {
+	tests := []CommentTestCase{
+		{
+			Name:     "comment edited",
+			Body:     map[string]string{"body": "Edited"},
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				edited := sampleComment
+				edited.Body = "Edited"
+				s.mockUsecase.On("Update", mock.Anything, "task1", "comment1", "Edited", sampleActor).Return(edited, nil).Once()
+			},
+		},
+		{
+			Name:     "missing body",
+			Body:     map[string]string{},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "not the author",
+			Body:     map[string]string{"body": "Edited"},
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, "task1", "comment1", "Edited", sampleActor).Return(domain.Comment{}, domain.ErrNotCommentAuthor).Once()
+			},
+		},
+		{
+			Name:     "comment not found",
+			Body:     map[string]string{"body": "Edited"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, "task1", "comment1", "Edited", sampleActor).Return(domain.Comment{}, domain.ErrCommentNotFound).Once()
+			},
+		},
+		{
+			Name:     "invalid comment id",
+			Body:     map[string]string{"body": "Edited"},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, "task1", "comment1", "Edited", sampleActor).Return(domain.Comment{}, domain.ErrInvalidCommentID).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPatch, "/tasks/task1/comments/comment1", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestDeleteComment is used to test DeleteComment controller
+func (s *SuiteCommentUsecase) TestDeleteComment() {
+	tests := []CommentTestCase{
+		{
+			Name:     "comment deleted",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "task1", "comment1", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "not the author",
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "task1", "comment1", sampleActor).Return(domain.ErrNotCommentAuthor).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "task1", "comment1", sampleActor).Return(domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tasks/task1/comments/comment1", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
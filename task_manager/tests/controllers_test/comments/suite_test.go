@@ -0,0 +1,48 @@
+package comments
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteCommentUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockCommentUsecase
+}
+
+func (s *SuiteCommentUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockCommentUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	commentController := controllers.CommentController{CommentUsecase: s.mockUsecase}
+	s.router.GET("/tasks/:id/comments", commentController.GetComments)
+	s.router.POST("/tasks/:id/comments", commentController.CreateComment)
+	s.router.PATCH("/tasks/:id/comments/:commentID", commentController.UpdateComment)
+	s.router.DELETE("/tasks/:id/comments/:commentID", commentController.DeleteComment)
+}
+
+func (s *SuiteCommentUsecase) PrepareTest(tt CommentTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestCommentController(t *testing.T) {
+	suite.Run(t, new(SuiteCommentUsecase))
+}
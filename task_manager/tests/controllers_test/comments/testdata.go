@@ -0,0 +1,37 @@
+package comments
+
+// this file contains shared data, and struct within the comments test
+
+import (
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated user injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+type CommentTestCase struct {
+	Name      string // name of the test
+	Query     string // query string of a listing request
+	Body      any    // payload if it is a post or patch request
+	MockSetup func() // mock setup
+	Expected  int    // expected status
+}
+
+// sample comment posted by sampleUser
+var sampleComment = domain.Comment{
+	ID:        "comment1",
+	TaskID:    "task1",
+	AuthorID:  "user1",
+	Body:      "Looks good to me",
+	CreatedAt: time.Now(),
+}
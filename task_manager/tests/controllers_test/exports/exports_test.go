@@ -0,0 +1,151 @@
+package exports
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportTasks is used to test ExportTasks controller
+func (s *SuiteExportUsecase) TestExportTasks() {
+	// writes content to the response like the usecase streaming tasks
+	writeExport := func(content string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			io.WriteString(args.Get(3).(io.Writer), content)
+		}
+	}
+
+	tests := []ExportTestCase{
+		{
+			Name:        "csv",
+			Query:       "?format=csv",
+			Expected:    http.StatusOK,
+			ContentType: "text/csv; charset=utf-8",
+			Body:        "id,project_id",
+			MockSetup: func() {
+				s.mockUsecase.On("Export", mock.Anything, domain.TaskQuery{}, domain.ExportCSV, mock.Anything, sampleActor).
+					Run(writeExport("id,project_id\n")).Return(nil).Once()
+			},
+		},
+		{
+			Name:        "filtered calendar",
+			Query:       "?format=ICS&project=project1&status=pending&due_after=2025-01-01T00:00:00Z&tag=work&sort=due_date&order=desc",
+			Expected:    http.StatusOK,
+			ContentType: "text/calendar; charset=utf-8",
+			Body:        "BEGIN:VCALENDAR",
+			MockSetup: func() {
+				query := domain.TaskQuery{
+					ProjectID: "project1",
+					Status:    domain.StatusPending,
+					DueAfter:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Tags:      []string{"work"},
+					SortBy:    domain.TaskSortByDueDate,
+					SortDesc:  true,
+				}
+				s.mockUsecase.On("Export", mock.Anything, mock.MatchedBy(func(q domain.TaskQuery) bool {
+					return q.ProjectID == query.ProjectID && q.Status == query.Status && q.DueAfter.Equal(query.DueAfter) &&
+						len(q.Tags) == 1 && q.Tags[0] == "work" && q.SortBy == query.SortBy && q.SortDesc
+				}), domain.ExportICS, mock.Anything, sampleActor).Run(writeExport("BEGIN:VCALENDAR\r\n")).Return(nil).Once()
+			},
+		},
+		{
+			Name:        "json lines",
+			Query:       "?format=jsonl",
+			Expected:    http.StatusOK,
+			ContentType: "application/x-ndjson",
+			MockSetup: func() {
+				s.mockUsecase.On("Export", mock.Anything, domain.TaskQuery{}, domain.ExportJSONL, mock.Anything, sampleActor).
+					Run(writeExport("{}\n")).Return(nil).Once()
+			},
+		},
+		{
+			Name:        "empty export",
+			Query:       "?format=jsonl&tag=none",
+			Expected:    http.StatusOK,
+			ContentType: "application/x-ndjson",
+			MockSetup: func() {
+				s.mockUsecase.On("Export", mock.Anything, mock.Anything, domain.ExportJSONL, mock.Anything, sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing format",
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "unknown format",
+			Query:    "?format=xlsx",
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "invalid order",
+			Query:    "?format=csv&order=up",
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "invalid query",
+			Query:    "?format=csv&status=started",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				err := fmt.Errorf("%w: unknown status %q", domain.ErrInvalidQuery, "started")
+				s.mockUsecase.On("Export", mock.Anything, mock.Anything, domain.ExportCSV, mock.Anything, sampleActor).Return(err).Once()
+			},
+		},
+		{
+			Name:     "project not found",
+			Query:    "?format=csv&project=project2",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Export", mock.Anything, mock.Anything, domain.ExportCSV, mock.Anything, sampleActor).Return(domain.ErrProjectNotFound).Once()
+			},
+		},
+		{
+			Name:     "internal server error",
+			Query:    "?format=csv",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("Export", mock.Anything, mock.Anything, domain.ExportCSV, mock.Anything, sampleActor).Return(fmt.Errorf("db error")).Once()
+			},
+		},
+		{
+			Name:        "failure after the export started",
+			Query:       "?format=csv",
+			Expected:    http.StatusOK,
+			ContentType: "text/csv; charset=utf-8",
+			Body:        "id,project_id",
+			MockSetup: func() {
+				s.mockUsecase.On("Export", mock.Anything, mock.Anything, domain.ExportCSV, mock.Anything, sampleActor).
+					Run(writeExport("id,project_id\n")).Return(fmt.Errorf("cursor killed")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/export"+tt.Query, nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+			if tt.ContentType == "" {
+				require.Contains(s.T(), resp.Header().Get("Content-Type"), "application/json")
+				require.Empty(s.T(), resp.Header().Get("Content-Disposition"))
+				return
+			}
+			require.Equal(s.T(), tt.ContentType, resp.Header().Get("Content-Type"))
+			require.Contains(s.T(), resp.Header().Get("Content-Disposition"), "attachment")
+			require.True(s.T(), strings.HasPrefix(resp.Body.String(), tt.Body))
+			// an export started before failing is never followed by an error document
+			require.NotContains(s.T(), resp.Body.String(), `"error"`)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package exports
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteExportUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockTaskExportUsecase
+}
+
+func (s *SuiteExportUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockTaskExportUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	exportController := controllers.ExportController{TaskExportUsecase: s.mockUsecase}
+	s.router.GET("/tasks/export", exportController.ExportTasks)
+}
+
+func (s *SuiteExportUsecase) PrepareTest(tt ExportTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestExportController(t *testing.T) {
+	suite.Run(t, new(SuiteExportUsecase))
+}
@@ -0,0 +1,27 @@
+package exports
+
+// this file contains shared data, and struct within the export test
+
+import (
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated user injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+type ExportTestCase struct {
+	Name        string // name of the test
+	Query       string // query string of the request
+	MockSetup   func() // mock setup
+	Expected    int    // expected status
+	ContentType string // expected Content-Type, empty for JSON error responses
+	Body        string // expected start of the body, empty to skip the check
+}
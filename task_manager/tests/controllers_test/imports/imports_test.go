@@ -0,0 +1,154 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestImportTasks is used to test ImportTasks controller
+func (s *SuiteImportUsecase) TestImportTasks() {
+	tests := []ImportTestCase{
+		{
+			Name:        "dry run",
+			ContentType: "text/csv",
+			Body:        sampleCSV,
+			Expected:    http.StatusOK,
+			Errors:      []bool{false, true},
+			MockSetup: func() {
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, true, sampleActor).Return(sampleReport, nil).Once()
+			},
+		},
+		{
+			Name:        "confirmed import",
+			Query:       "?confirm=true",
+			ContentType: "text/csv; charset=utf-8",
+			Body:        sampleCSV,
+			Expected:    http.StatusCreated,
+			Errors:      []bool{false, true},
+			MockSetup: func() {
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, false, sampleActor).Return(sampleImportedReport, nil).Once()
+			},
+		},
+		{
+			Name:        "confirmed import without valid rows",
+			Query:       "?confirm=true",
+			ContentType: "application/json",
+			Body:        `[{"title": "Old task"}]`,
+			Expected:    http.StatusUnprocessableEntity,
+			Errors:      []bool{true},
+			MockSetup: func() {
+				report := domain.ImportReport{Invalid: 1, Rows: []domain.ImportRow{{Row: 1, Title: "Old task", Err: domain.ErrInvalidDueDate}}}
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportJSON, mock.Anything, false, sampleActor).Return(report, nil).Once()
+			},
+		},
+		{
+			Name:        "format overrides content type",
+			Query:       "?format=CSV",
+			ContentType: "text/plain",
+			Body:        sampleCSV,
+			Expected:    http.StatusOK,
+			Errors:      []bool{false, true},
+			MockSetup: func() {
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, true, sampleActor).Return(sampleReport, nil).Once()
+			},
+		},
+		{
+			Name:        "unknown format",
+			Query:       "?format=xml",
+			ContentType: "text/csv",
+			Body:        sampleCSV,
+			Expected:    http.StatusBadRequest,
+		},
+		{
+			Name:        "unsupported content type",
+			ContentType: "application/xml",
+			Body:        `<tasks/>`,
+			Expected:    http.StatusUnsupportedMediaType,
+		},
+		{
+			Name:        "invalid confirm",
+			Query:       "?confirm=maybe",
+			ContentType: "text/csv",
+			Body:        sampleCSV,
+			Expected:    http.StatusBadRequest,
+		},
+		{
+			Name:        "invalid file",
+			ContentType: "text/csv",
+			Body:        "owner\nuser1\n",
+			Expected:    http.StatusBadRequest,
+			MockSetup: func() {
+				err := fmt.Errorf("%w: unknown column %q", domain.ErrInvalidImport, "owner")
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, true, sampleActor).Return(domain.ImportReport{}, err).Once()
+			},
+		},
+		{
+			Name:        "too many rows",
+			ContentType: "text/csv",
+			Body:        sampleCSV,
+			Expected:    http.StatusRequestEntityTooLarge,
+			MockSetup: func() {
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, true, sampleActor).Return(domain.ImportReport{}, domain.ErrImportTooLarge).Once()
+			},
+		},
+		{
+			Name:        "file too large",
+			ContentType: "text/csv",
+			Body:        sampleCSV,
+			Expected:    http.StatusRequestEntityTooLarge,
+			MockSetup: func() {
+				err := fmt.Errorf("%w: %w", domain.ErrInvalidImport, &http.MaxBytesError{Limit: 1})
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, true, sampleActor).Return(domain.ImportReport{}, err).Once()
+			},
+		},
+		{
+			Name:        "internal server error",
+			ContentType: "text/csv",
+			Body:        sampleCSV,
+			Expected:    http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("Import", mock.Anything, domain.ImportCSV, mock.Anything, true, sampleActor).Return(domain.ImportReport{}, fmt.Errorf("db error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/import"+tt.Query, bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", tt.ContentType)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+			if tt.Errors == nil {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					Rows []struct {
+						Row   int    `json:"row"`
+						ID    string `json:"id"`
+						Error string `json:"error"`
+					} `json:"rows"`
+				} `json:"data"`
+			}
+			require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+			require.Len(s.T(), body.Data.Rows, len(tt.Errors))
+			for i, hasError := range tt.Errors {
+				require.Equal(s.T(), i+1, body.Data.Rows[i].Row)
+				require.Equal(s.T(), hasError, body.Data.Rows[i].Error != "")
+			}
+		})
+	}
+}
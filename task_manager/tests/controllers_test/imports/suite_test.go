@@ -0,0 +1,45 @@
+package imports
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteImportUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockTaskImportUsecase
+}
+
+func (s *SuiteImportUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockTaskImportUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	importController := controllers.ImportController{TaskImportUsecase: s.mockUsecase}
+	s.router.POST("/tasks/import", importController.ImportTasks)
+}
+
+func (s *SuiteImportUsecase) PrepareTest(tt ImportTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestImportController(t *testing.T) {
+	suite.Run(t, new(SuiteImportUsecase))
+}
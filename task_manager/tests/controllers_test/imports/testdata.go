@@ -0,0 +1,56 @@
+package imports
+
+// this file contains shared data, and struct within the import test
+
+import (
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated admin injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "admin1",
+	Username: "admin",
+	IsAdmin:  true,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+type ImportTestCase struct {
+	Name        string // name of the test
+	Query       string // query string of the request
+	ContentType string // Content-Type header of the request
+	Body        string // payload of the request
+	MockSetup   func() // mock setup
+	Expected    int    // expected status
+	Errors      []bool // whether each row of the response has an error, nil when the response lists none
+}
+
+// file with a valid row and a row due in the past
+const sampleCSV = `title,project_id,due_date,status
+Write report,project1,2999-01-01,pending
+Old task,project1,2000-01-01,pending
+`
+
+// report of sampleCSV
+var sampleReport = domain.ImportReport{
+	DryRun:  true,
+	Valid:   1,
+	Invalid: 1,
+	Rows: []domain.ImportRow{
+		{Row: 1, Title: "Write report"},
+		{Row: 2, Title: "Old task", Err: domain.ErrInvalidDueDate},
+	},
+}
+
+// report of sampleCSV once imported
+var sampleImportedReport = domain.ImportReport{
+	Valid:    1,
+	Invalid:  1,
+	Imported: 1,
+	Rows: []domain.ImportRow{
+		{Row: 1, Title: "Write report", TaskID: "task1"},
+		{Row: 2, Title: "Old task", Err: domain.ErrInvalidDueDate},
+	},
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentUsecase) Upload(c context.Context, taskID string, upload domain.AttachmentUpload, actor domain.Actor) (domain.Attachment, error) {
+	args := m.Called(c, taskID, upload, actor)
+	return args.Get(0).(domain.Attachment), args.Error(1)
+}
+func (m *MockAttachmentUsecase) FetchByTaskID(c context.Context, taskID string, actor domain.Actor) ([]domain.Attachment, error) {
+	args := m.Called(c, taskID, actor)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+func (m *MockAttachmentUsecase) Open(c context.Context, taskID string, attachmentID string, actor domain.Actor) (domain.Attachment, io.ReadCloser, error) {
+	args := m.Called(c, taskID, attachmentID, actor)
+	content, _ := args.Get(1).(io.ReadCloser)
+	return args.Get(0).(domain.Attachment), content, args.Error(2)
+}
+func (m *MockAttachmentUsecase) Delete(c context.Context, taskID string, attachmentID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, attachmentID, actor)
+	return args.Error(0)
+}
+func (m *MockAttachmentUsecase) DeleteByTaskIDs(c context.Context, taskIDs []string) error {
+	args := m.Called(c, taskIDs)
+	return args.Error(0)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalendarUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCalendarUsecase) IssueToken(c context.Context, actor domain.Actor) (string, error) {
+	args := m.Called(c, actor)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCalendarUsecase) RevokeToken(c context.Context, actor domain.Actor) error {
+	args := m.Called(c, actor)
+	return args.Error(0)
+}
+
+func (m *MockCalendarUsecase) FetchFeed(c context.Context, token string, ifNoneMatch string) (domain.CalendarFeed, error) {
+	args := m.Called(c, token, ifNoneMatch)
+	return args.Get(0).(domain.CalendarFeed), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCommentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockCommentUsecase) Create(c context.Context, taskID string, body string, actor domain.Actor) (domain.Comment, error) {
+	args := m.Called(c, taskID, body, actor)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+func (m *MockCommentUsecase) FetchByTaskID(c context.Context, query domain.CommentQuery, actor domain.Actor) (domain.CommentPage, error) {
+	args := m.Called(c, query, actor)
+	return args.Get(0).(domain.CommentPage), args.Error(1)
+}
+func (m *MockCommentUsecase) Update(c context.Context, taskID string, commentID string, body string, actor domain.Actor) (domain.Comment, error) {
+	args := m.Called(c, taskID, commentID, body, actor)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+func (m *MockCommentUsecase) Delete(c context.Context, taskID string, commentID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, commentID, actor)
+	return args.Error(0)
+}
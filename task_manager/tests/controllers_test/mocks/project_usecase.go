@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProjectUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProjectUsecase) Create(c context.Context, project *domain.Project, actor domain.Actor) error {
+	args := m.Called(c, project, actor)
+	return args.Error(0)
+}
+func (m *MockProjectUsecase) FetchByID(c context.Context, projectID string, actor domain.Actor) (domain.Project, error) {
+	args := m.Called(c, projectID, actor)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+func (m *MockProjectUsecase) FetchAll(c context.Context, actor domain.Actor) ([]domain.Project, error) {
+	args := m.Called(c, actor)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+func (m *MockProjectUsecase) Update(c context.Context, project *domain.Project, actor domain.Actor) error {
+	args := m.Called(c, project, actor)
+	return args.Error(0)
+}
+func (m *MockProjectUsecase) AddMember(c context.Context, projectID string, member domain.ProjectMember, actor domain.Actor) error {
+	args := m.Called(c, projectID, member, actor)
+	return args.Error(0)
+}
+func (m *MockProjectUsecase) UpdateMemberRole(c context.Context, projectID string, userID string, role domain.ProjectRole, actor domain.Actor) error {
+	args := m.Called(c, projectID, userID, role, actor)
+	return args.Error(0)
+}
+func (m *MockProjectUsecase) RemoveMember(c context.Context, projectID string, userID string, actor domain.Actor) error {
+	args := m.Called(c, projectID, userID, actor)
+	return args.Error(0)
+}
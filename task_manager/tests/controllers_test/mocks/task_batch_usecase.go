@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskBatchUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaskBatchUsecase) Execute(c context.Context, operations []domain.BatchOperation, atomic bool, actor domain.Actor) ([]domain.BatchResult, error) {
+	args := m.Called(c, operations, atomic, actor)
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
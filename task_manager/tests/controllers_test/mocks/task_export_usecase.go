@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskExportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaskExportUsecase) Export(c context.Context, query domain.TaskQuery, format domain.ExportFormat, w io.Writer, actor domain.Actor) error {
+	args := m.Called(c, query, format, w, actor)
+	return args.Error(0)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaskImportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTaskImportUsecase) Import(c context.Context, format domain.ImportFormat, file io.Reader, dryRun bool, actor domain.Actor) (domain.ImportReport, error) {
+	args := m.Called(c, format, file, dryRun, actor)
+	return args.Get(0).(domain.ImportReport), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	domain "github.com/A2SVTask7/Domain"
 	"github.com/stretchr/testify/mock"
@@ -11,24 +12,120 @@ type MockTaskUsecase struct {
 	mock.Mock
 }
 
-func (m *MockTaskUsecase) Create(c context.Context, task *domain.Task) error {
-	args := m.Called(c, task)
+func (m *MockTaskUsecase) Create(c context.Context, task *domain.Task, actor domain.Actor) error {
+	args := m.Called(c, task, actor)
 	return args.Error(0)
 }
 
-func (m *MockTaskUsecase) FetchByTaskID(c context.Context, taskID string) (domain.Task, error) {
-	args := m.Called(c, taskID)
+func (m *MockTaskUsecase) FetchByTaskID(c context.Context, taskID string, actor domain.Actor) (domain.Task, error) {
+	args := m.Called(c, taskID, actor)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
-func (m *MockTaskUsecase) FetchAllTasks(c context.Context) ([]domain.Task, error) {
+func (m *MockTaskUsecase) FetchAllTasks(c context.Context, query domain.TaskQuery, actor domain.Actor) (domain.TaskPage, error) {
+	args := m.Called(c, query, actor)
+	return args.Get(0).(domain.TaskPage), args.Error(1)
+}
+func (m *MockTaskUsecase) DeleteByTaskID(c context.Context, taskID string, version int, actor domain.Actor) error {
+	args := m.Called(c, taskID, version, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) FetchHistory(c context.Context, taskID string, actor domain.Actor) ([]domain.TaskHistoryEntry, error) {
+	args := m.Called(c, taskID, actor)
+	return args.Get(0).([]domain.TaskHistoryEntry), args.Error(1)
+}
+func (m *MockTaskUsecase) FetchTrash(c context.Context) ([]domain.Task, error) {
+	args := m.Called(c)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+func (m *MockTaskUsecase) RestoreByTaskID(c context.Context, taskID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) PurgeTrash(c context.Context) (int, error) {
 	args := m.Called(c)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockTaskUsecase) UpdateByTaskID(c context.Context, task *domain.Task, actor domain.Actor) error {
+	args := m.Called(c, task, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) PatchByTaskID(c context.Context, taskID string, patch domain.TaskPatch, actor domain.Actor) (domain.Task, error) {
+	args := m.Called(c, taskID, patch, actor)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+func (m *MockTaskUsecase) AssignUser(c context.Context, taskID string, userID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, userID, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) UnassignUser(c context.Context, taskID string, userID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, userID, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) FetchAssignedTasks(c context.Context, userID string) ([]domain.Task, error) {
+	args := m.Called(c, userID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+func (m *MockTaskUsecase) Search(c context.Context, query domain.TaskSearchQuery, actor domain.Actor) ([]domain.TaskSearchResult, error) {
+	args := m.Called(c, query, actor)
+	return args.Get(0).([]domain.TaskSearchResult), args.Error(1)
+}
+func (m *MockTaskUsecase) SetRecurrence(c context.Context, taskID string, recurrence string, actor domain.Actor) (domain.Task, error) {
+	args := m.Called(c, taskID, recurrence, actor)
+	return args.Get(0).(domain.Task), args.Error(1)
+}
+func (m *MockTaskUsecase) StopRecurrence(c context.Context, taskID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) MarkOverdueMissed(c context.Context, now time.Time) (int, error) {
+	args := m.Called(c, now)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockTaskUsecase) FetchSubtasks(c context.Context, taskID string, actor domain.Actor) ([]domain.Task, error) {
+	args := m.Called(c, taskID, actor)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+func (m *MockTaskUsecase) CreateSubtask(c context.Context, parentID string, task *domain.Task, actor domain.Actor) error {
+	args := m.Called(c, parentID, task, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) ReorderSubtasks(c context.Context, parentID string, taskIDs []string, actor domain.Actor) ([]domain.Task, error) {
+	args := m.Called(c, parentID, taskIDs, actor)
 	return args.Get(0).([]domain.Task), args.Error(1)
 }
-func (m *MockTaskUsecase) DeleteByTaskID(c context.Context, taskID string) error {
-	args := m.Called(c, taskID)
+func (m *MockTaskUsecase) AddChecklistItem(c context.Context, taskID string, text string, actor domain.Actor) (domain.ChecklistItem, error) {
+	args := m.Called(c, taskID, text, actor)
+	return args.Get(0).(domain.ChecklistItem), args.Error(1)
+}
+func (m *MockTaskUsecase) UpdateChecklistItem(c context.Context, taskID string, itemID string, patch domain.ChecklistItemPatch, actor domain.Actor) (domain.ChecklistItem, error) {
+	args := m.Called(c, taskID, itemID, patch, actor)
+	return args.Get(0).(domain.ChecklistItem), args.Error(1)
+}
+func (m *MockTaskUsecase) RemoveChecklistItem(c context.Context, taskID string, itemID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, itemID, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) ReorderChecklist(c context.Context, taskID string, itemIDs []string, actor domain.Actor) ([]domain.ChecklistItem, error) {
+	args := m.Called(c, taskID, itemIDs, actor)
+	return args.Get(0).([]domain.ChecklistItem), args.Error(1)
+}
+func (m *MockTaskUsecase) AddDependency(c context.Context, taskID string, blockerID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, blockerID, actor)
 	return args.Error(0)
 }
-func (m *MockTaskUsecase) UpdateByTaskID(c context.Context, task *domain.Task) error {
-	args := m.Called(c, task)
+func (m *MockTaskUsecase) RemoveDependency(c context.Context, taskID string, blockerID string, actor domain.Actor) error {
+	args := m.Called(c, taskID, blockerID, actor)
 	return args.Error(0)
 }
+func (m *MockTaskUsecase) AddTag(c context.Context, taskID string, tag string, actor domain.Actor) error {
+	args := m.Called(c, taskID, tag, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) RemoveTag(c context.Context, taskID string, tag string, actor domain.Actor) error {
+	args := m.Called(c, taskID, tag, actor)
+	return args.Error(0)
+}
+func (m *MockTaskUsecase) FetchTags(c context.Context, actor domain.Actor) ([]domain.TagCount, error) {
+	args := m.Called(c, actor)
+	return args.Get(0).([]domain.TagCount), args.Error(1)
+}
@@ -26,9 +26,9 @@ func (m *MockUserUsecase) FetchByUsername(c context.Context, username string) (d
 	return args.Get(0).(domain.User), args.Error(1)
 }
 
-func (m *MockUserUsecase) FetchAllUsers(c context.Context) ([]domain.User, error) {
-	args := m.Called(c)
-	return args.Get(0).([]domain.User), args.Error(1)
+func (m *MockUserUsecase) FetchAllUsers(c context.Context, query domain.UserQuery) (domain.UserPage, error) {
+	args := m.Called(c, query)
+	return args.Get(0).(domain.UserPage), args.Error(1)
 }
 
 func (m *MockUserUsecase) PromoteByUserID(c context.Context, userID string) error {
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookUsecase struct {
+	mock.Mock
+}
+
+func (m *MockWebhookUsecase) Create(c context.Context, webhook *domain.Webhook, actor domain.Actor) error {
+	args := m.Called(c, webhook, actor)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUsecase) FetchAll(c context.Context) ([]domain.Webhook, error) {
+	args := m.Called(c)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookUsecase) FetchByID(c context.Context, webhookID string) (domain.Webhook, error) {
+	args := m.Called(c, webhookID)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookUsecase) Update(c context.Context, webhook *domain.Webhook) error {
+	args := m.Called(c, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUsecase) Delete(c context.Context, webhookID string) error {
+	args := m.Called(c, webhookID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUsecase) FetchDeliveries(c context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(c, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookUsecase) Redeliver(c context.Context, webhookID string, deliveryID string) (domain.WebhookDelivery, error) {
+	args := m.Called(c, webhookID, deliveryID)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
@@ -0,0 +1,313 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetProjects is used to test GetProjects controller
+func (s *SuiteProjectUsecase) TestGetProjects() {
+	s.Run("member projects", func() {
+		s.PrepareTest(ProjectTestCase{
+			MockSetup: func() {
+				s.mockUsecase.On("FetchAll", mock.Anything, sampleActor).Return([]domain.Project{sampleProject}, nil).Once()
+			},
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/projects", nil)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusOK, resp.Code)
+
+		var body struct {
+			Data []domain.Project `json:"data"`
+		}
+		require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+		require.Len(s.T(), body.Data, 1)
+		require.Equal(s.T(), sampleProject.Name, body.Data[0].Name)
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("internal server error", func() {
+		s.PrepareTest(ProjectTestCase{
+			MockSetup: func() {
+				s.mockUsecase.On("FetchAll", mock.Anything, sampleActor).Return([]domain.Project{}, fmt.Errorf("db error")).Once()
+			},
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/projects", nil)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusInternalServerError, resp.Code)
+	})
+}
+
+// TestCreateProject is used to test CreateProject controller
+func (s *SuiteProjectUsecase) TestCreateProject() {
+	tests := []ProjectTestCase{
+		{
+			Name:     "project created",
+			Body:     map[string]string{"name": "Launch", "description": "Q3 launch"},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+					return p.Name == "Launch" && p.Description == "Q3 launch"
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing name",
+			Body:     map[string]string{"description": "Q3 launch"},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "blank name",
+			Body:     map[string]string{"name": "   "},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrInvalidProjectName).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPost, "/projects", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestGetProject is used to test GetProject controller
+func (s *SuiteProjectUsecase) TestGetProject() {
+	tests := []ProjectTestCase{
+		{
+			Name:     "project found",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByID", mock.Anything, "project1", sampleActor).Return(sampleProject, nil).Once()
+			},
+		},
+		{
+			Name:     "project of another team",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByID", mock.Anything, "project1", sampleActor).Return(domain.Project{}, domain.ErrProjectNotFound).Once()
+			},
+		},
+		{
+			Name:     "invalid project id",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByID", mock.Anything, "project1", sampleActor).Return(domain.Project{}, domain.ErrInvalidProjectID).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/projects/project1", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestUpdateProject is used to test UpdateProject controller
+func (s *SuiteProjectUsecase) TestUpdateProject() {
+	tests := []ProjectTestCase{
+		{
+			Name:     "project renamed",
+			Body:     map[string]string{"name": "Relaunch"},
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Project) bool {
+					return p.ID == "project1" && p.Name == "Relaunch"
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "not an owner",
+			Body:     map[string]string{"name": "Relaunch"},
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrNotProjectOwner).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPut, "/projects/project1", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestAddProjectMember is used to test AddProjectMember controller
+func (s *SuiteProjectUsecase) TestAddProjectMember() {
+	member := domain.ProjectMember{UserID: "user2", Role: domain.ProjectRoleEditor}
+
+	tests := []ProjectTestCase{
+		{
+			Name:     "member added",
+			Body:     map[string]string{"user_id": "user2", "role": "editor"},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("AddMember", mock.Anything, "project1", member, sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing role",
+			Body:     map[string]string{"user_id": "user2"},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "unknown role",
+			Body:     map[string]string{"user_id": "user2", "role": "guest"},
+			Expected: http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("AddMember", mock.Anything, "project1", mock.Anything, sampleActor).Return(domain.ErrInvalidProjectRole).Once()
+			},
+		},
+		{
+			Name:     "already a member",
+			Body:     map[string]string{"user_id": "user2", "role": "editor"},
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("AddMember", mock.Anything, "project1", member, sampleActor).Return(domain.ErrProjectMemberExists).Once()
+			},
+		},
+		{
+			Name:     "unknown user",
+			Body:     map[string]string{"user_id": "user2", "role": "editor"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("AddMember", mock.Anything, "project1", member, sampleActor).Return(domain.ErrUserNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPost, "/projects/project1/members", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestUpdateProjectMember is used to test UpdateProjectMember controller
+func (s *SuiteProjectUsecase) TestUpdateProjectMember() {
+	tests := []ProjectTestCase{
+		{
+			Name:     "role changed",
+			Body:     map[string]string{"role": "viewer"},
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateMemberRole", mock.Anything, "project1", "user2", domain.ProjectRoleViewer, sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "last owner",
+			Body:     map[string]string{"role": "viewer"},
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateMemberRole", mock.Anything, "project1", "user2", domain.ProjectRoleViewer, sampleActor).Return(domain.ErrLastProjectOwner).Once()
+			},
+		},
+		{
+			Name:     "not a member",
+			Body:     map[string]string{"role": "viewer"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateMemberRole", mock.Anything, "project1", "user2", domain.ProjectRoleViewer, sampleActor).Return(domain.ErrNotProjectMember).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPatch, "/projects/project1/members/user2", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestRemoveProjectMember is used to test RemoveProjectMember controller
+func (s *SuiteProjectUsecase) TestRemoveProjectMember() {
+	tests := []ProjectTestCase{
+		{
+			Name:     "member removed",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveMember", mock.Anything, "project1", "user2", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "not an owner",
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveMember", mock.Anything, "project1", "user2", sampleActor).Return(domain.ErrNotProjectOwner).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/projects/project1/members/user2", nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteProjectUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockProjectUsecase
+}
+
+func (s *SuiteProjectUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockProjectUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	projectController := controllers.ProjectController{ProjectUsecase: s.mockUsecase}
+	s.router.GET("/projects", projectController.GetProjects)
+	s.router.POST("/projects", projectController.CreateProject)
+	s.router.GET("/projects/:id", projectController.GetProject)
+	s.router.PUT("/projects/:id", projectController.UpdateProject)
+	s.router.POST("/projects/:id/members", projectController.AddProjectMember)
+	s.router.PATCH("/projects/:id/members/:userID", projectController.UpdateProjectMember)
+	s.router.DELETE("/projects/:id/members/:userID", projectController.RemoveProjectMember)
+}
+
+func (s *SuiteProjectUsecase) PrepareTest(tt ProjectTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestProjectController(t *testing.T) {
+	suite.Run(t, new(SuiteProjectUsecase))
+}
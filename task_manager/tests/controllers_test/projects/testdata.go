@@ -0,0 +1,36 @@
+package projects
+
+// this file contains shared data, and struct within the projects test
+
+import (
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated user injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+type ProjectTestCase struct {
+	Name      string // name of the test
+	Body      any    // payload if it is a post, put or patch request
+	MockSetup func() // mock setup
+	Expected  int    // expected status
+}
+
+// sample project owned by sampleUser
+var sampleProject = domain.Project{
+	ID:          "project1",
+	Name:        "Launch",
+	Description: "Q3 launch",
+	Members:     []domain.ProjectMember{{UserID: "user1", Role: domain.ProjectRoleOwner}},
+	CreatedAt:   time.Now(),
+}
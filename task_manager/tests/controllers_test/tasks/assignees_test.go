@@ -0,0 +1,122 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssignUser is used to test AssignUser controller
+func (s *SuiteTaskUsecase) TestAssignUser() {
+	tests := []struct {
+		Name      string
+		Body      any
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "user assigned",
+			Body:     map[string]string{"user_id": "user2"},
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("AssignUser", mock.Anything, "task1", "user2", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing user id",
+			Body:     map[string]string{},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "user does not exist",
+			Body:     map[string]string{"user_id": "ghost"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("AssignUser", mock.Anything, "task1", "ghost", sampleActor).Return(domain.ErrUserNotFound).Once()
+			},
+		},
+		{
+			Name:     "already assigned",
+			Body:     map[string]string{"user_id": "user2"},
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("AssignUser", mock.Anything, "task1", "user2", sampleActor).Return(domain.ErrUserAlreadyAssigned).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/assignees", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestUnassignUser is used to test UnassignUser controller
+func (s *SuiteTaskUsecase) TestUnassignUser() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "user unassigned",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("UnassignUser", mock.Anything, "task1", "user2", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "user not assigned",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("UnassignUser", mock.Anything, "task1", "user2", sampleActor).Return(domain.ErrUserNotAssigned).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tasks/task1/assignees/user2", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestGetMyTasks is used to test GetMyTasks controller
+func (s *SuiteTaskUsecase) TestGetMyTasks() {
+	s.PrepareTest(TaskListTestCase{
+		MockSetup: func() {
+			s.mockUsecase.On("FetchAssignedTasks", mock.Anything, sampleUser.ID).Return(sampleDatas, nil).Once()
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/me/tasks", nil)
+	resp := httptest.NewRecorder()
+	s.router.ServeHTTP(resp, req)
+
+	require.Equal(s.T(), http.StatusOK, resp.Code)
+
+	var body []domain.Task
+	require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(s.T(), body, len(sampleDatas))
+	s.mockUsecase.AssertExpectations(s.T())
+}
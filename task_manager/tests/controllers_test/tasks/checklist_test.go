@@ -0,0 +1,135 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChecklist is used to test the checklist controllers
+func (s *SuiteTaskUsecase) TestChecklist() {
+	item := domain.ChecklistItem{ID: "item1", Text: "write tests", Done: true}
+
+	tests := []struct {
+		Name      string
+		Method    string
+		Path      string
+		Body      string
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "item added",
+			Method:   http.MethodPost,
+			Path:     "/tasks/task1/checklist",
+			Body:     `{"text": "write tests"}`,
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("AddChecklistItem", mock.Anything, "task1", "write tests", sampleActor).Return(item, nil).Once()
+			},
+		},
+		{
+			Name:     "item text missing",
+			Method:   http.MethodPost,
+			Path:     "/tasks/task1/checklist",
+			Body:     `{}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "item added to missing task",
+			Method:   http.MethodPost,
+			Path:     "/tasks/task1/checklist",
+			Body:     `{"text": "write tests"}`,
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("AddChecklistItem", mock.Anything, "task1", "write tests", sampleActor).Return(domain.ChecklistItem{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+		{
+			Name:     "item toggled",
+			Method:   http.MethodPatch,
+			Path:     "/tasks/task1/checklist/item1",
+			Body:     `{"done": true}`,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateChecklistItem", mock.Anything, "task1", "item1", mock.MatchedBy(func(p domain.ChecklistItemPatch) bool {
+					return p.Text == nil && p.Done != nil && *p.Done
+				}), sampleActor).Return(item, nil).Once()
+			},
+		},
+		{
+			Name:     "unknown item",
+			Method:   http.MethodPatch,
+			Path:     "/tasks/task1/checklist/item2",
+			Body:     `{"done": true}`,
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateChecklistItem", mock.Anything, "task1", "item2", mock.Anything, sampleActor).Return(domain.ChecklistItem{}, domain.ErrChecklistItemNotFound).Once()
+			},
+		},
+		{
+			Name:     "concurrent change",
+			Method:   http.MethodPatch,
+			Path:     "/tasks/task1/checklist/item1",
+			Body:     `{"text": "write more tests"}`,
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateChecklistItem", mock.Anything, "task1", "item1", mock.Anything, sampleActor).Return(domain.ChecklistItem{}, domain.ErrVersionConflict).Once()
+			},
+		},
+		{
+			Name:     "item removed",
+			Method:   http.MethodDelete,
+			Path:     "/tasks/task1/checklist/item1",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveChecklistItem", mock.Anything, "task1", "item1", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "checklist reordered",
+			Method:   http.MethodPut,
+			Path:     "/tasks/task1/checklist/order",
+			Body:     `{"ids": ["item2", "item1"]}`,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("ReorderChecklist", mock.Anything, "task1", []string{"item2", "item1"}, sampleActor).Return([]domain.ChecklistItem{item}, nil).Once()
+			},
+		},
+		{
+			Name:     "incomplete order",
+			Method:   http.MethodPut,
+			Path:     "/tasks/task1/checklist/order",
+			Body:     `{"ids": ["item2"]}`,
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("ReorderChecklist", mock.Anything, "task1", []string{"item2"}, sampleActor).Return([]domain.ChecklistItem{}, domain.ErrInvalidOrder).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(tt.Method, tt.Path, bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			if tt.Method == http.MethodPatch && resp.Code == http.StatusOK {
+				var body domain.ChecklistItem
+				require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+				require.Equal(s.T(), item, body)
+			}
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
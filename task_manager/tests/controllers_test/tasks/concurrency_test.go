@@ -0,0 +1,139 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTaskETag checks that GetTaskByID exposes the task version as ETag
+func (s *SuiteTaskUsecase) TestTaskETag() {
+	task := sampleDatas[0]
+	task.Version = 7
+	s.PrepareTest(TaskListTestCase{
+		MockSetup: func() {
+			s.mockUsecase.On("FetchByTaskID", mock.Anything, task.ID, sampleActor).Return(task, nil).Once()
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/"+task.ID, nil)
+	resp := httptest.NewRecorder()
+	s.router.ServeHTTP(resp, req)
+
+	require.Equal(s.T(), http.StatusOK, resp.Code)
+	require.Equal(s.T(), `"7"`, resp.Header().Get("ETag"))
+}
+
+// TestIfMatch checks that updates and deletes honour the If-Match header
+func (s *SuiteTaskUsecase) TestIfMatch() {
+	payload, _ := json.Marshal(TaskRequest{
+		Title:   sampleDatas[0].Title,
+		DueDate: sampleDatas[0].DueDate,
+		Status:  "pending",
+	})
+
+	tests := []struct {
+		Name      string
+		Method    string
+		IfMatch   string
+		Expected  int
+		ETag      string
+		MockSetup func()
+	}{
+		{
+			Name:     "update with current version",
+			Method:   http.MethodPut,
+			IfMatch:  `"3"`,
+			Expected: http.StatusOK,
+			ETag:     `"4"`,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+					if t.Version != 3 {
+						return false
+					}
+					t.Version = 4
+					return true
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "update with stale version",
+			Method:   http.MethodPut,
+			IfMatch:  `"2"`,
+			Expected: http.StatusPreconditionFailed,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrVersionConflict).Once()
+			},
+		},
+		{
+			Name:     "update racing another status change",
+			Method:   http.MethodPut,
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrVersionConflict).Once()
+			},
+		},
+		{
+			Name:     "update with unusable entity tag",
+			Method:   http.MethodPut,
+			IfMatch:  `W/"3"`,
+			Expected: http.StatusPreconditionFailed,
+		},
+		{
+			Name:     "delete with current version",
+			Method:   http.MethodDelete,
+			IfMatch:  `"5"`,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("DeleteByTaskID", mock.Anything, "task1", 5, sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "delete with stale version",
+			Method:   http.MethodDelete,
+			IfMatch:  `"4"`,
+			Expected: http.StatusPreconditionFailed,
+			MockSetup: func() {
+				s.mockUsecase.On("DeleteByTaskID", mock.Anything, "task1", 4, sampleActor).Return(domain.ErrVersionConflict).Once()
+			},
+		},
+		{
+			Name:     "unconditional delete",
+			Method:   http.MethodDelete,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("DeleteByTaskID", mock.Anything, "task1", 0, sampleActor).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			var body *bytes.Buffer
+			if tt.Method == http.MethodPut {
+				body = bytes.NewBuffer(payload)
+			} else {
+				body = bytes.NewBuffer(nil)
+			}
+			req, _ := http.NewRequest(tt.Method, "/tasks/task1", body)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.IfMatch != "" {
+				req.Header.Set("If-Match", tt.IfMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			require.Equal(s.T(), tt.ETag, resp.Header().Get("ETag"))
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
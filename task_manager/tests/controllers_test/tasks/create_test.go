@@ -20,6 +20,7 @@ func (s *SuiteTaskUsecase) TestCreateTask() {
 		{
 			Name: "passing test",
 			Payload: TaskRequest{
+				ProjectID:   "project1",
 				Title:       "new task",
 				Description: "not mandatory",
 				DueDate:     time.Now().Add(24 * time.Hour),
@@ -28,8 +29,8 @@ func (s *SuiteTaskUsecase) TestCreateTask() {
 			Expected: http.StatusCreated,
 			MockSetup: func() {
 				s.mockUsecase.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
-					return t.Title == "new task"
-				})).Return(nil).Once()
+					return t.Title == "new task" && t.OwnerID == sampleUser.ID && t.ProjectID == "project1"
+				}), sampleActor).Return(nil).Once()
 			},
 		},
 		{
@@ -42,6 +43,112 @@ func (s *SuiteTaskUsecase) TestCreateTask() {
 			},
 			Expected: http.StatusBadRequest,
 		},
+		{
+			Name: "recurring task",
+			Payload: TaskRequest{
+				Title:   "weekly review",
+				DueDate: time.Now().Add(24 * time.Hour),
+				Status:  "pending",
+				RRule:   "FREQ=WEEKLY;BYDAY=FR",
+			},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+					return t.Recurrence == "FREQ=WEEKLY;BYDAY=FR"
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name: "priority is forwarded",
+			Payload: TaskRequest{
+				Title:    "fix outage",
+				DueDate:  time.Now().Add(24 * time.Hour),
+				Status:   "pending",
+				Priority: "critical",
+			},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+					return t.Priority == domain.PriorityCritical
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name: "unknown priority",
+			Payload: TaskRequest{
+				Title:    "fix outage",
+				DueDate:  time.Now().Add(24 * time.Hour),
+				Status:   "pending",
+				Priority: "asap",
+			},
+			Expected: http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrInvalidPriority).Once()
+			},
+		},
+		{
+			Name: "missing project",
+			Payload: TaskRequest{
+				Title:   "new task",
+				DueDate: time.Now().Add(24 * time.Hour),
+				Status:  "pending",
+			},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrProjectRequired).Once()
+			},
+		},
+		{
+			Name: "project of another team",
+			Payload: TaskRequest{
+				ProjectID: "project2",
+				Title:     "new task",
+				DueDate:   time.Now().Add(24 * time.Hour),
+				Status:    "pending",
+			},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrProjectNotFound).Once()
+			},
+		},
+		{
+			Name: "viewer of the project",
+			Payload: TaskRequest{
+				ProjectID: "project1",
+				Title:     "new task",
+				DueDate:   time.Now().Add(24 * time.Hour),
+				Status:    "pending",
+			},
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrProjectReadOnly).Once()
+			},
+		},
+		{
+			Name: "not pending",
+			Payload: TaskRequest{
+				Title:   "fix outage",
+				DueDate: time.Now().Add(24 * time.Hour),
+				Status:  "completed",
+			},
+			Expected: http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrInvalidStatusTransition).Once()
+			},
+		},
+		{
+			Name: "invalid recurrence",
+			Payload: TaskRequest{
+				Title:   "weekly review",
+				DueDate: time.Now().Add(24 * time.Hour),
+				Status:  "pending",
+				RRule:   "FREQ=HOURLY",
+			},
+			Expected: http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrInvalidRecurrence).Once()
+			},
+		},
 	}
 
 	for _, tt := range tests {
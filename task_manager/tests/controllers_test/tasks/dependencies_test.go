@@ -0,0 +1,151 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddDependency is used to test AddDependency controller
+func (s *SuiteTaskUsecase) TestAddDependency() {
+	tests := []struct {
+		Name      string
+		Body      any
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "dependency added",
+			Body:     map[string]string{"blocker_id": "task2"},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("AddDependency", mock.Anything, "task1", "task2", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing blocker id",
+			Body:     map[string]string{},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "blocker does not exist",
+			Body:     map[string]string{"blocker_id": "ghost"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("AddDependency", mock.Anything, "task1", "ghost", sampleActor).Return(domain.ErrTaskNotFound).Once()
+			},
+		},
+		{
+			Name:     "dependency cycle",
+			Body:     map[string]string{"blocker_id": "task2"},
+			Expected: http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("AddDependency", mock.Anything, "task1", "task2", sampleActor).Return(domain.ErrDependencyCycle).Once()
+			},
+		},
+		{
+			Name:     "already blocked",
+			Body:     map[string]string{"blocker_id": "task2"},
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("AddDependency", mock.Anything, "task1", "task2", sampleActor).Return(domain.ErrDependencyExists).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/dependencies", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestRemoveDependency is used to test RemoveDependency controller
+func (s *SuiteTaskUsecase) TestRemoveDependency() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "dependency removed",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveDependency", mock.Anything, "task1", "task2", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "not blocked",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveDependency", mock.Anything, "task1", "task2", sampleActor).Return(domain.ErrDependencyNotFound).Once()
+			},
+		},
+		{
+			Name:     "invalid task id",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveDependency", mock.Anything, "task1", "task2", sampleActor).Return(domain.ErrInvalidTaskID).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tasks/task1/dependencies/task2", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestCompleteBlockedTask checks that the open blockers are listed when completion is refused
+func (s *SuiteTaskUsecase) TestCompleteBlockedTask() {
+	blocker := domain.Task{ID: "task2", Title: "Second Task", Status: domain.StatusPending}
+	s.PrepareTest(TaskListTestCase{
+		MockSetup: func() {
+			s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.Anything, sampleActor).
+				Return(domain.Task{}, &domain.BlockedError{Blockers: []domain.Task{blocker}}).Once()
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodPatch, "/tasks/task1", bytes.NewBufferString(`{"status": "completed"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp := httptest.NewRecorder()
+
+	s.router.ServeHTTP(resp, req)
+
+	require.Equal(s.T(), http.StatusConflict, resp.Code)
+
+	var body struct {
+		Error     string `json:"error"`
+		BlockedBy []struct {
+			ID     string `json:"id"`
+			Title  string `json:"title"`
+			Status string `json:"status"`
+		} `json:"blocked_by"`
+	}
+	require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(s.T(), body.BlockedBy, 1)
+	require.Equal(s.T(), "task2", body.BlockedBy[0].ID)
+	require.Equal(s.T(), "Second Task", body.BlockedBy[0].Title)
+	require.Equal(s.T(), "pending", body.BlockedBy[0].Status)
+	s.mockUsecase.AssertExpectations(s.T())
+}
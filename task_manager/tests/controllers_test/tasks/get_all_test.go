@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	domain "github.com/A2SVTask7/Domain"
 	"github.com/stretchr/testify/mock"
@@ -15,38 +16,154 @@ import (
 func (s *SuiteTaskUsecase) TestGetTask() {
 	// expected returning value
 	expectedTasks := sampleDatas
-	// a list of tests
-	tests := []TaskListTestCase{
+	expectedPage := domain.TaskPage{Tasks: expectedTasks, Total: 42, Limit: 20}
+	dueAfter := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
 
+	// a list of tests
+	tests := []struct {
+		TaskListTestCase
+		Query      string
+		Total      int
+		NextCursor string
+	}{
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "Successfully fetch tasks",
+				MockSetup: func() {
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, domain.TaskQuery{}, sampleActor).Return(expectedPage, nil).Once()
+				},
+				Expected: http.StatusOK,
+				ValidateSlice: func(body []domain.Task) {
+					require.Equal(s.T(), len(expectedTasks), len(body))
+					require.Equal(s.T(), expectedTasks[0].Title, body[0].Title)
+					require.Equal(s.T(), expectedTasks[1].Status, body[1].Status)
+				},
+			},
+			Total: 42,
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "Query parameters are forwarded",
+				MockSetup: func() {
+					query := domain.TaskQuery{
+						Status:   "pending",
+						DueAfter: dueAfter,
+						Title:    "report",
+						SortBy:   "due_date",
+						SortDesc: true,
+						Limit:    5,
+						Offset:   10,
+					}
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, query, sampleActor).Return(expectedPage, nil).Once()
+				},
+				Expected:      http.StatusOK,
+				ValidateSlice: func(body []domain.Task) { require.Len(s.T(), body, len(expectedTasks)) },
+			},
+			Query: "?status=pending&due_after=2030-01-01T00:00:00Z&title=report&sort=due_date&order=desc&limit=5&offset=10",
+			Total: 42,
+		},
 		{
-			Name: "Successfully fetch tasks",
-			MockSetup: func() {
-				s.mockUsecase.On("FetchAllTasks", mock.Anything).Return(expectedTasks, nil)
+			TaskListTestCase: TaskListTestCase{
+				Name: "Tag filters are forwarded",
+				MockSetup: func() {
+					query := domain.TaskQuery{Tags: []string{"work", "urgent"}, AnyTags: []string{"home"}}
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, query, sampleActor).Return(expectedPage, nil).Once()
+				},
+				Expected:      http.StatusOK,
+				ValidateSlice: func(body []domain.Task) { require.Len(s.T(), body, len(expectedTasks)) },
 			},
-			Expected: http.StatusOK,
-			ValidateSlice: func(body []domain.Task) {
-				require.Equal(s.T(), len(expectedTasks), len(body))
-				require.Equal(s.T(), expectedTasks[0].Title, body[0].Title)
-				require.Equal(s.T(), expectedTasks[1].Status, body[1].Status)
+			Query: "?tag=work&tag=urgent&any_tag=home",
+			Total: 42,
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "Project filter is forwarded",
+				MockSetup: func() {
+					query := domain.TaskQuery{ProjectID: "project1"}
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, query, sampleActor).Return(expectedPage, nil).Once()
+				},
+				Expected:      http.StatusOK,
+				ValidateSlice: func(body []domain.Task) { require.Len(s.T(), body, len(expectedTasks)) },
 			},
+			Query: "?project=project1",
+			Total: 42,
 		},
 		{
-			Name: "internal server error",
-			MockSetup: func() {
-				s.mockUsecase.On("FetchAllTasks", mock.Anything).Return([]domain.Task{}, fmt.Errorf("db error")).Once()
+			TaskListTestCase: TaskListTestCase{
+				Name: "Project of another team",
+				MockSetup: func() {
+					query := domain.TaskQuery{ProjectID: "project2"}
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, query, sampleActor).Return(domain.TaskPage{}, domain.ErrProjectNotFound).Once()
+				},
+				Expected:      http.StatusNotFound,
+				ValidateSlice: func(body []domain.Task) { require.Len(s.T(), body, 0) },
 			},
-			Expected: http.StatusInternalServerError,
-			ValidateSlice: func(body []domain.Task) {
-				require.Equal(s.T(), 0, len(body))
+			Query: "?project=project2",
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "Cursor is forwarded",
+				MockSetup: func() {
+					page := domain.TaskPage{Tasks: expectedTasks[:1], Total: 42, Limit: 1, NextCursor: "next"}
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, domain.TaskQuery{Limit: 1, Cursor: "abc.def"}, sampleActor).Return(page, nil).Once()
+				},
+				Expected:      http.StatusOK,
+				ValidateSlice: func(body []domain.Task) { require.Len(s.T(), body, 1) },
+			},
+			Query:      "?limit=1&cursor=abc.def",
+			Total:      42,
+			NextCursor: "next",
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "Invalid cursor",
+				MockSetup: func() {
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, domain.TaskQuery{Cursor: "forged"}, sampleActor).Return(domain.TaskPage{}, domain.ErrInvalidCursor).Once()
+				},
+				Expected:      http.StatusBadRequest,
+				ValidateSlice: func(body []domain.Task) { require.Empty(s.T(), body) },
+			},
+			Query: "?cursor=forged",
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name:          "Invalid order",
+				Expected:      http.StatusBadRequest,
+				ValidateSlice: func(body []domain.Task) { require.Empty(s.T(), body) },
+			},
+			Query: "?order=sideways",
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "Invalid query rejected by usecase",
+				MockSetup: func() {
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, domain.TaskQuery{SortBy: "owner"}, sampleActor).
+						Return(domain.TaskPage{}, fmt.Errorf("%w: bad sort", domain.ErrInvalidQuery)).Once()
+				},
+				Expected:      http.StatusBadRequest,
+				ValidateSlice: func(body []domain.Task) { require.Empty(s.T(), body) },
+			},
+			Query: "?sort=owner",
+		},
+		{
+			TaskListTestCase: TaskListTestCase{
+				Name: "internal server error",
+				MockSetup: func() {
+					s.mockUsecase.On("FetchAllTasks", mock.Anything, domain.TaskQuery{}, sampleActor).Return(domain.TaskPage{}, fmt.Errorf("db error")).Once()
+				},
+				Expected: http.StatusInternalServerError,
+				ValidateSlice: func(body []domain.Task) {
+					require.Equal(s.T(), 0, len(body))
+				},
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.Name, func() {
-			s.PrepareTest(tt)
+			s.PrepareTest(tt.TaskListTestCase)
 			// create request and response
-			req, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
+			req, _ := http.NewRequest(http.MethodGet, "/tasks"+tt.Query, nil)
 			resp := httptest.NewRecorder()
 
 			s.router.ServeHTTP(resp, req)
@@ -54,15 +171,19 @@ func (s *SuiteTaskUsecase) TestGetTask() {
 			// check for valid responses
 			require.Equal(s.T(), tt.Expected, resp.Code)
 
-			var responseBody []domain.Task
+			var responseBody struct {
+				Data       []domain.Task `json:"data"`
+				Total      int           `json:"total"`
+				NextCursor string        `json:"next_cursor"`
+			}
 			if resp.Code == http.StatusOK {
 				err := json.Unmarshal(resp.Body.Bytes(), &responseBody)
 				require.NoError(s.T(), err, "Failed to unmarshal response body")
+				require.Equal(s.T(), tt.Total, responseBody.Total)
+				require.Equal(s.T(), tt.NextCursor, responseBody.NextCursor)
 			}
-			tt.ValidateSlice(responseBody)
-
+			tt.ValidateSlice(responseBody.Data)
+			s.mockUsecase.AssertExpectations(s.T())
 		})
 	}
-	s.mockUsecase.AssertExpectations(s.T())
-
 }
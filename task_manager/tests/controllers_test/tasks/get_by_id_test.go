@@ -28,7 +28,7 @@ func (s *SuiteTaskUsecase) TestGetByTaskID() {
 				ID: "1234",
 			},
 			Expected:  http.StatusOK,
-			MockSetup: func() { s.mockUsecase.On("FetchByTaskID", mock.Anything, "1234").Return(expected, nil) },
+			MockSetup: func() { s.mockUsecase.On("FetchByTaskID", mock.Anything, "1234", sampleActor).Return(expected, nil) },
 			Validate: func(t domain.Task) {
 				require.Equal(s.T(), expected.ID, t.ID)
 				require.Equal(s.T(), expected.Title, t.Title)
@@ -57,7 +57,7 @@ func (s *SuiteTaskUsecase) TestGetByTaskID() {
 			},
 			Expected: http.StatusNotFound,
 			MockSetup: func() {
-				s.mockUsecase.On("FetchByTaskID", mock.Anything, "9999").
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, "9999", sampleActor).
 					Return(domain.Task{}, domain.ErrTaskNotFound).Once()
 			},
 			Validate: func(t domain.Task) {
@@ -73,7 +73,7 @@ func (s *SuiteTaskUsecase) TestGetByTaskID() {
 			},
 			Expected: http.StatusInternalServerError,
 			MockSetup: func() {
-				s.mockUsecase.On("FetchByTaskID", mock.Anything, "1234").
+				s.mockUsecase.On("FetchByTaskID", mock.Anything, "1234", sampleActor).
 					Return(domain.Task{}, errors.New("db error")).Once()
 			},
 			Validate: func(t domain.Task) {
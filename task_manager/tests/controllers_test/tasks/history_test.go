@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetTaskHistory is used to test GetTaskHistory controller
+func (s *SuiteTaskUsecase) TestGetTaskHistory() {
+	history := []domain.TaskHistoryEntry{
+		{
+			ID:        "entry1",
+			TaskID:    "task1",
+			ActorID:   "admin1",
+			Action:    domain.TaskActionUpdate,
+			Timestamp: time.Now(),
+			Changes:   []domain.FieldChange{{Field: "status", Before: "pending", After: "completed"}},
+		},
+	}
+
+	tests := []struct {
+		Name      string
+		Expected  int
+		Entries   int
+		MockSetup func()
+	}{
+		{
+			Name:     "history listed",
+			Expected: http.StatusOK,
+			Entries:  1,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchHistory", mock.Anything, "task1", sampleActor).Return(history, nil).Once()
+			},
+		},
+		{
+			Name:     "task not visible",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchHistory", mock.Anything, "task1", sampleActor).
+					Return([]domain.TaskHistoryEntry(nil), domain.ErrTaskNotFound).Once()
+			},
+		},
+		{
+			Name:     "invalid task id",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchHistory", mock.Anything, "task1", sampleActor).
+					Return([]domain.TaskHistoryEntry(nil), domain.ErrInvalidTaskID).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/history", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			if tt.Expected == http.StatusOK {
+				var body struct {
+					Data []domain.TaskHistoryEntry `json:"data"`
+				}
+				require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+				require.Len(s.T(), body.Data, tt.Entries)
+				require.Equal(s.T(), "status", body.Data[0].Changes[0].Field)
+			}
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPatchTask is used to test PatchTask controller
+func (s *SuiteTaskUsecase) TestPatchTask() {
+	patched := sampleDatas[0]
+	patched.Status = "completed"
+	patched.Description = ""
+
+	tests := []struct {
+		Name        string
+		Body        string
+		ContentType string
+		Expected    int
+		MockSetup   func()
+	}{
+		{
+			Name:        "only supplied fields are patched",
+			Body:        `{"status": "completed", "description": null}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.MatchedBy(func(p domain.TaskPatch) bool {
+					return p.Title == nil && p.DueDate == nil &&
+						p.Status != nil && *p.Status == "completed" &&
+						p.Description != nil && *p.Description == ""
+				}), sampleActor).Return(patched, nil).Once()
+			},
+		},
+		{
+			Name:        "plain json is accepted",
+			Body:        `{"title": "renamed"}`,
+			ContentType: "application/json",
+			Expected:    http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.MatchedBy(func(p domain.TaskPatch) bool {
+					return p.Title != nil && *p.Title == "renamed"
+				}), sampleActor).Return(patched, nil).Once()
+			},
+		},
+		{
+			Name:        "priority is patched",
+			Body:        `{"priority": "high"}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.MatchedBy(func(p domain.TaskPatch) bool {
+					return p.Priority != nil && *p.Priority == domain.PriorityHigh && p.Status == nil
+				}), sampleActor).Return(patched, nil).Once()
+			},
+		},
+		{
+			Name:        "priority can not be removed",
+			Body:        `{"priority": null}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusBadRequest,
+		},
+		{
+			Name:        "required field can not be removed",
+			Body:        `{"title": null}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusBadRequest,
+		},
+		{
+			Name:        "unknown field",
+			Body:        `{"owner": "me"}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusBadRequest,
+		},
+		{
+			Name:        "body is not an object",
+			Body:        `["status"]`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusBadRequest,
+		},
+		{
+			Name:        "unsupported media type",
+			Body:        `status=completed`,
+			ContentType: "application/x-www-form-urlencoded",
+			Expected:    http.StatusUnsupportedMediaType,
+		},
+		{
+			Name:        "unknown status",
+			Body:        `{"status": "archived"}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Task{}, domain.ErrInvalidStatus).Once()
+			},
+		},
+		{
+			Name:        "forbidden status transition",
+			Body:        `{"status": "missed"}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Task{}, domain.ErrInvalidStatusTransition).Once()
+			},
+		},
+		{
+			Name:        "task not found",
+			Body:        `{"status": "completed"}`,
+			ContentType: "application/merge-patch+json",
+			Expected:    http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("PatchByTaskID", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.Task{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(http.MethodPatch, "/tasks/task1", bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", tt.ContentType)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			if resp.Code == http.StatusOK {
+				var body domain.Task
+				require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+				require.Equal(s.T(), patched.Status, body.Status)
+			}
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetRecurrence is used to test SetRecurrence controller
+func (s *SuiteTaskUsecase) TestSetRecurrence() {
+	recurring := sampleDatas[0]
+	recurring.Recurrence = "FREQ=DAILY"
+
+	tests := []struct {
+		Name      string
+		Body      string
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "recurrence set",
+			Body:     `{"rrule": "FREQ=DAILY"}`,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("SetRecurrence", mock.Anything, "task1", "FREQ=DAILY", sampleActor).Return(recurring, nil).Once()
+			},
+		},
+		{
+			Name:     "missing rule",
+			Body:     `{}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "invalid rule",
+			Body:     `{"rrule": "FREQ=HOURLY"}`,
+			Expected: http.StatusUnprocessableEntity,
+			MockSetup: func() {
+				s.mockUsecase.On("SetRecurrence", mock.Anything, "task1", "FREQ=HOURLY", sampleActor).Return(domain.Task{}, domain.ErrInvalidRecurrence).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Body:     `{"rrule": "FREQ=DAILY"}`,
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("SetRecurrence", mock.Anything, "task1", "FREQ=DAILY", sampleActor).Return(domain.Task{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(http.MethodPut, "/tasks/task1/recurrence", bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestStopRecurrence is used to test StopRecurrence controller
+func (s *SuiteTaskUsecase) TestStopRecurrence() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "recurrence stopped",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("StopRecurrence", mock.Anything, "task1", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "task does not recur",
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("StopRecurrence", mock.Anything, "task1", sampleActor).Return(domain.ErrNotRecurring).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("StopRecurrence", mock.Anything, "task1", sampleActor).Return(domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tasks/task1/recurrence", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
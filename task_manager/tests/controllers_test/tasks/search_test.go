@@ -0,0 +1,73 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchTasks is used to test SearchTasks controller
+func (s *SuiteTaskUsecase) TestSearchTasks() {
+	results := []domain.TaskSearchResult{
+		{
+			Task:       sampleDatas[0],
+			Score:      1.5,
+			Highlights: map[string]string{"title": "<mark>First</mark> Task"},
+		},
+	}
+
+	tests := []struct {
+		Name      string
+		Query     string
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "results are returned",
+			Query:    "?q=first&limit=5",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("Search", mock.Anything, domain.TaskSearchQuery{Text: "first", Limit: 5}, sampleActor).Return(results, nil).Once()
+			},
+		},
+		{
+			Name:     "missing search text",
+			Query:    "",
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "search fails",
+			Query:    "?q=first",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("Search", mock.Anything, domain.TaskSearchQuery{Text: "first"}, sampleActor).Return([]domain.TaskSearchResult{}, errors.New("no text index")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/search"+tt.Query, nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			if resp.Code == http.StatusOK {
+				var body struct {
+					Data []domain.TaskSearchResult `json:"data"`
+				}
+				require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+				require.Len(s.T(), body.Data, 1)
+				require.Equal(s.T(), results[0].Highlights, body.Data[0].Highlights)
+			}
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetSubtasks is used to test GetSubtasks controller
+func (s *SuiteTaskUsecase) TestGetSubtasks() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "subtasks listed",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchSubtasks", mock.Anything, "task1", sampleActor).Return(sampleDatas, nil).Once()
+			},
+		},
+		{
+			Name:     "parent not found",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchSubtasks", mock.Anything, "task1", sampleActor).Return([]domain.Task{}, domain.ErrTaskNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/tasks/task1/subtasks", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestCreateSubtask is used to test CreateSubtask controller
+func (s *SuiteTaskUsecase) TestCreateSubtask() {
+	tests := []struct {
+		Name      string
+		Body      string
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "subtask created",
+			Body:     `{"title": "step", "due_date": "2999-01-01T00:00:00Z", "status": "pending"}`,
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("CreateSubtask", mock.Anything, "task1", mock.MatchedBy(func(t *domain.Task) bool {
+					return t.Title == "step" && t.OwnerID == sampleUser.ID
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing title",
+			Body:     `{"due_date": "2999-01-01T00:00:00Z", "status": "pending"}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "parent not found",
+			Body:     `{"title": "step", "due_date": "2999-01-01T00:00:00Z", "status": "pending"}`,
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("CreateSubtask", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.ErrTaskNotFound).Once()
+			},
+		},
+		{
+			Name:     "viewer of the project",
+			Body:     `{"title": "step", "due_date": "2999-01-01T00:00:00Z", "status": "pending"}`,
+			Expected: http.StatusForbidden,
+			MockSetup: func() {
+				s.mockUsecase.On("CreateSubtask", mock.Anything, "task1", mock.Anything, sampleActor).Return(domain.ErrProjectReadOnly).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/subtasks", bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestReorderSubtasks is used to test ReorderSubtasks controller
+func (s *SuiteTaskUsecase) TestReorderSubtasks() {
+	tests := []struct {
+		Name      string
+		Body      string
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "subtasks reordered",
+			Body:     `{"ids": ["sub2", "sub1"]}`,
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("ReorderSubtasks", mock.Anything, "task1", []string{"sub2", "sub1"}, sampleActor).Return(sampleDatas, nil).Once()
+			},
+		},
+		{
+			Name:     "missing ids",
+			Body:     `{}`,
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "incomplete order",
+			Body:     `{"ids": ["sub2"]}`,
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("ReorderSubtasks", mock.Anything, "task1", []string{"sub2"}, sampleActor).Return([]domain.Task{}, domain.ErrInvalidOrder).Once()
+			},
+		},
+		{
+			Name:     "concurrent change",
+			Body:     `{"ids": ["sub2", "sub1"]}`,
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("ReorderSubtasks", mock.Anything, "task1", mock.Anything, sampleActor).Return([]domain.Task{}, domain.ErrVersionConflict).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			req, _ := http.NewRequest(http.MethodPut, "/tasks/task1/subtasks/order", bytes.NewBufferString(tt.Body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -21,12 +21,40 @@ func (s *SuiteTaskUsecase) SetupTest() {
 	s.mockUsecase = new(mock.MockTaskUsecase)
 	s.router = gin.Default()
 	s.router.RedirectTrailingSlash = false
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
 
 	taskController := controllers.TaskController{TaskUsecase: s.mockUsecase}
 	s.router.POST("/tasks", taskController.CreateTask)
 	s.router.GET("/tasks", taskController.GetAllTasks)
+	s.router.GET("/tasks/search", taskController.SearchTasks)
 	s.router.GET("/tasks/:id", taskController.GetTaskByID)
+	s.router.GET("/tasks/:id/history", taskController.GetTaskHistory)
+	s.router.GET("/tasks/:id/subtasks", taskController.GetSubtasks)
+	s.router.POST("/tasks/:id/subtasks", taskController.CreateSubtask)
+	s.router.PUT("/tasks/:id/subtasks/order", taskController.ReorderSubtasks)
+	s.router.POST("/tasks/:id/checklist", taskController.AddChecklistItem)
+	s.router.PATCH("/tasks/:id/checklist/:itemID", taskController.UpdateChecklistItem)
+	s.router.DELETE("/tasks/:id/checklist/:itemID", taskController.RemoveChecklistItem)
+	s.router.PUT("/tasks/:id/checklist/order", taskController.ReorderChecklist)
 	s.router.PUT("/tasks/:id", taskController.UpdateTask)
+	s.router.PATCH("/tasks/:id", taskController.PatchTask)
+	s.router.DELETE("/tasks/:id", taskController.DeleteTask)
+	s.router.POST("/tasks/:id/assignees", taskController.AssignUser)
+	s.router.DELETE("/tasks/:id/assignees/:userID", taskController.UnassignUser)
+	s.router.PUT("/tasks/:id/recurrence", taskController.SetRecurrence)
+	s.router.DELETE("/tasks/:id/recurrence", taskController.StopRecurrence)
+	s.router.POST("/tasks/:id/dependencies", taskController.AddDependency)
+	s.router.DELETE("/tasks/:id/dependencies/:blockerID", taskController.RemoveDependency)
+	s.router.POST("/tasks/:id/tags", taskController.AddTag)
+	s.router.DELETE("/tasks/:id/tags/:tag", taskController.RemoveTag)
+	s.router.GET("/tags", taskController.GetTags)
+	s.router.GET("/me/tasks", taskController.GetMyTasks)
+	s.router.GET("/trash", taskController.GetTrash)
+	s.router.POST("/trash/:id/restore", taskController.RestoreTask)
+	s.router.DELETE("/trash", taskController.PurgeTrash)
 }
 
 func (s *SuiteTaskUsecase) PrepareTest(tt TaskListTestCase) {
@@ -0,0 +1,155 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetTags is used to test GetTags controller
+func (s *SuiteTaskUsecase) TestGetTags() {
+	s.Run("tags with counts", func() {
+		s.PrepareTest(TaskListTestCase{
+			MockSetup: func() {
+				counts := []domain.TagCount{{Tag: "work", Count: 3}, {Tag: "home", Count: 1}}
+				s.mockUsecase.On("FetchTags", mock.Anything, sampleActor).Return(counts, nil).Once()
+			},
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/tags", nil)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusOK, resp.Code)
+
+		var body struct {
+			Data []struct {
+				Tag   string `json:"tag"`
+				Count int    `json:"count"`
+			} `json:"data"`
+		}
+		require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+		require.Len(s.T(), body.Data, 2)
+		require.Equal(s.T(), "work", body.Data[0].Tag)
+		require.Equal(s.T(), 3, body.Data[0].Count)
+		s.mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("usecase failure", func() {
+		s.PrepareTest(TaskListTestCase{
+			MockSetup: func() {
+				s.mockUsecase.On("FetchTags", mock.Anything, sampleActor).Return([]domain.TagCount{}, fmt.Errorf("db error")).Once()
+			},
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/tags", nil)
+		resp := httptest.NewRecorder()
+		s.router.ServeHTTP(resp, req)
+
+		require.Equal(s.T(), http.StatusInternalServerError, resp.Code)
+	})
+}
+
+// TestAddTag is used to test AddTag controller
+func (s *SuiteTaskUsecase) TestAddTag() {
+	tests := []struct {
+		Name      string
+		Body      any
+		Expected  int
+		MockSetup func()
+	}{
+		{
+			Name:     "tag added",
+			Body:     map[string]string{"tag": "work"},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("AddTag", mock.Anything, "task1", "work", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "missing tag",
+			Body:     map[string]string{},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "invalid tag",
+			Body:     map[string]string{"tag": "   "},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("AddTag", mock.Anything, "task1", "   ", sampleActor).Return(domain.ErrInvalidTag).Once()
+			},
+		},
+		{
+			Name:     "task not found",
+			Body:     map[string]string{"tag": "work"},
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("AddTag", mock.Anything, "task1", "work", sampleActor).Return(domain.ErrTaskNotFound).Once()
+			},
+		},
+		{
+			Name:     "already tagged",
+			Body:     map[string]string{"tag": "work"},
+			Expected: http.StatusConflict,
+			MockSetup: func() {
+				s.mockUsecase.On("AddTag", mock.Anything, "task1", "work", sampleActor).Return(domain.ErrTagExists).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(TaskListTestCase{MockSetup: tt.MockSetup})
+
+			body, _ := json.Marshal(tt.Body)
+			req, _ := http.NewRequest(http.MethodPost, "/tasks/task1/tags", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestRemoveTag is used to test RemoveTag controller
+func (s *SuiteTaskUsecase) TestRemoveTag() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "tag removed",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveTag", mock.Anything, "task1", "work", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "tag not on task",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("RemoveTag", mock.Anything, "task1", "work", sampleActor).Return(domain.ErrTagNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/tasks/task1/tags/work", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
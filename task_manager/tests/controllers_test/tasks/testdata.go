@@ -5,15 +5,29 @@ import (
 	"time"
 
 	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
 )
 
+// authenticated user injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "user1",
+	Username: "tester",
+	IsAdmin:  false,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
 // request struct
 type TaskRequest struct {
 	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id,omitempty"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	DueDate     time.Time `json:"due_date"`
 	Status      string    `json:"status"`
+	Priority    string    `json:"priority,omitempty"`
+	RRule       string    `json:"rrule,omitempty"`
 }
 
 type TaskListTestCase struct {
@@ -33,6 +47,7 @@ var sampleDatas = []domain.Task{
 		Description: "Do something",
 		DueDate:     time.Now().Add(24 * time.Hour),
 		Status:      "pending",
+		OwnerID:     "user1",
 	},
 	{
 		ID:          "task2",
@@ -40,5 +55,6 @@ var sampleDatas = []domain.Task{
 		Description: "Do something else",
 		DueDate:     time.Now().Add(48 * time.Hour),
 		Status:      "done",
+		OwnerID:     "user1",
 	},
 }
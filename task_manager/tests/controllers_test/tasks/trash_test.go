@@ -0,0 +1,120 @@
+package tasks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetTrash is used to test GetTrash controller
+func (s *SuiteTaskUsecase) TestGetTrash() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "trash listed",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchTrash", mock.Anything).Return(sampleDatas, nil).Once()
+			},
+		},
+		{
+			Name:     "repository failure",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchTrash", mock.Anything).Return([]domain.Task{}, errors.New("db down")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, "/trash", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestRestoreTask is used to test RestoreTask controller
+func (s *SuiteTaskUsecase) TestRestoreTask() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "task restored",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("RestoreByTaskID", mock.Anything, "task1", sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "task not in trash",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("RestoreByTaskID", mock.Anything, "task1", sampleActor).Return(domain.ErrTaskNotFound).Once()
+			},
+		},
+		{
+			Name:     "invalid task id",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("RestoreByTaskID", mock.Anything, "task1", sampleActor).Return(domain.ErrInvalidTaskID).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodPost, "/trash/task1/restore", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestPurgeTrash is used to test PurgeTrash controller
+func (s *SuiteTaskUsecase) TestPurgeTrash() {
+	tests := []TaskListTestCase{
+		{
+			Name:     "trash purged",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("PurgeTrash", mock.Anything).Return(2, nil).Once()
+			},
+		},
+		{
+			Name:     "repository failure",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("PurgeTrash", mock.Anything).Return(0, errors.New("db down")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/trash", nil)
+			resp := httptest.NewRecorder()
+
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -19,7 +19,7 @@ func (s *SuiteTaskUsecase) TestUpdateTask() {
 		ID:          sampleDatas[0].ID,
 		Title:       sampleDatas[0].Title,
 		Description: sampleDatas[0].Description,
-		Status:      sampleDatas[0].Status,
+		Status:      string(sampleDatas[0].Status),
 		DueDate:     sampleDatas[0].DueDate,
 	}
 	modified := original
@@ -34,14 +34,14 @@ func (s *SuiteTaskUsecase) TestUpdateTask() {
 				require.Equal(s.T(), modified.ID, t.ID)
 				require.Equal(s.T(), modified.Title, t.Title)
 				require.Equal(s.T(), modified.Description, t.Description)
-				require.Equal(s.T(), modified.Status, t.Status)
+				require.Equal(s.T(), modified.Status, string(t.Status))
 				require.WithinDuration(s.T(), modified.DueDate, t.DueDate, 1*time.Second)
 			},
 			MockSetup: func() {
 				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
 					t.Status = "completed"
 					return true
-				})).Return(nil).Once()
+				}), sampleActor).Return(nil).Once()
 			},
 		},
 		{
@@ -50,7 +50,7 @@ func (s *SuiteTaskUsecase) TestUpdateTask() {
 			Expected: http.StatusBadRequest,
 			Validate: func(t domain.Task) {},
 			MockSetup: func() {
-				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything).
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
 					Return(domain.ErrInvalidTaskID).Once()
 			},
 		},
@@ -66,17 +66,57 @@ func (s *SuiteTaskUsecase) TestUpdateTask() {
 			Expected: http.StatusBadRequest,
 			Validate: func(t domain.Task) {},
 			MockSetup: func() {
-				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything).
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
 					Return(domain.ErrInvalidDueDate).Once()
 			},
 		},
+		{
+			Name:     "Unknown status",
+			Payload:  original,
+			Expected: http.StatusUnprocessableEntity,
+			Validate: func(t domain.Task) {},
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
+					Return(domain.ErrInvalidStatus).Once()
+			},
+		},
+		{
+			Name:     "Forbidden status transition",
+			Payload:  original,
+			Expected: http.StatusConflict,
+			Validate: func(t domain.Task) {},
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
+					Return(domain.ErrInvalidStatusTransition).Once()
+			},
+		},
+		{
+			Name:     "Blocked by open task",
+			Payload:  original,
+			Expected: http.StatusConflict,
+			Validate: func(t domain.Task) {},
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
+					Return(&domain.BlockedError{Blockers: sampleDatas[:1]}).Once()
+			},
+		},
+		{
+			Name:     "Viewer of the project",
+			Payload:  original,
+			Expected: http.StatusForbidden,
+			Validate: func(t domain.Task) {},
+			MockSetup: func() {
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
+					Return(domain.ErrProjectReadOnly).Once()
+			},
+		},
 		{
 			Name:     "Task not found",
 			Payload:  original,
 			Expected: http.StatusBadRequest,
 			Validate: func(t domain.Task) {},
 			MockSetup: func() {
-				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything).
+				s.mockUsecase.On("UpdateByTaskID", mock.Anything, mock.Anything, sampleActor).
 					Return(domain.ErrTaskNotFound).Once()
 			},
 		},
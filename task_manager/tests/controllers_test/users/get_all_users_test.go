@@ -24,14 +24,34 @@ func (s *SuiteUserUsecase) TestGetAllUsers() {
 			},
 			Expected: http.StatusOK,
 			MockSetup: func() {
-				s.mockUsecase.On("FetchAllUsers", mock.Anything).Return(expected, nil)
+				s.mockUsecase.On("FetchAllUsers", mock.Anything, domain.UserQuery{}).Return(domain.UserPage{Users: expected}, nil)
+			},
+		},
+		{
+			Name:  "Forwards limit and cursor",
+			Query: "?limit=1&cursor=abc.def",
+			ValidateSlice: func(u []domain.User) {
+				s.Equal(1, len(u))
+			},
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				page := domain.UserPage{Users: expected[:1], NextCursor: "next"}
+				s.mockUsecase.On("FetchAllUsers", mock.Anything, domain.UserQuery{Limit: 1, Cursor: "abc.def"}).Return(page, nil)
+			},
+		},
+		{
+			Name:     "Invalid cursor",
+			Query:    "?cursor=forged",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchAllUsers", mock.Anything, domain.UserQuery{Cursor: "forged"}).Return(domain.UserPage{}, domain.ErrInvalidCursor)
 			},
 		},
 		{
 			Name:     "Random Error",
 			Expected: http.StatusInternalServerError,
 			MockSetup: func() {
-				s.mockUsecase.On("FetchAllUsers", mock.Anything).Return(expected, errors.New("random error"))
+				s.mockUsecase.On("FetchAllUsers", mock.Anything, domain.UserQuery{}).Return(domain.UserPage{}, errors.New("random error"))
 			},
 		},
 	}
@@ -40,12 +60,15 @@ func (s *SuiteUserUsecase) TestGetAllUsers() {
 		s.Run(tt.Name, func() {
 			s.PrepareTest(tt)
 
-			req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+			req, _ := http.NewRequest(http.MethodGet, "/users"+tt.Query, nil)
 			resp := httptest.NewRecorder()
 
 			s.router.ServeHTTP(resp, req)
 
-			var responseBody []domain.User
+			var responseBody struct {
+				Data       []domain.User `json:"data"`
+				NextCursor string        `json:"next_cursor"`
+			}
 			if resp.Code == http.StatusOK {
 				err := json.Unmarshal(resp.Body.Bytes(), &responseBody)
 				s.NoError(err)
@@ -53,7 +76,7 @@ func (s *SuiteUserUsecase) TestGetAllUsers() {
 
 			s.Equal(tt.Expected, resp.Code)
 			if tt.ValidateSlice != nil {
-				tt.ValidateSlice(responseBody)
+				tt.ValidateSlice(responseBody.Data)
 			}
 		})
 	}
@@ -20,6 +20,7 @@ type UserListTestCase struct {
 	ValidateSlice func([]domain.User) // validation datas of a slice
 	Validate      func(domain.User)   // validation of a single data
 	Payload       UserRequest         // payload if it is a post request
+	Query         string              // query string appended to the request url
 }
 
 // User represents a user in the system
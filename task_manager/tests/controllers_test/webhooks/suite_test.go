@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/A2SVTask7/Delivery/controllers"
+	mock "github.com/A2SVTask7/tests/controllers_test/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuiteWebhookUsecase struct {
+	suite.Suite
+	router      *gin.Engine
+	mockUsecase *mock.MockWebhookUsecase
+}
+
+func (s *SuiteWebhookUsecase) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	s.mockUsecase = new(mock.MockWebhookUsecase)
+	s.router = gin.Default()
+	s.router.Use(func(c *gin.Context) {
+		c.Set("user", sampleUser)
+		c.Next()
+	})
+
+	webhookController := controllers.WebhookController{WebhookUsecase: s.mockUsecase}
+	s.router.GET("/webhooks", webhookController.GetWebhooks)
+	s.router.POST("/webhooks", webhookController.CreateWebhook)
+	s.router.GET("/webhooks/:id", webhookController.GetWebhook)
+	s.router.PUT("/webhooks/:id", webhookController.UpdateWebhook)
+	s.router.DELETE("/webhooks/:id", webhookController.DeleteWebhook)
+	s.router.GET("/webhooks/:id/deliveries", webhookController.GetWebhookDeliveries)
+	s.router.POST("/webhooks/:id/deliveries/:deliveryID/redeliver", webhookController.RedeliverWebhook)
+}
+
+func (s *SuiteWebhookUsecase) PrepareTest(tt WebhookTestCase) {
+	// Clear previous mock calls and expectations
+	s.mockUsecase.ExpectedCalls = nil
+	s.mockUsecase.Calls = nil
+
+	// If there's a MockSetup function, run it
+	if tt.MockSetup != nil {
+		tt.MockSetup()
+	}
+}
+
+func TestWebhookController(t *testing.T) {
+	suite.Run(t, new(SuiteWebhookUsecase))
+}
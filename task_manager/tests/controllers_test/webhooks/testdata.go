@@ -0,0 +1,51 @@
+package webhooks
+
+// this file contains shared data, and struct within the webhooks test
+
+import (
+	"encoding/json"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+)
+
+// authenticated admin injected into every request
+var sampleUser = infrastructure.AuthenticatedUser{
+	ID:       "admin1",
+	Username: "admin",
+	IsAdmin:  true,
+}
+
+// actor the controller derives from sampleUser
+var sampleActor = domain.Actor{ID: sampleUser.ID, IsAdmin: sampleUser.IsAdmin}
+
+type WebhookTestCase struct {
+	Name      string // name of the test
+	Method    string // method of the request
+	Path      string // path of the request
+	Body      any    // payload if it is a post or put request
+	MockSetup func() // mock setup
+	Expected  int    // expected status
+}
+
+// sample webhook as listed, without its secret
+var sampleWebhook = domain.Webhook{
+	ID:        "webhook1",
+	URL:       "https://ci.example.com/hooks",
+	Events:    []string{domain.WebhookEventTaskCreated},
+	Active:    true,
+	CreatedBy: "admin1",
+	CreatedAt: time.Now(),
+}
+
+// sample delivery of sampleWebhook that failed once
+var sampleDelivery = domain.WebhookDelivery{
+	ID:        "delivery1",
+	WebhookID: "webhook1",
+	EventID:   "event1",
+	Event:     domain.WebhookEventTaskCreated,
+	Payload:   json.RawMessage(`{"id":"event1","event":"task.created"}`),
+	Status:    domain.DeliveryPending,
+	Attempts:  []domain.WebhookAttempt{{At: time.Now(), StatusCode: 503, Error: "webhook responded with status 503"}},
+}
@@ -0,0 +1,270 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebhooks is used to test the controllers managing webhooks
+func (s *SuiteWebhookUsecase) TestWebhooks() {
+	active := false
+	tests := []WebhookTestCase{
+		{
+			Name:     "list",
+			Method:   http.MethodGet,
+			Path:     "/webhooks",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchAll", mock.Anything).Return([]domain.Webhook{sampleWebhook}, nil).Once()
+			},
+		},
+		{
+			Name:     "list fails",
+			Method:   http.MethodGet,
+			Path:     "/webhooks",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchAll", mock.Anything).Return([]domain.Webhook{}, fmt.Errorf("db error")).Once()
+			},
+		},
+		{
+			Name:     "create",
+			Method:   http.MethodPost,
+			Path:     "/webhooks",
+			Body:     map[string]any{"url": "https://ci.example.com/hooks", "events": []string{"task.created"}},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+					return w.URL == "https://ci.example.com/hooks" && len(w.Events) == 1 && w.Active
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "create inactive",
+			Method:   http.MethodPost,
+			Path:     "/webhooks",
+			Body:     map[string]any{"url": "https://ci.example.com/hooks", "events": []string{"task.created"}, "secret": "s3cret", "active": &active},
+			Expected: http.StatusCreated,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+					return w.Secret == "s3cret" && !w.Active
+				}), sampleActor).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "create without events",
+			Method:   http.MethodPost,
+			Path:     "/webhooks",
+			Body:     map[string]any{"url": "https://ci.example.com/hooks"},
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "create with unknown event",
+			Method:   http.MethodPost,
+			Path:     "/webhooks",
+			Body:     map[string]any{"url": "https://ci.example.com/hooks", "events": []string{"task.commented"}},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Create", mock.Anything, mock.Anything, sampleActor).Return(domain.ErrInvalidWebhookEvent).Once()
+			},
+		},
+		{
+			Name:     "get",
+			Method:   http.MethodGet,
+			Path:     "/webhooks/webhook1",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByID", mock.Anything, "webhook1").Return(sampleWebhook, nil).Once()
+			},
+		},
+		{
+			Name:     "get unknown",
+			Method:   http.MethodGet,
+			Path:     "/webhooks/unknown",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchByID", mock.Anything, "unknown").Return(domain.Webhook{}, domain.ErrInvalidWebhookID).Once()
+			},
+		},
+		{
+			Name:     "update",
+			Method:   http.MethodPut,
+			Path:     "/webhooks/webhook1",
+			Body:     map[string]any{"url": "https://ci.example.com/v2/hooks", "events": []string{"task.updated"}, "active": &active},
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+					return w.ID == "webhook1" && w.URL == "https://ci.example.com/v2/hooks" && w.Secret == "" && !w.Active
+				})).Return(nil).Once()
+			},
+		},
+		{
+			Name:     "update with invalid url",
+			Method:   http.MethodPut,
+			Path:     "/webhooks/webhook1",
+			Body:     map[string]any{"url": "ci.example.com", "events": []string{"task.updated"}},
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("Update", mock.Anything, mock.Anything).Return(domain.ErrInvalidWebhookURL).Once()
+			},
+		},
+		{
+			Name:     "delete",
+			Method:   http.MethodDelete,
+			Path:     "/webhooks/webhook1",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "webhook1").Return(nil).Once()
+			},
+		},
+		{
+			Name:     "delete unknown",
+			Method:   http.MethodDelete,
+			Path:     "/webhooks/webhook2",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Delete", mock.Anything, "webhook2").Return(domain.ErrWebhookNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			var body bytes.Buffer
+			if tt.Body != nil {
+				json.NewEncoder(&body).Encode(tt.Body)
+			}
+			req, _ := http.NewRequest(tt.Method, tt.Path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// TestGetWebhookDeliveries is used to test GetWebhookDeliveries controller
+func (s *SuiteWebhookUsecase) TestGetWebhookDeliveries() {
+	tests := []WebhookTestCase{
+		{
+			Name:     "delivery log",
+			Path:     "/webhooks/webhook1/deliveries?limit=5",
+			Expected: http.StatusOK,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchDeliveries", mock.Anything, "webhook1", 5).Return([]domain.WebhookDelivery{sampleDelivery}, nil).Once()
+			},
+		},
+		{
+			Name:     "invalid limit",
+			Path:     "/webhooks/webhook1/deliveries?limit=many",
+			Expected: http.StatusBadRequest,
+		},
+		{
+			Name:     "negative limit",
+			Path:     "/webhooks/webhook1/deliveries?limit=-1",
+			Expected: http.StatusBadRequest,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchDeliveries", mock.Anything, "webhook1", -1).Return([]domain.WebhookDelivery{}, domain.ErrInvalidQuery).Once()
+			},
+		},
+		{
+			Name:     "unknown webhook",
+			Path:     "/webhooks/webhook2/deliveries",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("FetchDeliveries", mock.Anything, "webhook2", 0).Return([]domain.WebhookDelivery{}, domain.ErrWebhookNotFound).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodGet, tt.Path, nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
+
+// Test the payload of a delivery is listed as JSON rather than as an encoded string
+func (s *SuiteWebhookUsecase) TestGetWebhookDeliveries_Payload() {
+	s.mockUsecase.On("FetchDeliveries", mock.Anything, "webhook1", 0).Return([]domain.WebhookDelivery{sampleDelivery}, nil).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks/webhook1/deliveries", nil)
+	resp := httptest.NewRecorder()
+	s.router.ServeHTTP(resp, req)
+
+	var body struct {
+		Data []struct {
+			Payload struct {
+				Event string `json:"event"`
+			}
+			Attempts []domain.WebhookAttempt
+		} `json:"data"`
+	}
+	require.NoError(s.T(), json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(s.T(), body.Data, 1)
+	require.Equal(s.T(), "task.created", body.Data[0].Payload.Event)
+	require.Equal(s.T(), 503, body.Data[0].Attempts[0].StatusCode)
+}
+
+// TestRedeliverWebhook is used to test RedeliverWebhook controller
+func (s *SuiteWebhookUsecase) TestRedeliverWebhook() {
+	tests := []WebhookTestCase{
+		{
+			Name:     "redelivery queued",
+			Path:     "/webhooks/webhook1/deliveries/delivery1/redeliver",
+			Expected: http.StatusAccepted,
+			MockSetup: func() {
+				redelivery := sampleDelivery
+				redelivery.ID, redelivery.RedeliveryOf, redelivery.Attempts = "delivery2", "delivery1", nil
+				s.mockUsecase.On("Redeliver", mock.Anything, "webhook1", "delivery1").Return(redelivery, nil).Once()
+			},
+		},
+		{
+			Name:     "unknown delivery",
+			Path:     "/webhooks/webhook1/deliveries/delivery9/redeliver",
+			Expected: http.StatusNotFound,
+			MockSetup: func() {
+				s.mockUsecase.On("Redeliver", mock.Anything, "webhook1", "delivery9").Return(domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound).Once()
+			},
+		},
+		{
+			Name:     "internal server error",
+			Path:     "/webhooks/webhook1/deliveries/delivery1/redeliver",
+			Expected: http.StatusInternalServerError,
+			MockSetup: func() {
+				s.mockUsecase.On("Redeliver", mock.Anything, "webhook1", "delivery1").Return(domain.WebhookDelivery{}, fmt.Errorf("db error")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.Name, func() {
+			s.PrepareTest(tt)
+
+			req, _ := http.NewRequest(http.MethodPost, tt.Path, nil)
+			resp := httptest.NewRecorder()
+			s.router.ServeHTTP(resp, req)
+
+			require.Equal(s.T(), tt.Expected, resp.Code)
+			s.mockUsecase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package domain_test
+
+import (
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBatchOp(t *testing.T) {
+	op, err := domain.ParseBatchOp(" Delete ")
+	require.NoError(t, err)
+	require.Equal(t, domain.BatchDelete, op)
+
+	_, err = domain.ParseBatchOp("archive")
+	require.ErrorIs(t, err, domain.ErrInvalidBatchOp)
+}
+
+func TestParseImportFormat(t *testing.T) {
+	format, err := domain.ParseImportFormat(" CSV ")
+	require.NoError(t, err)
+	require.Equal(t, domain.ImportCSV, format)
+
+	_, err = domain.ParseImportFormat("xlsx")
+	require.ErrorIs(t, err, domain.ErrInvalidImportFormat)
+}
@@ -0,0 +1,17 @@
+package domain_test
+
+import (
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := domain.ParseExportFormat(" ICS ")
+	require.NoError(t, err)
+	require.Equal(t, domain.ExportICS, format)
+
+	_, err = domain.ParseExportFormat("xlsx")
+	require.ErrorIs(t, err, domain.ErrInvalidExportFormat)
+}
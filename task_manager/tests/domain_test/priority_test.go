@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaskPriority(t *testing.T) {
+	priority, err := domain.ParseTaskPriority(" High ")
+	require.NoError(t, err)
+	require.Equal(t, domain.PriorityHigh, priority)
+
+	_, err = domain.ParseTaskPriority("asap")
+	require.ErrorIs(t, err, domain.ErrInvalidPriority)
+
+	_, err = domain.ParseTaskPriority("")
+	require.ErrorIs(t, err, domain.ErrInvalidPriority)
+}
+
+func TestUrgencyAt(t *testing.T) {
+	now := time.Date(2030, time.March, 10, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	lowToday := domain.Task{Priority: domain.PriorityLow, DueDate: now}
+	criticalNextWeek := domain.Task{Priority: domain.PriorityCritical, DueDate: now.Add(7 * day)}
+	mediumTomorrow := domain.Task{Priority: domain.PriorityMedium, DueDate: now.Add(day)}
+	highOverdue := domain.Task{Priority: domain.PriorityHigh, DueDate: now.Add(-12 * time.Hour)}
+
+	require.Equal(t, 0.0, lowToday.UrgencyAt(now))
+	require.Equal(t, 0.0, criticalNextWeek.UrgencyAt(now))
+	require.Equal(t, 1.0, mediumTomorrow.UrgencyAt(now))
+	require.Equal(t, 4.5, highOverdue.UrgencyAt(now))
+
+	// the score grows by one a day for every task, so the ordering never changes
+	later := now.Add(36 * time.Hour)
+	require.Equal(t, 2.5, mediumTomorrow.UrgencyAt(later))
+	require.Equal(t, 6.0, highOverdue.UrgencyAt(later))
+	require.True(t, highOverdue.UrgentAt().Before(mediumTomorrow.UrgentAt()))
+}
+
+func TestUrgentAt_UnknownPriority(t *testing.T) {
+	due := time.Date(2030, time.March, 10, 9, 0, 0, 0, time.UTC)
+	stored := domain.Task{DueDate: due}
+	medium := domain.Task{Priority: domain.DefaultPriority, DueDate: due}
+	require.Equal(t, medium.UrgentAt(), stored.UrgentAt())
+}
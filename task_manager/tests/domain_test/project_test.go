@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProjectRole(t *testing.T) {
+	role, err := domain.ParseProjectRole(" Editor ")
+	require.NoError(t, err)
+	require.Equal(t, domain.ProjectRoleEditor, role)
+
+	_, err = domain.ParseProjectRole("guest")
+	require.ErrorIs(t, err, domain.ErrInvalidProjectRole)
+
+	require.True(t, domain.ProjectRoleEditor.CanEditTasks())
+	require.False(t, domain.ProjectRoleViewer.CanEditTasks())
+	require.False(t, domain.ProjectRoleEditor.CanManage())
+}
+
+func TestProjectRoleOfActor(t *testing.T) {
+	project := domain.Project{Members: []domain.ProjectMember{
+		{UserID: "owner", Role: domain.ProjectRoleOwner},
+		{UserID: "viewer", Role: domain.ProjectRoleViewer},
+	}}
+
+	role, ok := project.RoleOfActor(domain.Actor{ID: "viewer"})
+	require.True(t, ok)
+	require.Equal(t, domain.ProjectRoleViewer, role)
+
+	_, ok = project.RoleOfActor(domain.Actor{ID: "stranger"})
+	require.False(t, ok)
+
+	// admins act as owners of every project
+	role, ok = project.RoleOfActor(domain.Actor{ID: "admin", IsAdmin: true})
+	require.True(t, ok)
+	require.Equal(t, domain.ProjectRoleOwner, role)
+	require.Equal(t, 1, project.OwnerCount())
+}
@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Rule      string
+		Canonical string
+		Invalid   bool
+	}{
+		{Name: "daily", Rule: "FREQ=DAILY", Canonical: "FREQ=DAILY"},
+		{Name: "prefix and case", Rule: "rrule:freq=weekly;interval=2;byday=th,mo", Canonical: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{Name: "monthly by day", Rule: "FREQ=MONTHLY;BYMONTHDAY=15,-1;COUNT=6", Canonical: "FREQ=MONTHLY;BYMONTHDAY=15,-1;COUNT=6"},
+		{Name: "until date", Rule: "FREQ=YEARLY;UNTIL=20301231", Canonical: "FREQ=YEARLY;UNTIL=20301231T235959Z"},
+		{Name: "missing freq", Rule: "INTERVAL=2", Invalid: true},
+		{Name: "unsupported freq", Rule: "FREQ=HOURLY", Invalid: true},
+		{Name: "count and until", Rule: "FREQ=DAILY;COUNT=3;UNTIL=20301231", Invalid: true},
+		{Name: "byday on daily rule", Rule: "FREQ=DAILY;BYDAY=MO", Invalid: true},
+		{Name: "byday ordinal", Rule: "FREQ=WEEKLY;BYDAY=1MO", Invalid: true},
+		{Name: "bymonthday out of range", Rule: "FREQ=MONTHLY;BYMONTHDAY=32", Invalid: true},
+		{Name: "zero interval", Rule: "FREQ=DAILY;INTERVAL=0", Invalid: true},
+		{Name: "repeated part", Rule: "FREQ=DAILY;FREQ=WEEKLY", Invalid: true},
+		{Name: "unsupported part", Rule: "FREQ=DAILY;BYHOUR=9", Invalid: true},
+		{Name: "empty", Rule: " ", Invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			rule, err := domain.ParseRRule(tt.Rule)
+			if tt.Invalid {
+				require.ErrorIs(t, err, domain.ErrInvalidRecurrence)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.Canonical, rule.String())
+		})
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		Name       string
+		Rule       string
+		Prev       time.Time
+		Occurrence int
+		Next       time.Time // zero when the series is over
+	}{
+		{Name: "every other day", Rule: "FREQ=DAILY;INTERVAL=2", Prev: date(2025, 2, 27), Next: date(2025, 3, 1)},
+		{Name: "weekly keeps weekday", Rule: "FREQ=WEEKLY", Prev: date(2025, 3, 5), Next: date(2025, 3, 12)},
+		{Name: "next day of the same week", Rule: "FREQ=WEEKLY;BYDAY=MO,TH", Prev: date(2025, 3, 3), Next: date(2025, 3, 6)},
+		{Name: "first day of a later week", Rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", Prev: date(2025, 3, 6), Next: date(2025, 3, 17)},
+		{Name: "sunday ends the week", Rule: "FREQ=WEEKLY;BYDAY=SU,MO", Prev: date(2025, 3, 9), Next: date(2025, 3, 10)},
+		{Name: "monthly skips short months", Rule: "FREQ=MONTHLY", Prev: date(2025, 1, 31), Next: date(2025, 3, 31)},
+		{Name: "last day of the month", Rule: "FREQ=MONTHLY;BYMONTHDAY=-1", Prev: date(2025, 1, 31), Next: date(2025, 2, 28)},
+		{Name: "later day of the same month", Rule: "FREQ=MONTHLY;BYMONTHDAY=1,15", Prev: date(2025, 1, 1), Next: date(2025, 1, 15)},
+		{Name: "yearly skips to next leap year", Rule: "FREQ=YEARLY", Prev: date(2024, 2, 29), Next: date(2028, 2, 29)},
+		{Name: "count reached", Rule: "FREQ=DAILY;COUNT=3", Prev: date(2025, 1, 1), Occurrence: 3},
+		{Name: "count not reached", Rule: "FREQ=DAILY;COUNT=3", Prev: date(2025, 1, 1), Occurrence: 2, Next: date(2025, 1, 2)},
+		{Name: "until passed", Rule: "FREQ=WEEKLY;UNTIL=20250105", Prev: date(2025, 1, 1)},
+		{Name: "until includes the day", Rule: "FREQ=DAILY;UNTIL=20250102", Prev: date(2025, 1, 1), Next: date(2025, 1, 2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			rule, err := domain.ParseRRule(tt.Rule)
+			require.NoError(t, err)
+
+			occurrence := tt.Occurrence
+			if occurrence == 0 {
+				occurrence = 1
+			}
+			next, ok := rule.Next(tt.Prev, occurrence)
+			require.Equal(t, !tt.Next.IsZero(), ok)
+			require.True(t, tt.Next.Equal(next), "expected %s, got %s", tt.Next, next)
+		})
+	}
+}
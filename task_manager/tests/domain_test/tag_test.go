@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Tag     string
+		Parsed  string
+		Invalid bool
+	}{
+		{Name: "plain", Tag: "work", Parsed: "work"},
+		{Name: "trimmed and lowercased", Tag: "  Q3 Review ", Parsed: "q3 review"},
+		{Name: "longest", Tag: strings.Repeat("é", domain.MaxTagLength), Parsed: strings.Repeat("é", domain.MaxTagLength)},
+		{Name: "blank", Tag: "   ", Invalid: true},
+		{Name: "too long", Tag: strings.Repeat("a", domain.MaxTagLength+1), Invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tag, err := domain.ParseTag(tt.Tag)
+			if tt.Invalid {
+				require.ErrorIs(t, err, domain.ErrInvalidTag)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.Parsed, tag)
+		})
+	}
+}
+
+func TestParseTags_DropsDuplicates(t *testing.T) {
+	tags, err := domain.ParseTags([]string{"Work", "home", "work "})
+	require.NoError(t, err)
+	require.Equal(t, []string{"work", "home"}, tags)
+
+	_, err = domain.ParseTags([]string{"work", ""})
+	require.ErrorIs(t, err, domain.ErrInvalidTag)
+}
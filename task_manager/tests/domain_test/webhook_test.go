@@ -0,0 +1,20 @@
+package domain_test
+
+import (
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookEvents(t *testing.T) {
+	events, err := domain.ParseWebhookEvents([]string{"Task.Updated ", "task.status_changed", "task.updated"})
+	require.NoError(t, err)
+	require.Equal(t, []string{domain.WebhookEventTaskUpdated, domain.WebhookEventTaskStatusChanged}, events)
+
+	_, err = domain.ParseWebhookEvents(nil)
+	require.ErrorIs(t, err, domain.ErrInvalidWebhookEvent)
+
+	_, err = domain.ParseWebhookEvents([]string{"task.created", "project.created"})
+	require.ErrorIs(t, err, domain.ErrInvalidWebhookEvent)
+}
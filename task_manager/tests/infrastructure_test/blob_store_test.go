@@ -0,0 +1,170 @@
+package infrastructure_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeS3Server is a minimal in-memory stand-in for an S3-compatible server using path-style addressing
+type fakeS3Server struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	auth    []string // Authorization headers received, in order
+}
+
+func newFakeS3Server() *fakeS3Server {
+	return &fakeS3Server{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (f *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	if r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") == "" {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	path := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[path] = data
+		f.types[path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[path])
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+type BlobStoreSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (s *BlobStoreSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+// checkRoundTrip stores, reads back and deletes a blob
+func (s *BlobStoreSuite) checkRoundTrip(store domain.BlobStore) {
+	content := "hello attachments"
+	s.Require().NoError(store.Put(s.ctx, "task-1/blob-1", strings.NewReader(content), int64(len(content)), "text/plain"))
+
+	reader, err := store.Get(s.ctx, "task-1/blob-1")
+	s.Require().NoError(err)
+	data, err := io.ReadAll(reader)
+	s.Require().NoError(err)
+	s.Require().NoError(reader.Close())
+	s.Equal(content, string(data))
+
+	s.NoError(store.Delete(s.ctx, "task-1/blob-1"))
+	_, err = store.Get(s.ctx, "task-1/blob-1")
+	s.ErrorIs(err, domain.ErrBlobNotFound)
+
+	// deleting twice is not an error
+	s.NoError(store.Delete(s.ctx, "task-1/blob-1"))
+}
+
+func (s *BlobStoreSuite) TestLocalBlobStore_RoundTrip() {
+	root := filepath.Join(s.T().TempDir(), "blobs")
+	store, err := infrastructure.NewLocalBlobStore(root)
+	s.Require().NoError(err)
+
+	s.checkRoundTrip(store)
+
+	// the directory of the task goes away with its last blob
+	_, err = os.Stat(filepath.Join(root, "task-1"))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *BlobStoreSuite) TestLocalBlobStore_ShortContent() {
+	store, err := infrastructure.NewLocalBlobStore(s.T().TempDir())
+	s.Require().NoError(err)
+
+	err = store.Put(s.ctx, "task-1/blob-1", strings.NewReader("short"), 10, "text/plain")
+	s.Error(err)
+	_, err = store.Get(s.ctx, "task-1/blob-1")
+	s.ErrorIs(err, domain.ErrBlobNotFound)
+}
+
+func (s *BlobStoreSuite) TestLocalBlobStore_RejectsEscapingKeys() {
+	store, err := infrastructure.NewLocalBlobStore(s.T().TempDir())
+	s.Require().NoError(err)
+
+	for _, key := range []string{"", "../outside", "task-1/../../outside", "/etc/passwd", "task-1\\blob"} {
+		err := store.Put(s.ctx, key, strings.NewReader("x"), 1, "text/plain")
+		s.ErrorIs(err, domain.ErrInvalidBlobKey, key)
+	}
+}
+
+func (s *BlobStoreSuite) TestS3BlobStore_RoundTrip() {
+	fake := newFakeS3Server()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := infrastructure.NewS3BlobStore(server.URL, "eu-west-1", "attachments", "access-key", "secret-key", server.Client())
+	s.checkRoundTrip(store)
+
+	s.Require().NotEmpty(fake.auth)
+	for _, auth := range fake.auth {
+		s.True(strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access-key/"), auth)
+		s.Contains(auth, "/eu-west-1/s3/aws4_request")
+		s.Contains(auth, "SignedHeaders=")
+		s.Contains(auth, "host;x-amz-content-sha256;x-amz-date")
+	}
+}
+
+func (s *BlobStoreSuite) TestS3BlobStore_UsesBucketPath() {
+	fake := newFakeS3Server()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := infrastructure.NewS3BlobStore(server.URL, "us-east-1", "attachments", "access-key", "secret-key", server.Client())
+	s.Require().NoError(store.Put(s.ctx, "task-1/blob-1", strings.NewReader("png"), 3, "image/png"))
+
+	s.Equal([]byte("png"), fake.objects["/attachments/task-1/blob-1"])
+	s.Equal("image/png", fake.types["/attachments/task-1/blob-1"])
+}
+
+func (s *BlobStoreSuite) TestS3BlobStore_ServerError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>InternalError</Code></Error>", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := infrastructure.NewS3BlobStore(server.URL, "us-east-1", "attachments", "access-key", "secret-key", server.Client())
+	err := store.Put(s.ctx, "task-1/blob-1", strings.NewReader("x"), 1, "text/plain")
+	s.ErrorContains(err, "InternalError")
+
+	_, err = store.Get(s.ctx, "task-1/blob-1")
+	s.Error(err)
+	s.NotErrorIs(err, domain.ErrBlobNotFound)
+}
+
+func TestBlobStoreSuite(t *testing.T) {
+	suite.Run(t, new(BlobStoreSuite))
+}
@@ -17,13 +17,14 @@ type ConfigSuite struct {
 func (s *ConfigSuite) SetupSuite() {
 	// Define all env vars and their values once
 	s.envVars = map[string]string{
-		"MONGO_URI":       "mongodb://customhost:12345",
-		"COLLECTION_TASK": "mytasks",
-		"COLLECTION_USER": "myusers",
-		"JWT_SECRET":      "myjwtsecret",
-		"DBName":          "mydatabase",
-		"Port":            "9090",
-		"APP_TIMEOUT":     "10s",
+		"MONGO_URI":            "mongodb://customhost:12345",
+		"COLLECTION_TASK":      "mytasks",
+		"COLLECTION_USER":      "myusers",
+		"JWT_SECRET":           "myjwtsecret",
+		"DBName":               "mydatabase",
+		"Port":                 "9090",
+		"APP_TIMEOUT":          "10s",
+		"WEBHOOK_MAX_ATTEMPTS": "5",
 	}
 
 	// Set all env vars
@@ -50,6 +51,31 @@ func (s *ConfigSuite) TestLoadConfig() {
 	s.Equal(s.envVars["DBName"], cfg.DBName)
 	s.Equal(s.envVars["Port"], cfg.Port)
 	s.Equal(10*time.Second, cfg.Timeout)
+	s.Equal(5, cfg.WebhookMaxAttempts)
+}
+
+// Test a non-positive or malformed trash retention falls back to the default instead of purging right away
+func (s *ConfigSuite) TestLoadConfig_TrashRetention() {
+	defer os.Unsetenv("TRASH_RETENTION")
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 720 * time.Hour},
+		{"48h", 48 * time.Hour},
+		{"-1h", 720 * time.Hour},
+		{"0s", 720 * time.Hour},
+		{"a week", 720 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.value, func() {
+			os.Setenv("TRASH_RETENTION", tt.value)
+			infrastructure.LoadConfig()
+			s.Equal(tt.expected, infrastructure.AppConfig.TrashRetention)
+		})
+	}
 }
 
 func TestConfigSuite(t *testing.T) {
@@ -0,0 +1,74 @@
+package infrastructure_test
+
+import (
+	"strings"
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/suite"
+)
+
+type CursorServiceSuite struct {
+	suite.Suite
+	service domain.CursorService
+}
+
+func (s *CursorServiceSuite) SetupTest() {
+	s.service = infrastructure.NewCursorService("cursor-secret")
+}
+
+// Test Encode and Decode round trip a cursor
+func (s *CursorServiceSuite) TestEncodeDecode() {
+	cursor := domain.Cursor{
+		SortBy:    "due_date",
+		SortDesc:  true,
+		SortValue: "2030-01-01T00:00:00Z",
+		ID:        "64b7f0c2a1b2c3d4e5f60718",
+	}
+
+	token, err := s.service.Encode(cursor)
+	s.Require().NoError(err)
+	s.NotEmpty(token)
+
+	decoded, err := s.service.Decode(token)
+	s.Require().NoError(err)
+	s.Equal(cursor, decoded)
+}
+
+// Test Decode rejects a token whose payload was altered
+func (s *CursorServiceSuite) TestDecode_TamperedPayload() {
+	token, err := s.service.Encode(domain.Cursor{ID: "64b7f0c2a1b2c3d4e5f60718"})
+	s.Require().NoError(err)
+
+	other, err := s.service.Encode(domain.Cursor{ID: "64b7f0c2a1b2c3d4e5f60719"})
+	s.Require().NoError(err)
+
+	// swap the payload while keeping the original signature
+	payload, _, _ := strings.Cut(other, ".")
+	_, signature, _ := strings.Cut(token, ".")
+
+	_, err = s.service.Decode(payload + "." + signature)
+	s.ErrorIs(err, domain.ErrInvalidCursor)
+}
+
+// Test Decode rejects a token signed with another secret
+func (s *CursorServiceSuite) TestDecode_WrongSecret() {
+	token, err := infrastructure.NewCursorService("another-secret").Encode(domain.Cursor{ID: "64b7f0c2a1b2c3d4e5f60718"})
+	s.Require().NoError(err)
+
+	_, err = s.service.Decode(token)
+	s.ErrorIs(err, domain.ErrInvalidCursor)
+}
+
+// Test Decode rejects malformed tokens
+func (s *CursorServiceSuite) TestDecode_Malformed() {
+	for _, token := range []string{"", "no-dot", "a.b", "!!!.???"} {
+		_, err := s.service.Decode(token)
+		s.ErrorIs(err, domain.ErrInvalidCursor, token)
+	}
+}
+
+func TestCursorServiceSuite(t *testing.T) {
+	suite.Run(t, new(CursorServiceSuite))
+}
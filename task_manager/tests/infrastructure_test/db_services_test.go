@@ -1,4 +1,3 @@
-
 package infrastructure_test
 
 import (
@@ -12,6 +11,12 @@ import (
 )
 
 func TestInitMongo_Success(t *testing.T) {
+	// needs a running server, e.g. MONGO_TEST_URI=mongodb://localhost:27017
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
 	// Set environment variable for DB_NAME if your function still uses it
 	os.Setenv("DB_NAME", "testdb")
 	defer os.Unsetenv("DB_NAME")
@@ -19,7 +24,7 @@ func TestInitMongo_Success(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	db, err := infrastructure.InitMongo(ctx, "mongodb://localhost:27017", "testdb")
+	db, err := infrastructure.InitMongo(ctx, uri, "testdb")
 	require.NoError(t, err)
 	require.NotNil(t, db)
 	require.Equal(t, "testdb", db.Name())
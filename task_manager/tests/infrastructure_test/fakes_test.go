@@ -0,0 +1,138 @@
+package infrastructure_test
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeClock is a domain.Clock whose ticks are delivered by the test
+type fakeClock struct {
+	now   time.Time
+	ticks chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(time.Duration) <-chan time.Time { return c.ticks }
+
+// taskUsecaseStub mocks the usecase methods used by the background jobs
+// Any other method panics through the nil embedded interface
+type taskUsecaseStub struct {
+	domain.TaskUsecase
+	mock.Mock
+}
+
+func (u *taskUsecaseStub) MarkOverdueMissed(c context.Context, now time.Time) (int, error) {
+	args := u.Called(c, now)
+	return args.Int(0), args.Error(1)
+}
+
+// taskRepositoryStub mocks the repository methods used by the background jobs
+// Any other method panics through the nil embedded interface
+type taskRepositoryStub struct {
+	domain.TaskRepository
+	mock.Mock
+}
+
+func (r *taskRepositoryStub) FetchPendingDueBetween(c context.Context, from time.Time, to time.Time) ([]domain.Task, error) {
+	args := r.Called(c, from, to)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+// userRepositoryStub mocks the user lookups used by the background jobs
+type userRepositoryStub struct {
+	domain.UserRepository
+	mock.Mock
+}
+
+func (r *userRepositoryStub) FetchByUserID(c context.Context, userID string) (domain.User, error) {
+	args := r.Called(c, userID)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+// memoryReminderRepository is an in-memory domain.ReminderRepository
+type memoryReminderRepository struct {
+	sent map[string]bool
+}
+
+func newMemoryReminderRepository() *memoryReminderRepository {
+	return &memoryReminderRepository{sent: make(map[string]bool)}
+}
+
+func reminderKey(taskID string, userID string, dueDate time.Time) string {
+	return taskID + "/" + userID + "/" + dueDate.UTC().Format(time.RFC3339Nano)
+}
+
+func (r *memoryReminderRepository) Claim(_ context.Context, taskID string, userID string, dueDate time.Time) (bool, error) {
+	key := reminderKey(taskID, userID, dueDate)
+	if r.sent[key] {
+		return false, nil
+	}
+	r.sent[key] = true
+	return true, nil
+}
+
+func (r *memoryReminderRepository) Release(_ context.Context, taskID string, userID string, dueDate time.Time) error {
+	delete(r.sent, reminderKey(taskID, userID, dueDate))
+	return nil
+}
+
+// notifierStub mocks domain.Notifier
+type notifierStub struct {
+	mock.Mock
+}
+
+func (n *notifierStub) Notify(c context.Context, notification domain.Notification) error {
+	args := n.Called(c, notification)
+	return args.Error(0)
+}
+
+// webhookRepositoryStub mocks the webhook lookups used by the background jobs
+type webhookRepositoryStub struct {
+	domain.WebhookRepository
+	mock.Mock
+}
+
+func (r *webhookRepositoryStub) FetchByID(c context.Context, webhookID string) (domain.Webhook, error) {
+	args := r.Called(c, webhookID)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+// memoryDeliveryRepository is an in-memory queue of webhook deliveries
+// Methods the dispatcher does not use panic through the nil embedded interface
+type memoryDeliveryRepository struct {
+	domain.WebhookDeliveryRepository
+	deliveries []*domain.WebhookDelivery
+}
+
+func (r *memoryDeliveryRepository) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (domain.WebhookDelivery, error) {
+	var due *domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status != domain.DeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || delivery.NextAttemptAt.Before(due.NextAttemptAt) {
+			due = delivery
+		}
+	}
+	if due == nil {
+		return domain.WebhookDelivery{}, domain.ErrNoWebhookDeliveryDue
+	}
+	due.NextAttemptAt = now.Add(lease)
+	return *due, nil
+}
+
+func (r *memoryDeliveryRepository) RecordAttempt(_ context.Context, deliveryID string, attempt domain.WebhookAttempt, status domain.WebhookDeliveryStatus, nextAttemptAt time.Time) error {
+	for _, delivery := range r.deliveries {
+		if delivery.ID == deliveryID {
+			delivery.Attempts = append(delivery.Attempts, attempt)
+			delivery.Status = status
+			delivery.NextAttemptAt = nextAttemptAt
+			return nil
+		}
+	}
+	return domain.ErrWebhookDeliveryNotFound
+}
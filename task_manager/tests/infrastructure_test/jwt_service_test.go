@@ -5,13 +5,14 @@ import (
 	"testing"
 	"time"
 
-	"github.com/A2SVTask7/Infrastructure"
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
 	"github.com/stretchr/testify/suite"
 )
 
 type JWTServiceSuite struct {
 	suite.Suite
-	service infrastructure.JWTService
+	service domain.JWTService
 	secret  string
 }
 
@@ -49,8 +50,8 @@ func (s *JWTServiceSuite) TestValidate_ValidToken() {
 
 	parsedClaims, err := s.service.Validate(tokenStr)
 	s.Require().NoError(err)
-	s.Equal("user1", parsedClaims.Username)
-	s.Equal("123", parsedClaims.Subject)
+	s.Equal("user1", parsedClaims["username"])
+	s.Equal("123", parsedClaims["sub"])
 }
 
 // Test Validate returns error on expired token
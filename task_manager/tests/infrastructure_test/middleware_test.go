@@ -11,7 +11,6 @@ import (
 	"github.com/A2SVTask7/tests/infrastructure_test/mocks"
 	mockRepo "github.com/A2SVTask7/tests/usecases_test"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -24,12 +23,10 @@ type AuthMiddlewareTestSuite struct {
 	mockRepo *mockRepo.MockUserRepository
 }
 
-var fakeClaims = &infrastructure.CustomClaims{
-	Username: "testuser",
-	RegisteredClaims: jwt.RegisteredClaims{
-		Subject:   "user-id-123",
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
-	},
+var fakeClaims = map[string]any{
+	"username": "testuser",
+	"sub":      "user-id-123",
+	"exp":      time.Now().Add(1 * time.Hour).Unix(),
 }
 
 var sampleUser = domain.User{
@@ -1,7 +1,6 @@
 package mocks
 
 import (
-	infrastructure "github.com/A2SVTask7/Infrastructure"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,14 +8,14 @@ type MockJWTService struct {
 	mock.Mock
 }
 
-// Validate(tokenString string) (*customClaims, error) // Validates a token and returns claims
-// Generate(claims map[string]any) (string, error)     // Generates a signed JWT string from claims
+// Validate(tokenString string) (map[string]any, error) // Validates a token and returns claims
+// Generate(claims map[string]any) (string, error)      // Generates a signed JWT string from claims
 
-func (m *MockJWTService) Validate(token string) (*infrastructure.CustomClaims, error) {
+func (m *MockJWTService) Validate(token string) (map[string]any, error) {
 	args := m.Called(token)
 
-	// try to assert to a pointer
-	if claims, ok := args.Get(0).(*infrastructure.CustomClaims); ok {
+	// try to assert to a claims map
+	if claims, ok := args.Get(0).(map[string]any); ok {
 		return claims, args.Error(1)
 	}
 	return nil, args.Error(1)
@@ -24,5 +23,5 @@ func (m *MockJWTService) Validate(token string) (*infrastructure.CustomClaims, e
 
 func (m *MockJWTService) Generate(claims map[string]any) (string, error) {
 	args := m.Called(claims)
-	return args.String(0), args.Error(0)
+	return args.String(0), args.Error(1)
 }
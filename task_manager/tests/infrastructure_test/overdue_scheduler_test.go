@@ -0,0 +1,74 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OverdueSchedulerSuite struct {
+	suite.Suite
+	usecase   *taskUsecaseStub
+	clock     *fakeClock
+	scheduler *infrastructure.OverdueScheduler
+}
+
+func (s *OverdueSchedulerSuite) SetupTest() {
+	s.usecase = new(taskUsecaseStub)
+	s.clock = &fakeClock{
+		now:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		ticks: make(chan time.Time),
+	}
+	s.scheduler = infrastructure.NewOverdueScheduler(s.usecase, s.clock, time.Minute, time.Second)
+}
+
+func (s *OverdueSchedulerSuite) TestRunOnce_UsesClock() {
+	s.usecase.On("MarkOverdueMissed", mock.Anything, s.clock.now).Return(3, nil).Once()
+
+	count, err := s.scheduler.RunOnce(context.Background())
+	s.NoError(err)
+	s.Equal(3, count)
+	s.usecase.AssertExpectations(s.T())
+}
+
+func (s *OverdueSchedulerSuite) TestRunOnce_Error() {
+	s.usecase.On("MarkOverdueMissed", mock.Anything, s.clock.now).Return(0, errors.New("db down")).Once()
+
+	_, err := s.scheduler.RunOnce(context.Background())
+	s.Error(err)
+}
+
+func (s *OverdueSchedulerSuite) TestRun_SweepsOnEveryTickUntilCancelled() {
+	swept := make(chan struct{}, 3)
+	s.usecase.On("MarkOverdueMissed", mock.Anything, mock.Anything).
+		Return(0, nil).
+		Run(func(mock.Arguments) { swept <- struct{}{} })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.scheduler.Run(ctx)
+		close(done)
+	}()
+
+	<-swept // initial sweep
+	s.clock.ticks <- s.clock.now
+	<-swept // sweep after one tick
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("scheduler did not stop after cancellation")
+	}
+	s.usecase.AssertNumberOfCalls(s.T(), "MarkOverdueMissed", 2)
+}
+
+func TestOverdueSchedulerSuite(t *testing.T) {
+	suite.Run(t, new(OverdueSchedulerSuite))
+}
@@ -3,36 +3,42 @@ package infrastructure_test
 import (
 	"testing"
 
-	"github.com/A2SVTask7/Infrastructure"
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
 	"github.com/stretchr/testify/suite"
 )
 
 type PasswordTestSuite struct {
 	suite.Suite
+	service domain.IPasswordService
+}
+
+func (suite *PasswordTestSuite) SetupTest() {
+	suite.service = infrastructure.NewPasswordService()
 }
 
 func (suite *PasswordTestSuite) TestHashPasswordAndCompare_Success() {
 	password := "my_secure_password"
 
-	hashed, err := infrastructure.HashPassword(password)
+	hashed, err := suite.service.HashPassword(password)
 	suite.NoError(err)
 	suite.NotEmpty(hashed)
 
 	// Should successfully compare correct password
-	err = infrastructure.ComparePassword(hashed, password)
+	err = suite.service.ComparePassword(hashed, password)
 	suite.NoError(err)
 
 	// Should fail for incorrect password
-	err = infrastructure.ComparePassword(hashed, "wrong_password")
+	err = suite.service.ComparePassword(hashed, "wrong_password")
 	suite.Error(err)
 }
 
 func (suite *PasswordTestSuite) TestHashPassword_ErrorOnEmpty() {
-	hashed, err := infrastructure.HashPassword("secret")
+	hashed, err := suite.service.HashPassword("secret")
 	suite.NoError(err)
 	suite.NotEmpty(hashed)
 
-	err = infrastructure.ComparePassword(hashed, "secret")
+	err = suite.service.ComparePassword(hashed, "secret")
 	suite.NoError(err)
 }
 
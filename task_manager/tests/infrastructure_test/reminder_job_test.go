@@ -0,0 +1,106 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReminderJobSuite struct {
+	suite.Suite
+	tasks     *taskRepositoryStub
+	users     *userRepositoryStub
+	reminders *memoryReminderRepository
+	notifier  *notifierStub
+	clock     *fakeClock
+	job       *infrastructure.ReminderJob
+	task      domain.Task
+}
+
+func (s *ReminderJobSuite) SetupTest() {
+	s.tasks = new(taskRepositoryStub)
+	s.users = new(userRepositoryStub)
+	s.reminders = newMemoryReminderRepository()
+	s.notifier = new(notifierStub)
+	s.clock = &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.job = infrastructure.NewReminderJob(s.tasks, s.users, s.reminders, s.notifier, s.clock, 24*time.Hour, time.Minute, time.Second)
+
+	s.task = domain.Task{
+		ID:        "task1",
+		Title:     "Write report",
+		DueDate:   s.clock.now.Add(3 * time.Hour),
+		Status:    domain.StatusPending,
+		OwnerID:   "owner",
+		Assignees: []string{"owner", "helper"},
+	}
+	s.tasks.On("FetchPendingDueBetween", mock.Anything, s.clock.now, s.clock.now.Add(24*time.Hour)).Return([]domain.Task{s.task}, nil)
+	s.users.On("FetchByUserID", mock.Anything, "owner").Return(domain.User{ID: "owner", Username: "olive", Email: "olive@example.com"}, nil)
+	s.users.On("FetchByUserID", mock.Anything, "helper").Return(domain.User{ID: "helper", Username: "hank", Email: "hank@example.com"}, nil)
+}
+
+func (s *ReminderJobSuite) TestRunOnce_NotifiesOwnerAndAssigneesOnce() {
+	s.notifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+
+	sent, err := s.job.RunOnce(context.Background())
+	s.Require().NoError(err)
+	s.Equal(2, sent)
+	s.notifier.AssertCalled(s.T(), "Notify", mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+		return n.Recipient.ID == "owner" && n.Subject == "Reminder: Write report is due soon"
+	}))
+
+	// a second sweep, or a restarted process sharing the repository, sends nothing new
+	sent, err = s.job.RunOnce(context.Background())
+	s.Require().NoError(err)
+	s.Equal(0, sent)
+	s.notifier.AssertNumberOfCalls(s.T(), "Notify", 2)
+}
+
+func (s *ReminderJobSuite) TestRunOnce_FailedNotificationIsRetried() {
+	s.notifier.On("Notify", mock.Anything, mock.MatchedBy(func(n domain.Notification) bool { return n.Recipient.ID == "owner" })).Return(nil)
+	s.notifier.On("Notify", mock.Anything, mock.Anything).Return(errors.New("smtp down")).Once()
+
+	sent, err := s.job.RunOnce(context.Background())
+	s.Error(err)
+	s.Equal(1, sent)
+
+	s.notifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+	sent, err = s.job.RunOnce(context.Background())
+	s.NoError(err)
+	s.Equal(1, sent)
+}
+
+func (s *ReminderJobSuite) TestRunOnce_UserWithoutAddressIsSkipped() {
+	s.notifier.On("Notify", mock.Anything, mock.Anything).Return(domain.ErrNoContactAddress)
+
+	sent, err := s.job.RunOnce(context.Background())
+	s.NoError(err)
+	s.Equal(0, sent)
+
+	_, _ = s.job.RunOnce(context.Background())
+	s.notifier.AssertNumberOfCalls(s.T(), "Notify", 2)
+}
+
+func (s *ReminderJobSuite) TestRunOnce_MovedDueDateRemindsAgain() {
+	s.notifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+	_, err := s.job.RunOnce(context.Background())
+	s.Require().NoError(err)
+
+	moved := s.task
+	moved.DueDate = moved.DueDate.Add(time.Hour)
+	s.tasks.ExpectedCalls = nil
+	s.tasks.On("FetchPendingDueBetween", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Task{moved}, nil)
+
+	sent, err := s.job.RunOnce(context.Background())
+	s.NoError(err)
+	s.Equal(2, sent)
+}
+
+func TestReminderJobSuite(t *testing.T) {
+	suite.Run(t, new(ReminderJobSuite))
+}
@@ -0,0 +1,32 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the token of a calendar feed never reaches the access log, while other paths are logged as is
+func TestRequestLogger_RedactsFeedTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var out bytes.Buffer
+	router := gin.New()
+	router.Use(infrastructure.RequestLogger(&out))
+	router.GET("/calendar/:file", func(c *gin.Context) { c.Status(http.StatusNotModified) })
+	router.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/calendar/s3cret-token.ics", "/calendar/s3cret-token.ics?x=1", "/tasks?status=pending"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	log := out.String()
+	require.NotContains(t, log, "s3cret-token")
+	require.Equal(t, 2, bytes.Count(out.Bytes(), []byte(`"/calendar/[redacted]"`)))
+	require.Contains(t, log, `"/tasks?status=pending"`)
+	require.Contains(t, log, "| 304 |")
+}
@@ -0,0 +1,150 @@
+package infrastructure_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/suite"
+)
+
+// receivedMail is an email accepted by fakeSMTPServer
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer is a minimal SMTP server accepting any mail on a local port
+type fakeSMTPServer struct {
+	listener net.Listener
+	mails    chan receivedMail
+}
+
+func newFakeSMTPServer() (*fakeSMTPServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server := &fakeSMTPServer{listener: listener, mails: make(chan receivedMail, 10)}
+	go server.serve()
+	return server, nil
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	var mail receivedMail
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			mail = receivedMail{from: strings.Trim(line[len("MAIL FROM:"):], "<>")}
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			mail.to = append(mail.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			mail.data = data.String()
+			s.mails <- mail
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) port() string {
+	return strconv.Itoa(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+type SMTPNotifierSuite struct {
+	suite.Suite
+	server   *fakeSMTPServer
+	notifier domain.Notifier
+}
+
+func (s *SMTPNotifierSuite) SetupTest() {
+	server, err := newFakeSMTPServer()
+	s.Require().NoError(err)
+	s.server = server
+	s.notifier = infrastructure.NewSMTPNotifier("127.0.0.1", server.port(), "", "", "tasks@example.com")
+}
+
+func (s *SMTPNotifierSuite) TearDownTest() {
+	s.server.listener.Close()
+}
+
+func (s *SMTPNotifierSuite) TestNotify_SendsMail() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := s.notifier.Notify(ctx, domain.Notification{
+		Recipient: domain.User{ID: "u1", Username: "olive", Email: "olive@example.com"},
+		Subject:   "Reminder: Write report is due soon",
+		Body:      "Hi olive,\nThe task is due tomorrow.",
+	})
+	s.Require().NoError(err)
+
+	select {
+	case mail := <-s.server.mails:
+		s.Equal("tasks@example.com", mail.from)
+		s.Equal([]string{"olive@example.com"}, mail.to)
+		s.Contains(mail.data, "Subject: Reminder: Write report is due soon\r\n")
+		s.Contains(mail.data, "To: olive@example.com\r\n")
+		s.Contains(mail.data, "The task is due tomorrow.")
+	case <-time.After(time.Second):
+		s.Fail("no mail received")
+	}
+}
+
+func (s *SMTPNotifierSuite) TestNotify_NoAddress() {
+	err := s.notifier.Notify(context.Background(), domain.Notification{Recipient: domain.User{ID: "u1"}})
+	s.ErrorIs(err, domain.ErrNoContactAddress)
+}
+
+func TestSMTPNotifierSuite(t *testing.T) {
+	suite.Run(t, new(SMTPNotifierSuite))
+}
@@ -0,0 +1,201 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskExporterSuite struct {
+	suite.Suite
+	exporter domain.TaskExporter
+	tasks    []domain.Task
+}
+
+func (s *TaskExporterSuite) SetupTest() {
+	s.exporter = infrastructure.NewTaskExporter(&fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)})
+	s.tasks = []domain.Task{
+		{
+			ID:          "task1",
+			ProjectID:   "project1",
+			Title:       "Write report, then review; carefully",
+			Description: "First line\nSecond line",
+			DueDate:     time.Date(2025, 2, 3, 16, 30, 0, 0, time.FixedZone("EAT", 3*60*60)),
+			Status:      domain.StatusPending,
+			Priority:    domain.PriorityHigh,
+			OwnerID:     "user1",
+			Assignees:   []string{"user2", "user3"},
+			Tags:        []string{"work", "docs"},
+			Version:     2,
+		},
+		{
+			ID:        "task2",
+			ProjectID: "project1",
+			Title:     "Proofread",
+			DueDate:   time.Date(2025, 2, 4, 9, 0, 0, 0, time.UTC),
+			Status:    domain.StatusCompleted,
+			Priority:  domain.PriorityLow,
+			ParentID:  "task1",
+			Version:   1,
+		},
+	}
+}
+
+// export encodes the tasks in the given format
+func (s *TaskExporterSuite) export(format domain.ExportFormat, tasks []domain.Task) string {
+	var out bytes.Buffer
+	encoder, err := s.exporter.NewEncoder(format, &out)
+	s.Require().NoError(err)
+	for _, task := range tasks {
+		s.Require().NoError(encoder.Encode(task))
+	}
+	s.Require().NoError(encoder.Close())
+	return out.String()
+}
+
+// Test CSV exports start with a header and list tags and assignees in one cell
+func (s *TaskExporterSuite) TestCSV() {
+	records, err := csv.NewReader(strings.NewReader(s.export(domain.ExportCSV, s.tasks))).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 3)
+
+	s.Equal("id", records[0][0])
+	s.Equal([]string{
+		"task1", "project1", "Write report, then review; carefully", "First line\nSecond line", "2025-02-03T13:30:00Z",
+		"pending", "high", "", "work,docs", "user1", "user2,user3", "", "2",
+	}, records[1])
+	s.Equal("task1", records[2][11])
+}
+
+// Test cells a spreadsheet app would run as a formula are quoted
+func (s *TaskExporterSuite) TestCSV_Formulas() {
+	task := s.tasks[1]
+	task.Title = "=HYPERLINK(\"https://example.com\")"
+	task.Description = "-2+3"
+	task.Tags = []string{"@home"}
+	records, err := csv.NewReader(strings.NewReader(s.export(domain.ExportCSV, []domain.Task{task}))).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 2)
+
+	s.Equal("'=HYPERLINK(\"https://example.com\")", records[1][2])
+	s.Equal("'-2+3", records[1][3])
+	s.Equal("'@home", records[1][8])
+	s.Equal("task2", records[1][0])
+}
+
+// Test an empty CSV export still has its header
+func (s *TaskExporterSuite) TestCSV_Empty() {
+	s.Equal(1, strings.Count(s.export(domain.ExportCSV, nil), "\n"))
+}
+
+// Test JSON Lines exports hold one task per line
+func (s *TaskExporterSuite) TestJSONL() {
+	lines := strings.Split(strings.TrimSuffix(s.export(domain.ExportJSONL, s.tasks), "\n"), "\n")
+	s.Require().Len(lines, 2)
+
+	var task domain.Task
+	s.Require().NoError(json.Unmarshal([]byte(lines[1]), &task))
+	s.Equal("task2", task.ID)
+	s.Equal(domain.StatusCompleted, task.Status)
+}
+
+// Test iCalendar exports map tasks to VTODOs
+func (s *TaskExporterSuite) TestICS() {
+	out := s.export(domain.ExportICS, s.tasks)
+
+	s.True(strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	s.True(strings.HasSuffix(out, "END:VTODO\r\nEND:VCALENDAR\r\n"))
+	s.Equal(2, strings.Count(out, "BEGIN:VTODO\r\n"))
+	s.Contains(out, "UID:task1@task-manager\r\n")
+	s.Contains(out, "DTSTAMP:20250101T120000Z\r\n")
+	s.Contains(out, "SUMMARY:Write report\\, then review\\; carefully\r\n")
+	s.Contains(out, "DESCRIPTION:First line\\nSecond line\r\n")
+	s.Contains(out, "DUE:20250203T133000Z\r\n")
+	s.Contains(out, "STATUS:NEEDS-ACTION\r\n")
+	s.Contains(out, "STATUS:COMPLETED\r\n")
+	s.Contains(out, "PRIORITY:3\r\n")
+	s.Contains(out, "CATEGORIES:work,docs\r\n")
+	s.Contains(out, "RELATED-TO;RELTYPE=PARENT:task1@task-manager\r\n")
+}
+
+// Test long iCalendar lines are folded without splitting characters
+func (s *TaskExporterSuite) TestICS_Folding() {
+	task := s.tasks[1]
+	task.Title = strings.Repeat("é", 100)
+	out := s.export(domain.ExportICS, []domain.Task{task})
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		s.LessOrEqual(len(line), 75)
+		s.True(strings.ToValidUTF8(line, "?") == line)
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	s.Contains(unfolded, "SUMMARY:"+task.Title+"\r\n")
+}
+
+// Test an empty iCalendar export is still a calendar
+func (s *TaskExporterSuite) TestICS_Empty() {
+	s.Equal("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//A2SV//Task Manager//EN\r\nCALSCALE:GREGORIAN\r\nEND:VCALENDAR\r\n", s.export(domain.ExportICS, nil))
+}
+
+// Test feeds hold an event at the due date of every task next to its to-do
+func (s *TaskExporterSuite) TestFeed() {
+	var out bytes.Buffer
+	encoder := s.exporter.NewFeedEncoder(&out)
+	for _, task := range s.tasks {
+		s.Require().NoError(encoder.Encode(task))
+	}
+	s.Require().NoError(encoder.Close())
+	feed := out.String()
+
+	s.Contains(feed, "X-WR-CALNAME:Tasks\r\nREFRESH-INTERVAL;VALUE=DURATION:PT15M\r\n")
+	s.Equal(2, strings.Count(feed, "BEGIN:VTODO\r\n"))
+	s.Equal(2, strings.Count(feed, "BEGIN:VEVENT\r\n"))
+	s.Contains(feed, "UID:task1-due@task-manager\r\n")
+	s.Contains(feed, "DTSTART:20250203T133000Z\r\nDTEND:20250203T133000Z\r\n")
+	s.Contains(feed, "RELATED-TO:task1@task-manager\r\n")
+	s.True(strings.HasSuffix(feed, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+}
+
+// Test nothing is written before the first task
+func (s *TaskExporterSuite) TestNothingWrittenUpFront() {
+	for _, format := range []domain.ExportFormat{domain.ExportCSV, domain.ExportJSONL, domain.ExportICS} {
+		var out bytes.Buffer
+		_, err := s.exporter.NewEncoder(format, &out)
+		s.NoError(err)
+		s.Zero(out.Len(), format)
+	}
+}
+
+// Test write failures are reported
+func (s *TaskExporterSuite) TestWriteError() {
+	encoder, err := s.exporter.NewEncoder(domain.ExportICS, failingWriter{})
+	s.Require().NoError(err)
+	// the first tasks fit in the buffer
+	for i := 0; i < 100 && err == nil; i++ {
+		err = encoder.Encode(s.tasks[0])
+	}
+	s.Error(err)
+}
+
+// Test unknown formats are rejected
+func (s *TaskExporterSuite) TestUnknownFormat() {
+	_, err := s.exporter.NewEncoder(domain.ExportFormat("xml"), &bytes.Buffer{})
+	s.ErrorIs(err, domain.ErrInvalidExportFormat)
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestTaskExporterSuite(t *testing.T) {
+	suite.Run(t, new(TaskExporterSuite))
+}
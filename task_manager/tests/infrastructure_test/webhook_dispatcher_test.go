@@ -0,0 +1,146 @@
+package infrastructure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookDispatcherSuite struct {
+	suite.Suite
+	clock      *fakeClock
+	webhooks   *webhookRepositoryStub
+	deliveries *memoryDeliveryRepository
+	server     *httptest.Server
+	status     int // Status the webhook server responds with
+	requests   int // Requests the webhook server received
+	dispatcher *infrastructure.WebhookDispatcher
+}
+
+func (s *WebhookDispatcherSuite) SetupTest() {
+	s.clock = &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.status = http.StatusOK
+	s.requests = 0
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		w.WriteHeader(s.status)
+	}))
+	s.webhooks = new(webhookRepositoryStub)
+	s.webhooks.On("FetchByID", mock.Anything, "webhook1").Return(domain.Webhook{ID: "webhook1", URL: s.server.URL, Secret: "s3cret", Active: true}, nil).Maybe()
+	s.webhooks.On("FetchByID", mock.Anything, "paused").Return(domain.Webhook{ID: "paused", URL: s.server.URL}, nil).Maybe()
+	s.webhooks.On("FetchByID", mock.Anything, "deleted").Return(domain.Webhook{}, domain.ErrWebhookNotFound).Maybe()
+	s.deliveries = &memoryDeliveryRepository{}
+	// at most 3 attempts, retried after 1 then 2 minutes
+	s.dispatcher = infrastructure.NewWebhookDispatcher(s.webhooks, s.deliveries, infrastructure.NewWebhookSender(nil), s.clock, 3, time.Minute, time.Second, time.Second)
+}
+
+func (s *WebhookDispatcherSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// queue adds a delivery to the given webhook, due now
+func (s *WebhookDispatcherSuite) queue(id string, webhookID string) *domain.WebhookDelivery {
+	delivery := &domain.WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		Event:         domain.WebhookEventTaskCreated,
+		Payload:       []byte(`{}`),
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: s.clock.now,
+	}
+	s.deliveries.deliveries = append(s.deliveries.deliveries, delivery)
+	return delivery
+}
+
+func (s *WebhookDispatcherSuite) TestRunOnce_Delivers() {
+	first := s.queue("delivery1", "webhook1")
+	second := s.queue("delivery2", "webhook1")
+
+	sent, err := s.dispatcher.RunOnce(context.Background())
+	s.Require().NoError(err)
+	s.Equal(2, sent)
+	s.Equal(2, s.requests)
+	for _, delivery := range []*domain.WebhookDelivery{first, second} {
+		s.Equal(domain.DeliverySucceeded, delivery.Status)
+		s.Require().Len(delivery.Attempts, 1)
+		s.Equal(http.StatusOK, delivery.Attempts[0].StatusCode)
+		s.Empty(delivery.Attempts[0].Error)
+	}
+
+	// nothing is left to send
+	sent, err = s.dispatcher.RunOnce(context.Background())
+	s.NoError(err)
+	s.Zero(sent)
+	s.Equal(2, s.requests)
+}
+
+func (s *WebhookDispatcherSuite) TestRunOnce_RetriesWithBackoff() {
+	s.status = http.StatusInternalServerError
+	delivery := s.queue("delivery1", "webhook1")
+	start := s.clock.now
+
+	_, err := s.dispatcher.RunOnce(context.Background())
+	s.Require().NoError(err)
+	s.Equal(domain.DeliveryPending, delivery.Status)
+	s.Equal(start.Add(time.Minute), delivery.NextAttemptAt)
+
+	// not due yet
+	_, _ = s.dispatcher.RunOnce(context.Background())
+	s.Equal(1, s.requests)
+
+	s.clock.now = start.Add(time.Minute)
+	_, _ = s.dispatcher.RunOnce(context.Background())
+	s.Equal(domain.DeliveryPending, delivery.Status)
+	s.Equal(s.clock.now.Add(2*time.Minute), delivery.NextAttemptAt)
+
+	// the third failure is the last
+	s.clock.now = s.clock.now.Add(2 * time.Minute)
+	_, _ = s.dispatcher.RunOnce(context.Background())
+	s.Equal(domain.DeliveryFailed, delivery.Status)
+	s.Len(delivery.Attempts, 3)
+	s.Equal(http.StatusInternalServerError, delivery.Attempts[2].StatusCode)
+	s.Contains(delivery.Attempts[2].Error, "500")
+
+	s.clock.now = s.clock.now.Add(time.Hour)
+	_, _ = s.dispatcher.RunOnce(context.Background())
+	s.Equal(3, s.requests)
+}
+
+func (s *WebhookDispatcherSuite) TestRunOnce_RecoversAfterFailure() {
+	s.status = http.StatusBadGateway
+	delivery := s.queue("delivery1", "webhook1")
+	_, _ = s.dispatcher.RunOnce(context.Background())
+
+	s.status = http.StatusAccepted
+	s.clock.now = s.clock.now.Add(time.Minute)
+	sent, err := s.dispatcher.RunOnce(context.Background())
+	s.Require().NoError(err)
+	s.Equal(1, sent)
+	s.Equal(domain.DeliverySucceeded, delivery.Status)
+	s.Len(delivery.Attempts, 2)
+}
+
+func (s *WebhookDispatcherSuite) TestRunOnce_WebhookGoneOrInactive() {
+	deleted := s.queue("delivery1", "deleted")
+	paused := s.queue("delivery2", "paused")
+
+	sent, err := s.dispatcher.RunOnce(context.Background())
+	s.Require().NoError(err)
+	s.Zero(sent)
+	s.Zero(s.requests)
+	s.Equal(domain.DeliveryFailed, deleted.Status)
+	s.Equal("webhook was deleted", deleted.Attempts[0].Error)
+	s.Equal(domain.DeliveryFailed, paused.Status)
+	s.Equal("webhook is inactive", paused.Attempts[0].Error)
+}
+
+func TestWebhookDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(WebhookDispatcherSuite))
+}
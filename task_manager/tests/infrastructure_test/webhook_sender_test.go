@@ -0,0 +1,74 @@
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	"github.com/stretchr/testify/require"
+)
+
+// Test deliveries are posted with their event, ID and a signature receivers can verify
+func TestWebhookSender_SignsPayload(t *testing.T) {
+	payload := []byte(`{"id":"event1","event":"task.created"}`)
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhook := domain.Webhook{URL: server.URL + "/hooks", Secret: "s3cret"}
+	delivery := domain.WebhookDelivery{ID: "delivery1", Event: domain.WebhookEventTaskCreated, Payload: payload}
+	status, err := infrastructure.NewWebhookSender(nil).Send(context.Background(), webhook, delivery)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, status)
+
+	require.Equal(t, http.MethodPost, received.Method)
+	require.Equal(t, "/hooks", received.URL.Path)
+	require.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	require.Equal(t, "task.created", received.Header.Get(infrastructure.WebhookEventHeader))
+	require.Equal(t, "delivery1", received.Header.Get(infrastructure.WebhookDeliveryHeader))
+	require.Equal(t, payload, body)
+
+	// computed the way a receiver would
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.Header.Get(infrastructure.WebhookSignatureHeader))
+}
+
+// Test error statuses and redirects are failures
+func TestWebhookSender_Failures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/hooks", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	sender := infrastructure.NewWebhookSender(nil)
+	delivery := domain.WebhookDelivery{ID: "delivery1", Payload: []byte(`{}`)}
+
+	status, err := sender.Send(context.Background(), domain.Webhook{URL: server.URL + "/hooks"}, delivery)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, status)
+
+	status, err = sender.Send(context.Background(), domain.Webhook{URL: server.URL + "/moved"}, delivery)
+	require.Error(t, err)
+	require.Equal(t, http.StatusFound, status)
+
+	server.Close()
+	status, err = sender.Send(context.Background(), domain.Webhook{URL: server.URL + "/hooks"}, delivery)
+	require.Error(t, err)
+	require.Zero(t, status)
+}
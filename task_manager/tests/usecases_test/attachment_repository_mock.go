@@ -0,0 +1,43 @@
+package usecases_test
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentRepository is a mock implementation of the AttachmentRepository interface
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(c context.Context, attachment *domain.Attachment) error {
+	args := m.Called(c, attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) FetchByID(c context.Context, attachmentID string) (domain.Attachment, error) {
+	args := m.Called(c, attachmentID)
+	return args.Get(0).(domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FetchByTaskID(c context.Context, taskID string) ([]domain.Attachment, error) {
+	args := m.Called(c, taskID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FetchByTaskIDs(c context.Context, taskIDs []string) ([]domain.Attachment, error) {
+	args := m.Called(c, taskIDs)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(c context.Context, attachmentID string) (int, error) {
+	args := m.Called(c, attachmentID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) DeleteMany(c context.Context, attachmentIDs []string) (int, error) {
+	args := m.Called(c, attachmentIDs)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,188 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentUsecaseTestSuite struct {
+	suite.Suite
+	mockAttachments   *MockAttachmentRepository
+	mockTasks         *MockTaskRepository
+	mockProjects      *MockProjectRepository
+	mockBlobs         *MockBlobStore
+	attachmentUsecase domain.AttachmentUsecase
+	ctx               context.Context
+}
+
+func (s *AttachmentUsecaseTestSuite) SetupTest() {
+	s.mockAttachments = new(MockAttachmentRepository)
+	s.mockTasks = new(MockTaskRepository)
+	s.mockTasks.On("FetchByTaskID", mock.Anything, sampleTask.ID).Return(sampleTask, nil).Maybe()
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	s.mockBlobs = new(MockBlobStore)
+	s.attachmentUsecase = usecases.NewAttachmentUsecase(s.mockAttachments, s.mockTasks, s.mockProjects, s.mockBlobs, 1024, []string{"image/*", "text/plain"}, time.Second*2)
+	s.ctx = context.Background()
+}
+
+var sampleAttachment = domain.Attachment{
+	ID:          "attachment-id-123",
+	TaskID:      "task-id-123",
+	UploaderID:  "owner-id-123",
+	Filename:    "notes.txt",
+	ContentType: "text/plain",
+	Size:        5,
+	StorageKey:  "task-id-123/blob",
+	CreatedAt:   time.Now(),
+}
+
+// pngHeader is the signature every PNG file starts with
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func textUpload(filename, content string) domain.AttachmentUpload {
+	return domain.AttachmentUpload{Filename: filename, Size: int64(len(content)), Content: strings.NewReader(content)}
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUpload_Success() {
+	s.mockBlobs.On("Put", mock.Anything, mock.AnythingOfType("string"), int64(len(pngHeader)+4), "image/png").Return(nil)
+	s.mockAttachments.On("Create", mock.Anything, mock.AnythingOfType("*domain.Attachment")).Return(nil)
+
+	// the type the client claims does not matter, the content is sniffed
+	attachment, err := s.attachmentUsecase.Upload(s.ctx, sampleTask.ID, textUpload("C:\\shots\\screen.png", pngHeader+"data"), ownerActor)
+	s.NoError(err)
+	s.Equal("screen.png", attachment.Filename)
+	s.Equal("image/png", attachment.ContentType)
+	s.Equal(ownerActor.ID, attachment.UploaderID)
+	s.True(strings.HasPrefix(attachment.StorageKey, sampleTask.ID+"/"))
+	s.Equal(pngHeader+"data", string(s.mockBlobs.Stored[attachment.StorageKey]))
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUpload_StreamsWithoutTimeout() {
+	// the content may take longer than the usecase timeout to arrive
+	s.mockBlobs.On("Put", mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return !ok
+	}), mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockAttachments.On("Create", mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	}), mock.Anything).Return(nil).Once()
+
+	_, err := s.attachmentUsecase.Upload(s.ctx, sampleTask.ID, textUpload("notes.txt", "hello"), ownerActor)
+	s.NoError(err)
+	s.mockBlobs.AssertExpectations(s.T())
+	s.mockAttachments.AssertExpectations(s.T())
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUpload_Rejected() {
+	tests := []struct {
+		name   string
+		upload domain.AttachmentUpload
+		err    error
+	}{
+		{name: "too large", upload: textUpload("big.txt", strings.Repeat("a", 1025)), err: domain.ErrAttachmentTooLarge},
+		{name: "empty", upload: textUpload("empty.txt", ""), err: domain.ErrEmptyAttachment},
+		{name: "no file name", upload: textUpload("../", "hello"), err: domain.ErrInvalidFilename},
+		{name: "type not allowed", upload: textUpload("doc.pdf", "%PDF-1.7 ..."), err: domain.ErrAttachmentTypeNotAllowed},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.attachmentUsecase.Upload(s.ctx, sampleTask.ID, tt.upload, ownerActor)
+			s.ErrorIs(err, tt.err)
+		})
+	}
+	s.mockBlobs.AssertNotCalled(s.T(), "Put")
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUpload_TaskNotVisible() {
+	_, err := s.attachmentUsecase.Upload(s.ctx, sampleTask.ID, textUpload("notes.txt", "hello"), otherActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+	s.mockBlobs.AssertNotCalled(s.T(), "Put")
+}
+
+func (s *AttachmentUsecaseTestSuite) TestUpload_RemovesBlobWhenMetadataFails() {
+	s.mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.mockBlobs.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	s.mockAttachments.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	_, err := s.attachmentUsecase.Upload(s.ctx, sampleTask.ID, textUpload("notes.txt", "hello"), ownerActor)
+	s.Error(err)
+	s.mockBlobs.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
+
+func (s *AttachmentUsecaseTestSuite) TestOpen_Success() {
+	s.mockAttachments.On("FetchByID", mock.Anything, sampleAttachment.ID).Return(sampleAttachment, nil)
+	s.mockBlobs.On("Get", mock.Anything, sampleAttachment.StorageKey).Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+	attachment, content, err := s.attachmentUsecase.Open(s.ctx, sampleTask.ID, sampleAttachment.ID, ownerActor)
+	s.NoError(err)
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	s.Equal("hello", string(data))
+	s.Equal(sampleAttachment.Filename, attachment.Filename)
+}
+
+func (s *AttachmentUsecaseTestSuite) TestOpen_MissingBlob() {
+	s.mockAttachments.On("FetchByID", mock.Anything, sampleAttachment.ID).Return(sampleAttachment, nil)
+	s.mockBlobs.On("Get", mock.Anything, sampleAttachment.StorageKey).Return(nil, domain.ErrBlobNotFound)
+
+	_, _, err := s.attachmentUsecase.Open(s.ctx, sampleTask.ID, sampleAttachment.ID, ownerActor)
+	s.ErrorIs(err, domain.ErrAttachmentNotFound)
+}
+
+func (s *AttachmentUsecaseTestSuite) TestOpen_AttachmentOfAnotherTask() {
+	attachment := sampleAttachment
+	attachment.TaskID = "task-id-456"
+	s.mockAttachments.On("FetchByID", mock.Anything, attachment.ID).Return(attachment, nil)
+
+	_, _, err := s.attachmentUsecase.Open(s.ctx, sampleTask.ID, attachment.ID, ownerActor)
+	s.ErrorIs(err, domain.ErrAttachmentNotFound)
+	s.mockBlobs.AssertNotCalled(s.T(), "Get")
+}
+
+func (s *AttachmentUsecaseTestSuite) TestDelete_ByUploader() {
+	s.mockAttachments.On("FetchByID", mock.Anything, sampleAttachment.ID).Return(sampleAttachment, nil)
+	s.mockAttachments.On("Delete", mock.Anything, sampleAttachment.ID).Return(1, nil)
+	s.mockBlobs.On("Delete", mock.Anything, sampleAttachment.StorageKey).Return(nil)
+
+	err := s.attachmentUsecase.Delete(s.ctx, sampleTask.ID, sampleAttachment.ID, ownerActor)
+	s.NoError(err)
+	s.mockBlobs.AssertCalled(s.T(), "Delete", mock.Anything, sampleAttachment.StorageKey)
+}
+
+func (s *AttachmentUsecaseTestSuite) TestDelete_NotUploader() {
+	s.mockAttachments.On("FetchByID", mock.Anything, sampleAttachment.ID).Return(sampleAttachment, nil)
+
+	err := s.attachmentUsecase.Delete(s.ctx, sampleTask.ID, sampleAttachment.ID, viewerActor)
+	s.ErrorIs(err, domain.ErrNotAttachmentUploader)
+	s.mockAttachments.AssertNotCalled(s.T(), "Delete")
+}
+
+func (s *AttachmentUsecaseTestSuite) TestDeleteByTaskIDs_KeepsWhatCannotBeRemoved() {
+	kept := sampleAttachment
+	kept.ID = "attachment-id-456"
+	kept.StorageKey = "task-id-123/kept"
+	s.mockAttachments.On("FetchByTaskIDs", mock.Anything, []string{sampleTask.ID}).Return([]domain.Attachment{sampleAttachment, kept}, nil)
+	s.mockBlobs.On("Delete", mock.Anything, sampleAttachment.StorageKey).Return(nil)
+	s.mockBlobs.On("Delete", mock.Anything, kept.StorageKey).Return(errors.New("store down"))
+	s.mockAttachments.On("DeleteMany", mock.Anything, []string{sampleAttachment.ID}).Return(1, nil)
+
+	err := s.attachmentUsecase.DeleteByTaskIDs(s.ctx, []string{sampleTask.ID})
+	s.NoError(err)
+	s.mockAttachments.AssertExpectations(s.T())
+}
+
+func TestAttachmentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUsecaseTestSuite))
+}
@@ -0,0 +1,41 @@
+package usecases_test
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBlobStore is a mock implementation of the BlobStore interface
+// Put drains the content into Stored so tests can check what was written
+type MockBlobStore struct {
+	mock.Mock
+	Stored map[string][]byte
+}
+
+func (m *MockBlobStore) Put(c context.Context, key string, content io.Reader, size int64, contentType string) error {
+	args := m.Called(c, key, size, contentType)
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	if m.Stored == nil {
+		m.Stored = map[string][]byte{}
+	}
+	m.Stored[key] = data
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Get(c context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(c, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockBlobStore) Delete(c context.Context, key string) error {
+	args := m.Called(c, key)
+	return args.Error(0)
+}
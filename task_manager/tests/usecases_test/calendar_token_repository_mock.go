@@ -0,0 +1,28 @@
+package usecases_test
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCalendarTokenRepository is a mock implementation of the CalendarTokenRepository interface
+type MockCalendarTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarTokenRepository) Save(c context.Context, token *domain.CalendarToken) error {
+	args := m.Called(c, token)
+	return args.Error(0)
+}
+
+func (m *MockCalendarTokenRepository) FetchByHash(c context.Context, tokenHash string) (domain.CalendarToken, error) {
+	args := m.Called(c, tokenHash)
+	return args.Get(0).(domain.CalendarToken), args.Error(1)
+}
+
+func (m *MockCalendarTokenRepository) DeleteByUserID(c context.Context, userID string) (int, error) {
+	args := m.Called(c, userID)
+	return args.Int(0), args.Error(1)
+}
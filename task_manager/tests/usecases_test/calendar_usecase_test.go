@@ -0,0 +1,214 @@
+package usecases_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalendarUsecaseTestSuite struct {
+	suite.Suite
+	mockTokens      *MockCalendarTokenRepository
+	mockUsers       *MockUserRepository
+	mockRepo        *MockTaskRepository
+	mockProjects    *MockProjectRepository
+	calendarUsecase domain.CalendarUsecase
+	ctx             context.Context
+}
+
+func (s *CalendarUsecaseTestSuite) SetupTest() {
+	s.mockTokens = new(MockCalendarTokenRepository)
+	s.mockUsers = new(MockUserRepository)
+	s.mockRepo = new(MockTaskRepository)
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByMember", mock.Anything, ownerActor.ID).Return([]domain.Project{sampleProject}, nil).Maybe()
+	exporter := infrastructure.NewTaskExporter(infrastructure.NewSystemClock())
+	s.calendarUsecase = usecases.NewCalendarUsecase(s.mockTokens, s.mockUsers, s.mockRepo, s.mockProjects, exporter, time.Second*2)
+	s.ctx = context.Background()
+}
+
+// hashOf returns the hash a token is stored by
+func hashOf(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// versionsOf returns the versions the repository reports for tasks
+func versionsOf(tasks []domain.Task) []domain.TaskVersion {
+	versions := []domain.TaskVersion{}
+	for _, task := range tasks {
+		versions = append(versions, domain.TaskVersion{ID: task.ID, Version: task.Version})
+	}
+	return versions
+}
+
+// expectFeed makes token the feed token of the owner, whose feed streams tasks
+func (s *CalendarUsecaseTestSuite) expectFeed(token string, tasks ...domain.Task) {
+	s.mockTokens.On("FetchByHash", mock.Anything, hashOf(token)).Return(domain.CalendarToken{UserID: ownerActor.ID}, nil)
+	s.mockUsers.On("FetchByUserID", mock.Anything, ownerActor.ID).Return(domain.User{ID: ownerActor.ID}, nil)
+	isOwnerFeed := mock.MatchedBy(func(query domain.TaskQuery) bool {
+		return query.Involving == ownerActor.ID && len(query.ProjectIDs) == 1 && query.ProjectIDs[0] == sampleProject.ID
+	})
+	s.mockRepo.On("FetchVersions", mock.Anything, isOwnerFeed).Return(versionsOf(tasks), nil)
+	s.mockRepo.On("StreamTasks", mock.Anything, isOwnerFeed, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(task domain.Task) error)
+		for _, task := range tasks {
+			fn(task)
+		}
+	})
+}
+
+func (s *CalendarUsecaseTestSuite) TestIssueToken_StoresOnlyTheHash() {
+	var saved *domain.CalendarToken
+	s.mockTokens.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*domain.CalendarToken)
+	}).Return(nil).Twice()
+
+	token, err := s.calendarUsecase.IssueToken(s.ctx, ownerActor)
+	s.Require().NoError(err)
+	s.GreaterOrEqual(len(token), 43)
+	s.Equal(ownerActor.ID, saved.UserID)
+	s.Equal(hashOf(token), saved.TokenHash)
+	s.NotContains(saved.TokenHash, token)
+
+	again, err := s.calendarUsecase.IssueToken(s.ctx, ownerActor)
+	s.Require().NoError(err)
+	s.NotEqual(token, again)
+}
+
+func (s *CalendarUsecaseTestSuite) TestRevokeToken() {
+	s.mockTokens.On("DeleteByUserID", mock.Anything, ownerActor.ID).Return(1, nil).Once()
+	s.NoError(s.calendarUsecase.RevokeToken(s.ctx, ownerActor))
+
+	s.mockTokens.On("DeleteByUserID", mock.Anything, otherActor.ID).Return(0, nil).Once()
+	s.ErrorIs(s.calendarUsecase.RevokeToken(s.ctx, otherActor), domain.ErrCalendarTokenNotFound)
+}
+
+func (s *CalendarUsecaseTestSuite) TestFetchFeed() {
+	s.expectFeed("secret", sampleTask)
+
+	feed, err := s.calendarUsecase.FetchFeed(s.ctx, "secret", "")
+	s.Require().NoError(err)
+	s.False(feed.NotModified)
+	content := string(feed.Content)
+	s.True(strings.HasPrefix(content, "BEGIN:VCALENDAR\r\n"))
+	s.Contains(content, "UID:"+sampleTask.ID+"@")
+	s.Contains(content, "UID:"+sampleTask.ID+"-due@")
+	s.True(strings.HasPrefix(feed.ETag, `"`) && strings.HasSuffix(feed.ETag, `"`))
+}
+
+// Test the entity tag only changes with the tasks
+func (s *CalendarUsecaseTestSuite) TestFetchFeed_ETag() {
+	changed := sampleTask
+	changed.Tags = []string{"moved"}
+	changed.Version++
+	s.expectFeed("first", sampleTask)
+	s.mockTokens.On("FetchByHash", mock.Anything, hashOf("second")).Return(domain.CalendarToken{UserID: otherActor.ID}, nil)
+	s.mockUsers.On("FetchByUserID", mock.Anything, otherActor.ID).Return(domain.User{ID: otherActor.ID, IsAdmin: true}, nil)
+	isOtherFeed := mock.MatchedBy(func(query domain.TaskQuery) bool {
+		return query.Involving == otherActor.ID
+	})
+	s.mockRepo.On("FetchVersions", mock.Anything, isOtherFeed).Return(versionsOf([]domain.Task{changed}), nil)
+	s.mockRepo.On("StreamTasks", mock.Anything, isOtherFeed, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(2).(func(task domain.Task) error)(changed)
+	})
+
+	first, err := s.calendarUsecase.FetchFeed(s.ctx, "first", "")
+	s.Require().NoError(err)
+	again, err := s.calendarUsecase.FetchFeed(s.ctx, "first", "")
+	s.Require().NoError(err)
+	s.Equal(first.ETag, again.ETag)
+
+	other, err := s.calendarUsecase.FetchFeed(s.ctx, "second", "")
+	s.Require().NoError(err)
+	s.NotEqual(first.ETag, other.ETag)
+}
+
+// Test swapping a task for another at the same version changes the entity tag
+func (s *CalendarUsecaseTestSuite) TestFetchFeed_ETagSwap() {
+	kept := domain.TaskVersion{ID: "task-id-1", Version: 3}
+	s.mockTokens.On("FetchByHash", mock.Anything, hashOf("secret")).Return(domain.CalendarToken{UserID: ownerActor.ID}, nil)
+	s.mockUsers.On("FetchByUserID", mock.Anything, ownerActor.ID).Return(domain.User{ID: ownerActor.ID}, nil)
+	// unassigned from task-id-2 and assigned to task-id-3, the number of tasks and their versions stay the same
+	s.mockRepo.On("FetchVersions", mock.Anything, mock.Anything).Return([]domain.TaskVersion{kept, {ID: "task-id-2", Version: 2}}, nil).Once()
+	s.mockRepo.On("FetchVersions", mock.Anything, mock.Anything).Return([]domain.TaskVersion{kept, {ID: "task-id-3", Version: 2}}, nil).Once()
+	s.mockRepo.On("StreamTasks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	before, err := s.calendarUsecase.FetchFeed(s.ctx, "secret", "")
+	s.Require().NoError(err)
+	after, err := s.calendarUsecase.FetchFeed(s.ctx, "secret", before.ETag)
+	s.Require().NoError(err)
+	s.NotEqual(before.ETag, after.ETag)
+	s.False(after.NotModified)
+}
+
+// Test a client holding the current entity tag gets no feed, and the tasks are not streamed for it
+func (s *CalendarUsecaseTestSuite) TestFetchFeed_NotModified() {
+	s.expectFeed("secret", sampleTask)
+	current, err := s.calendarUsecase.FetchFeed(s.ctx, "secret", "")
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		notModified bool
+	}{
+		{"current tag", current.ETag, true},
+		{"weak tag in a list", `"old", W/` + current.ETag, true},
+		{"any tag", "*", true},
+		{"previous tag", `"old"`, false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			feed, err := s.calendarUsecase.FetchFeed(s.ctx, "secret", tt.ifNoneMatch)
+			s.Require().NoError(err)
+			s.Equal(current.ETag, feed.ETag)
+			s.Equal(tt.notModified, feed.NotModified)
+			s.Equal(tt.notModified, feed.Content == nil)
+		})
+	}
+	// the first fetch and the previous tag
+	s.mockRepo.AssertNumberOfCalls(s.T(), "StreamTasks", 2)
+}
+
+func (s *CalendarUsecaseTestSuite) TestFetchFeed_Rejected() {
+	dbErr := errors.New("db error")
+	s.mockTokens.On("FetchByHash", mock.Anything, hashOf("unknown")).Return(domain.CalendarToken{}, domain.ErrCalendarTokenNotFound)
+	s.mockTokens.On("FetchByHash", mock.Anything, hashOf("orphan")).Return(domain.CalendarToken{UserID: "deleted-user"}, nil)
+	s.mockUsers.On("FetchByUserID", mock.Anything, "deleted-user").Return(domain.User{}, domain.ErrUserNotFound)
+	s.mockTokens.On("FetchByHash", mock.Anything, hashOf("broken")).Return(domain.CalendarToken{}, dbErr)
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"unknown token", "unknown", domain.ErrCalendarTokenNotFound},
+		{"deleted user", "orphan", domain.ErrCalendarTokenNotFound},
+		{"data store error", "broken", dbErr},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.calendarUsecase.FetchFeed(s.ctx, tt.token, "")
+			s.ErrorIs(err, tt.err)
+		})
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "FetchVersions", mock.Anything, mock.Anything)
+	s.mockRepo.AssertNotCalled(s.T(), "StreamTasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCalendarUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarUsecaseTestSuite))
+}
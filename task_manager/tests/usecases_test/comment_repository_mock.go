@@ -0,0 +1,44 @@
+package usecases_test
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCommentRepository is a mock implementation of the CommentRepository interface
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(c context.Context, comment *domain.Comment) error {
+	args := m.Called(c, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) FetchByID(c context.Context, commentID string) (domain.Comment, error) {
+	args := m.Called(c, commentID)
+	return args.Get(0).(domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FetchByTaskID(c context.Context, query domain.CommentQuery) ([]domain.Comment, error) {
+	args := m.Called(c, query)
+	return args.Get(0).([]domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) UpdateBody(c context.Context, commentID string, body string, editedAt time.Time) (int, error) {
+	args := m.Called(c, commentID, body, editedAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) Delete(c context.Context, commentID string) (int, error) {
+	args := m.Called(c, commentID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) DeleteByTaskIDs(c context.Context, taskIDs []string) (int, error) {
+	args := m.Called(c, taskIDs)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,166 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommentUsecaseTestSuite struct {
+	suite.Suite
+	mockComments   *MockCommentRepository
+	mockTasks      *MockTaskRepository
+	mockProjects   *MockProjectRepository
+	cursors        domain.CursorService
+	commentUsecase domain.CommentUsecase
+	ctx            context.Context
+}
+
+func (s *CommentUsecaseTestSuite) SetupTest() {
+	s.mockComments = new(MockCommentRepository)
+	s.mockTasks = new(MockTaskRepository)
+	s.mockTasks.On("FetchByTaskID", mock.Anything, sampleTask.ID).Return(sampleTask, nil).Maybe()
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	s.cursors = infrastructure.NewCursorService("test-secret")
+	s.commentUsecase = usecases.NewCommentUsecase(s.mockComments, s.mockTasks, s.mockProjects, s.cursors, time.Second*2)
+	s.ctx = context.Background()
+}
+
+var sampleComment = domain.Comment{
+	ID:        "comment-id-123",
+	TaskID:    "task-id-123",
+	AuthorID:  "owner-id-123",
+	Body:      "Looks good to me",
+	CreatedAt: time.Now(),
+}
+
+func (s *CommentUsecaseTestSuite) TestCreate_Success() {
+	s.mockComments.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil)
+
+	comment, err := s.commentUsecase.Create(s.ctx, sampleTask.ID, "  Looks good to me  ", ownerActor)
+	s.NoError(err)
+	s.Equal("Looks good to me", comment.Body)
+	s.Equal(ownerActor.ID, comment.AuthorID)
+	s.Equal(sampleTask.ID, comment.TaskID)
+	s.False(comment.CreatedAt.IsZero())
+}
+
+func (s *CommentUsecaseTestSuite) TestCreate_InvalidBody() {
+	s.mockComments.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil)
+
+	_, err := s.commentUsecase.Create(s.ctx, sampleTask.ID, "   ", ownerActor)
+	s.ErrorIs(err, domain.ErrEmptyComment)
+
+	_, err = s.commentUsecase.Create(s.ctx, sampleTask.ID, strings.Repeat("é", domain.MaxCommentLength+1), ownerActor)
+	s.ErrorIs(err, domain.ErrCommentTooLong)
+
+	_, err = s.commentUsecase.Create(s.ctx, sampleTask.ID, strings.Repeat("é", domain.MaxCommentLength), ownerActor)
+	s.NoError(err)
+	s.mockComments.AssertNumberOfCalls(s.T(), "Create", 1)
+}
+
+func (s *CommentUsecaseTestSuite) TestCreate_TaskNotVisible() {
+	_, err := s.commentUsecase.Create(s.ctx, sampleTask.ID, "Hello", otherActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+	s.mockComments.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *CommentUsecaseTestSuite) TestFetchByTaskID_Paginates() {
+	second := sampleComment
+	second.ID = "comment-id-456"
+	third := sampleComment
+	third.ID = "comment-id-789"
+
+	query := domain.CommentQuery{TaskID: sampleTask.ID, Limit: 3}
+	s.mockComments.On("FetchByTaskID", mock.Anything, query).Return([]domain.Comment{sampleComment, second, third}, nil).Once()
+
+	page, err := s.commentUsecase.FetchByTaskID(s.ctx, domain.CommentQuery{TaskID: sampleTask.ID, Limit: 2}, adminActor)
+	s.NoError(err)
+	s.Len(page.Comments, 2)
+	s.NotEmpty(page.NextCursor)
+
+	cursor, err := s.cursors.Decode(page.NextCursor)
+	s.NoError(err)
+	s.Equal(second.ID, cursor.ID)
+
+	// the next page starts after the last comment of the previous one
+	next := domain.CommentQuery{TaskID: sampleTask.ID, Limit: 3, Cursor: page.NextCursor, After: &cursor}
+	s.mockComments.On("FetchByTaskID", mock.Anything, next).Return([]domain.Comment{third}, nil).Once()
+
+	page, err = s.commentUsecase.FetchByTaskID(s.ctx, domain.CommentQuery{TaskID: sampleTask.ID, Limit: 2, Cursor: page.NextCursor}, adminActor)
+	s.NoError(err)
+	s.Len(page.Comments, 1)
+	s.Empty(page.NextCursor)
+}
+
+func (s *CommentUsecaseTestSuite) TestFetchByTaskID_InvalidCursor() {
+	_, err := s.commentUsecase.FetchByTaskID(s.ctx, domain.CommentQuery{TaskID: sampleTask.ID, Cursor: "bogus"}, ownerActor)
+	s.ErrorIs(err, domain.ErrInvalidCursor)
+	s.mockComments.AssertNotCalled(s.T(), "FetchByTaskID")
+}
+
+func (s *CommentUsecaseTestSuite) TestUpdate_ByAuthor() {
+	s.mockComments.On("FetchByID", mock.Anything, sampleComment.ID).Return(sampleComment, nil)
+	s.mockComments.On("UpdateBody", mock.Anything, sampleComment.ID, "Edited", mock.AnythingOfType("time.Time")).Return(1, nil)
+
+	comment, err := s.commentUsecase.Update(s.ctx, sampleTask.ID, sampleComment.ID, "Edited", ownerActor)
+	s.NoError(err)
+	s.Equal("Edited", comment.Body)
+	s.NotNil(comment.EditedAt)
+}
+
+func (s *CommentUsecaseTestSuite) TestUpdate_ByAdmin() {
+	s.mockComments.On("FetchByID", mock.Anything, sampleComment.ID).Return(sampleComment, nil)
+	s.mockComments.On("UpdateBody", mock.Anything, sampleComment.ID, "Edited", mock.AnythingOfType("time.Time")).Return(1, nil)
+
+	_, err := s.commentUsecase.Update(s.ctx, sampleTask.ID, sampleComment.ID, "Edited", adminActor)
+	s.NoError(err)
+}
+
+func (s *CommentUsecaseTestSuite) TestUpdate_NotAuthor() {
+	// a viewer may see the task and comment on it, but not edit the owner's comments
+	s.mockComments.On("FetchByID", mock.Anything, sampleComment.ID).Return(sampleComment, nil)
+
+	_, err := s.commentUsecase.Update(s.ctx, sampleTask.ID, sampleComment.ID, "Edited", viewerActor)
+	s.ErrorIs(err, domain.ErrNotCommentAuthor)
+	s.mockComments.AssertNotCalled(s.T(), "UpdateBody")
+}
+
+func (s *CommentUsecaseTestSuite) TestUpdate_CommentOfAnotherTask() {
+	comment := sampleComment
+	comment.TaskID = "task-id-456"
+	s.mockComments.On("FetchByID", mock.Anything, comment.ID).Return(comment, nil)
+
+	_, err := s.commentUsecase.Update(s.ctx, sampleTask.ID, comment.ID, "Edited", ownerActor)
+	s.ErrorIs(err, domain.ErrCommentNotFound)
+	s.mockComments.AssertNotCalled(s.T(), "UpdateBody")
+}
+
+func (s *CommentUsecaseTestSuite) TestDelete_ByAuthor() {
+	s.mockComments.On("FetchByID", mock.Anything, sampleComment.ID).Return(sampleComment, nil)
+	s.mockComments.On("Delete", mock.Anything, sampleComment.ID).Return(1, nil)
+
+	err := s.commentUsecase.Delete(s.ctx, sampleTask.ID, sampleComment.ID, ownerActor)
+	s.NoError(err)
+	s.mockComments.AssertCalled(s.T(), "Delete", mock.Anything, sampleComment.ID)
+}
+
+func (s *CommentUsecaseTestSuite) TestDelete_AlreadyDeleted() {
+	s.mockComments.On("FetchByID", mock.Anything, sampleComment.ID).Return(domain.Comment{}, domain.ErrCommentNotFound)
+
+	err := s.commentUsecase.Delete(s.ctx, sampleTask.ID, sampleComment.ID, ownerActor)
+	s.ErrorIs(err, domain.ErrCommentNotFound)
+	s.mockComments.AssertNotCalled(s.T(), "Delete")
+}
+
+func TestCommentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentUsecaseTestSuite))
+}
@@ -0,0 +1,53 @@
+package usecases_test
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectRepository is a mock implementation of the ProjectRepository interface
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) Create(c context.Context, project *domain.Project) error {
+	args := m.Called(c, project)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FetchByID(c context.Context, projectID string) (domain.Project, error) {
+	args := m.Called(c, projectID)
+	return args.Get(0).(domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FetchAll(c context.Context) ([]domain.Project, error) {
+	args := m.Called(c)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FetchByMember(c context.Context, userID string) ([]domain.Project, error) {
+	args := m.Called(c, userID)
+	return args.Get(0).([]domain.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Update(c context.Context, project *domain.Project) (int, error) {
+	args := m.Called(c, project)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) AddMember(c context.Context, projectID string, member domain.ProjectMember) (int, error) {
+	args := m.Called(c, projectID, member)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) UpdateMemberRole(c context.Context, projectID string, userID string, role domain.ProjectRole) (int, error) {
+	args := m.Called(c, projectID, userID, role)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) RemoveMember(c context.Context, projectID string, userID string) (int, error) {
+	args := m.Called(c, projectID, userID)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,143 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProjectUsecaseTestSuite struct {
+	suite.Suite
+	mockProjects   *MockProjectRepository
+	mockUsers      *MockUserRepository
+	mockTasks      *MockTaskRepository
+	projectUsecase domain.ProjectUsecase
+	ctx            context.Context
+}
+
+func (s *ProjectUsecaseTestSuite) SetupTest() {
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	s.mockUsers = new(MockUserRepository)
+	s.mockTasks = new(MockTaskRepository)
+	s.projectUsecase = usecases.NewProjectUsecase(s.mockProjects, s.mockUsers, s.mockTasks, time.Second*2)
+	s.ctx = context.Background()
+}
+
+func (s *ProjectUsecaseTestSuite) TestCreate_ActorOwnsProject() {
+	s.mockProjects.On("Create", mock.Anything, mock.AnythingOfType("*domain.Project")).Return(nil)
+
+	project := domain.Project{Name: "  Launch  ", Description: " Q3 launch "}
+	err := s.projectUsecase.Create(s.ctx, &project, otherActor)
+	s.Require().NoError(err)
+	s.Equal("Launch", project.Name)
+	s.Equal("Q3 launch", project.Description)
+	s.Equal([]domain.ProjectMember{{UserID: otherActor.ID, Role: domain.ProjectRoleOwner}}, project.Members)
+	s.False(project.CreatedAt.IsZero())
+}
+
+func (s *ProjectUsecaseTestSuite) TestCreate_InvalidName() {
+	for _, name := range []string{"   ", strings.Repeat("é", domain.MaxProjectNameLength+1)} {
+		project := domain.Project{Name: name}
+		err := s.projectUsecase.Create(s.ctx, &project, ownerActor)
+		s.ErrorIs(err, domain.ErrInvalidProjectName)
+	}
+	s.mockProjects.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *ProjectUsecaseTestSuite) TestFetchByID_NotMember() {
+	_, err := s.projectUsecase.FetchByID(s.ctx, sampleProject.ID, otherActor)
+	s.ErrorIs(err, domain.ErrProjectNotFound)
+
+	project, err := s.projectUsecase.FetchByID(s.ctx, sampleProject.ID, adminActor)
+	s.NoError(err)
+	s.Equal(sampleProject.ID, project.ID)
+}
+
+func (s *ProjectUsecaseTestSuite) TestFetchAll_MemberProjects() {
+	s.mockProjects.On("FetchByMember", mock.Anything, viewerActor.ID).Return([]domain.Project{sampleProject}, nil)
+	s.mockProjects.On("FetchAll", mock.Anything).Return([]domain.Project{sampleProject, {ID: "project-id-456"}}, nil)
+
+	projects, err := s.projectUsecase.FetchAll(s.ctx, viewerActor)
+	s.NoError(err)
+	s.Len(projects, 1)
+
+	projects, err = s.projectUsecase.FetchAll(s.ctx, adminActor)
+	s.NoError(err)
+	s.Len(projects, 2)
+}
+
+func (s *ProjectUsecaseTestSuite) TestUpdate_ViewerCannotManage() {
+	project := domain.Project{ID: sampleProject.ID, Name: "Renamed"}
+	err := s.projectUsecase.Update(s.ctx, &project, viewerActor)
+	s.ErrorIs(err, domain.ErrNotProjectOwner)
+	s.mockProjects.AssertNotCalled(s.T(), "Update")
+}
+
+func (s *ProjectUsecaseTestSuite) TestAddMember_Success() {
+	s.mockUsers.On("FetchByUserID", mock.Anything, otherActor.ID).Return(domain.User{ID: otherActor.ID}, nil)
+	member := domain.ProjectMember{UserID: otherActor.ID, Role: domain.ProjectRoleEditor}
+	s.mockProjects.On("AddMember", mock.Anything, sampleProject.ID, member).Return(1, nil)
+
+	err := s.projectUsecase.AddMember(s.ctx, sampleProject.ID, domain.ProjectMember{UserID: otherActor.ID, Role: " Editor "}, ownerActor)
+	s.NoError(err)
+	s.mockProjects.AssertCalled(s.T(), "AddMember", mock.Anything, sampleProject.ID, member)
+}
+
+func (s *ProjectUsecaseTestSuite) TestAddMember_Rejected() {
+	err := s.projectUsecase.AddMember(s.ctx, sampleProject.ID, domain.ProjectMember{UserID: otherActor.ID, Role: "guest"}, ownerActor)
+	s.ErrorIs(err, domain.ErrInvalidProjectRole)
+
+	err = s.projectUsecase.AddMember(s.ctx, sampleProject.ID, domain.ProjectMember{UserID: viewerActor.ID, Role: domain.ProjectRoleEditor}, ownerActor)
+	s.ErrorIs(err, domain.ErrProjectMemberExists)
+
+	s.mockUsers.On("FetchByUserID", mock.Anything, "missing-id").Return(domain.User{}, domain.ErrUserNotFound)
+	err = s.projectUsecase.AddMember(s.ctx, sampleProject.ID, domain.ProjectMember{UserID: "missing-id", Role: domain.ProjectRoleViewer}, ownerActor)
+	s.ErrorIs(err, domain.ErrUserNotFound)
+	s.mockProjects.AssertNotCalled(s.T(), "AddMember")
+}
+
+func (s *ProjectUsecaseTestSuite) TestUpdateMemberRole_LastOwner() {
+	err := s.projectUsecase.UpdateMemberRole(s.ctx, sampleProject.ID, ownerActor.ID, domain.ProjectRoleEditor, adminActor)
+	s.ErrorIs(err, domain.ErrLastProjectOwner)
+	s.mockProjects.AssertNotCalled(s.T(), "UpdateMemberRole")
+}
+
+func (s *ProjectUsecaseTestSuite) TestUpdateMemberRole_Success() {
+	s.mockProjects.On("UpdateMemberRole", mock.Anything, sampleProject.ID, viewerActor.ID, domain.ProjectRoleOwner).Return(1, nil)
+
+	err := s.projectUsecase.UpdateMemberRole(s.ctx, sampleProject.ID, viewerActor.ID, domain.ProjectRoleOwner, ownerActor)
+	s.NoError(err)
+}
+
+func (s *ProjectUsecaseTestSuite) TestRemoveMember_Leave() {
+	// a viewer cannot manage the project but may leave it, dropping their assignments
+	s.mockProjects.On("RemoveMember", mock.Anything, sampleProject.ID, viewerActor.ID).Return(1, nil)
+	s.mockTasks.On("UnassignFromProject", mock.Anything, sampleProject.ID, viewerActor.ID).Return(2, nil)
+
+	err := s.projectUsecase.RemoveMember(s.ctx, sampleProject.ID, viewerActor.ID, viewerActor)
+	s.NoError(err)
+	s.mockTasks.AssertCalled(s.T(), "UnassignFromProject", mock.Anything, sampleProject.ID, viewerActor.ID)
+}
+
+func (s *ProjectUsecaseTestSuite) TestRemoveMember_Rejected() {
+	err := s.projectUsecase.RemoveMember(s.ctx, sampleProject.ID, ownerActor.ID, viewerActor)
+	s.ErrorIs(err, domain.ErrNotProjectOwner)
+
+	err = s.projectUsecase.RemoveMember(s.ctx, sampleProject.ID, ownerActor.ID, ownerActor)
+	s.ErrorIs(err, domain.ErrLastProjectOwner)
+
+	err = s.projectUsecase.RemoveMember(s.ctx, sampleProject.ID, otherActor.ID, ownerActor)
+	s.ErrorIs(err, domain.ErrNotProjectMember)
+	s.mockProjects.AssertNotCalled(s.T(), "RemoveMember")
+}
+
+func TestProjectUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectUsecaseTestSuite))
+}
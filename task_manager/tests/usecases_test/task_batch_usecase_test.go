@@ -0,0 +1,171 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskBatchUsecaseTestSuite struct {
+	suite.Suite
+	mockRepo       *MockTaskRepository
+	mockProjects   *MockProjectRepository
+	mockTransactor *MockTransactor
+	batchUsecase   domain.TaskBatchUsecase
+	ctx            context.Context
+}
+
+func (s *TaskBatchUsecaseTestSuite) SetupTest() {
+	s.mockRepo = new(MockTaskRepository)
+	s.mockRepo.On("FetchSubtasks", mock.Anything, mock.Anything).Return([]domain.Task{}, nil).Maybe()
+	s.mockRepo.On("FetchByTaskID", mock.Anything, sampleTask.ID).Return(sampleTask, nil).Maybe()
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "missing-id").Return(domain.Task{}, domain.ErrTaskNotFound).Maybe()
+	history := new(MockTaskHistoryRepository)
+	history.On("Append", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	events := new(MockTaskEventPublisher)
+	events.On("Publish", mock.Anything, mock.Anything).Return(nil).Maybe()
+	taskUsecase := usecases.NewTaskUsecase(s.mockRepo, new(MockUserRepository), history, s.mockProjects, events, new(MockAttachmentUsecase), new(MockCommentRepository),
+		infrastructure.NewCursorService("test-secret"), trashRetention, time.Second*2)
+	s.mockTransactor = new(MockTransactor)
+	s.batchUsecase = usecases.NewTaskBatchUsecase(taskUsecase, s.mockTransactor, time.Second*2, time.Minute)
+	s.ctx = context.Background()
+}
+
+// batchOperations creates a task, renames sampleTask and deletes the task whose ID is given
+func batchOperations(deletedID string) []domain.BatchOperation {
+	title := "Renamed"
+	created := domain.Task{ProjectID: sampleProject.ID, Title: "Imported", DueDate: time.Now().Add(time.Hour), Status: domain.StatusPending, OwnerID: ownerActor.ID}
+	return []domain.BatchOperation{
+		{Op: domain.BatchCreate, Task: created},
+		{Op: domain.BatchUpdate, TaskID: sampleTask.ID, Patch: domain.TaskPatch{Title: &title}},
+		{Op: domain.BatchDelete, TaskID: deletedID},
+	}
+}
+
+func (s *TaskBatchUsecaseTestSuite) expectCreate() {
+	s.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Task")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Task).ID = "new-id"
+	}).Return(nil).Once()
+}
+
+func (s *TaskBatchUsecaseTestSuite) TestExecute_ReportsEachOperation() {
+	s.expectCreate()
+	s.mockRepo.On("PatchByTaskID", mock.Anything, sampleTask.ID, mock.Anything, mock.Anything).Return(1, 1, nil).Once()
+
+	results, err := s.batchUsecase.Execute(s.ctx, batchOperations("missing-id"), false, ownerActor)
+	s.Require().NoError(err)
+	s.Require().Len(results, 3)
+
+	s.NoError(results[0].Err)
+	s.Equal("new-id", results[0].TaskID)
+	s.Equal("Imported", results[0].Task.Title)
+	s.NoError(results[1].Err)
+	s.NotNil(results[1].Task)
+	s.ErrorIs(results[2].Err, domain.ErrTaskNotFound)
+	s.Equal(2, results[2].Index)
+	s.mockTransactor.AssertNotCalled(s.T(), "WithTransaction", mock.Anything)
+}
+
+func (s *TaskBatchUsecaseTestSuite) TestExecute_AtomicCommits() {
+	// the transaction as a whole runs under a deadline
+	s.mockTransactor.On("WithTransaction", mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})).Return(nil).Once()
+	s.expectCreate()
+	s.mockRepo.On("PatchByTaskID", mock.Anything, sampleTask.ID, mock.Anything, mock.Anything).Return(1, 1, nil).Once()
+	s.mockRepo.On("DeleteByTaskID", mock.Anything, sampleTask.ID, 0).Return(1, nil).Once()
+
+	results, err := s.batchUsecase.Execute(s.ctx, batchOperations(sampleTask.ID), true, ownerActor)
+	s.Require().NoError(err)
+	for _, result := range results {
+		s.NoError(result.Err)
+	}
+	s.mockTransactor.AssertExpectations(s.T())
+}
+
+// Test a batch of the largest size gets more time than one operation, up to the batch timeout
+func (s *TaskBatchUsecaseTestSuite) TestExecute_AtomicDeadline() {
+	taskUsecase := usecases.NewTaskUsecase(s.mockRepo, new(MockUserRepository), new(MockTaskHistoryRepository), s.mockProjects, new(MockTaskEventPublisher), new(MockAttachmentUsecase), new(MockCommentRepository),
+		infrastructure.NewCursorService("test-secret"), trashRetention, time.Second*2)
+	title := sampleTask.Title
+	operations := make([]domain.BatchOperation, domain.MaxBatchSize)
+	for i := range operations {
+		operations[i] = domain.BatchOperation{Op: domain.BatchUpdate, TaskID: sampleTask.ID, Patch: domain.TaskPatch{Title: &title}}
+	}
+	// every operation is a no-op patch taking a millisecond, far more than the 20ms of one operation in total
+	expired := 0
+	s.mockRepo.On("PatchByTaskID", mock.Anything, sampleTask.ID, mock.Anything, mock.Anything).After(time.Millisecond).Run(func(args mock.Arguments) {
+		if args.Get(0).(context.Context).Err() != nil {
+			expired++
+		}
+	}).Return(1, 0, nil)
+
+	var deadline time.Time
+	s.mockTransactor.On("WithTransaction", mock.Anything).Run(func(args mock.Arguments) {
+		deadline, _ = args.Get(0).(context.Context).Deadline()
+	}).Return(nil)
+
+	batchUsecase := usecases.NewTaskBatchUsecase(taskUsecase, s.mockTransactor, 20*time.Millisecond, time.Minute)
+	start := time.Now()
+	results, err := batchUsecase.Execute(s.ctx, operations, true, ownerActor)
+	s.Require().NoError(err)
+	s.Len(results, domain.MaxBatchSize)
+	s.Zero(expired)
+	s.WithinDuration(start.Add(10*time.Second), deadline, time.Second)
+
+	// capped by the batch timeout
+	batchUsecase = usecases.NewTaskBatchUsecase(taskUsecase, s.mockTransactor, time.Second*2, 3*time.Second)
+	start = time.Now()
+	_, err = batchUsecase.Execute(s.ctx, operations[:10], true, ownerActor)
+	s.Require().NoError(err)
+	s.WithinDuration(start.Add(3*time.Second), deadline, time.Second)
+}
+
+func (s *TaskBatchUsecaseTestSuite) TestExecute_AtomicRollsBack() {
+	s.mockTransactor.On("WithTransaction", mock.Anything).Return(nil).Once()
+	s.expectCreate()
+
+	operations := batchOperations(sampleTask.ID)
+	// the update fails, so the delete after it never runs
+	operations[1].TaskID = "missing-id"
+
+	results, err := s.batchUsecase.Execute(s.ctx, operations, true, ownerActor)
+	s.ErrorIs(err, domain.ErrBatchRolledBack)
+	s.Require().Len(results, 3)
+	s.ErrorIs(results[0].Err, domain.ErrBatchAborted)
+	s.Nil(results[0].Task)
+	s.ErrorIs(results[1].Err, domain.ErrTaskNotFound)
+	s.ErrorIs(results[2].Err, domain.ErrBatchAborted)
+	s.mockRepo.AssertNotCalled(s.T(), "DeleteByTaskID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskBatchUsecaseTestSuite) TestExecute_AtomicUnsupported() {
+	s.mockTransactor.On("WithTransaction", mock.Anything).Return(domain.ErrTransactionsUnsupported).Once()
+
+	results, err := s.batchUsecase.Execute(s.ctx, batchOperations(sampleTask.ID), true, ownerActor)
+	s.ErrorIs(err, domain.ErrTransactionsUnsupported)
+	s.Nil(results)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskBatchUsecaseTestSuite) TestExecute_Size() {
+	_, err := s.batchUsecase.Execute(s.ctx, nil, false, ownerActor)
+	s.ErrorIs(err, domain.ErrEmptyBatch)
+
+	operations := make([]domain.BatchOperation, domain.MaxBatchSize+1)
+	_, err = s.batchUsecase.Execute(s.ctx, operations, false, ownerActor)
+	s.ErrorIs(err, domain.ErrBatchTooLarge)
+}
+
+func TestTaskBatchUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskBatchUsecaseTestSuite))
+}
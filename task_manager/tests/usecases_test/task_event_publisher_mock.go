@@ -0,0 +1,18 @@
+package usecases_test
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskEventPublisher is a mock implementation of the TaskEventPublisher interface
+type MockTaskEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventPublisher) Publish(c context.Context, event domain.TaskEvent) error {
+	args := m.Called(c, event)
+	return args.Error(0)
+}
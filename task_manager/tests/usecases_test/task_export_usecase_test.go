@@ -0,0 +1,118 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskExportUsecaseTestSuite struct {
+	suite.Suite
+	mockRepo      *MockTaskRepository
+	mockProjects  *MockProjectRepository
+	exportUsecase domain.TaskExportUsecase
+	ctx           context.Context
+}
+
+func (s *TaskExportUsecaseTestSuite) SetupTest() {
+	s.mockRepo = new(MockTaskRepository)
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	s.mockProjects.On("FetchByMember", mock.Anything, ownerActor.ID).Return([]domain.Project{sampleProject}, nil).Maybe()
+	exporter := infrastructure.NewTaskExporter(infrastructure.NewSystemClock())
+	s.exportUsecase = usecases.NewTaskExportUsecase(s.mockRepo, s.mockProjects, exporter, time.Second*2)
+	s.ctx = context.Background()
+}
+
+// expectStream streams the tasks to the callback of StreamTasks for queries matching match
+func (s *TaskExportUsecaseTestSuite) expectStream(match func(query domain.TaskQuery) bool, tasks ...domain.Task) {
+	s.mockRepo.On("StreamTasks", mock.Anything, mock.MatchedBy(match), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(task domain.Task) error)
+		for _, task := range tasks {
+			if err := fn(task); err != nil {
+				return
+			}
+		}
+	}).Once()
+}
+
+func (s *TaskExportUsecaseTestSuite) TestExport_MemberProjects() {
+	s.expectStream(func(query domain.TaskQuery) bool {
+		return len(query.ProjectIDs) == 1 && query.ProjectIDs[0] == sampleProject.ID &&
+			query.Status == domain.StatusPending && query.SortBy == domain.TaskSortByDueDate
+	}, sampleTask)
+
+	var out bytes.Buffer
+	query := domain.TaskQuery{Status: "Pending", SortBy: domain.TaskSortByDueDate}
+	err := s.exportUsecase.Export(s.ctx, query, domain.ExportJSONL, &out, ownerActor)
+	s.Require().NoError(err)
+	s.Equal(1, strings.Count(out.String(), "\n"))
+	s.Contains(out.String(), sampleTask.ID)
+}
+
+func (s *TaskExportUsecaseTestSuite) TestExport_AdminSeesEveryProject() {
+	s.expectStream(func(query domain.TaskQuery) bool { return query.ProjectIDs == nil }, sampleTask)
+
+	var out bytes.Buffer
+	err := s.exportUsecase.Export(s.ctx, domain.TaskQuery{}, domain.ExportICS, &out, adminActor)
+	s.Require().NoError(err)
+	s.Contains(out.String(), "UID:"+sampleTask.ID+"@")
+}
+
+func (s *TaskExportUsecaseTestSuite) TestExport_IgnoresPagination() {
+	s.expectStream(func(query domain.TaskQuery) bool { return query.Offset == 0 && query.Cursor == "" })
+
+	var out bytes.Buffer
+	query := domain.TaskQuery{ProjectID: sampleProject.ID, Offset: 40, Cursor: "token"}
+	err := s.exportUsecase.Export(s.ctx, query, domain.ExportCSV, &out, ownerActor)
+	s.Require().NoError(err)
+	// only the header
+	s.Equal(1, strings.Count(out.String(), "\n"))
+}
+
+func (s *TaskExportUsecaseTestSuite) TestExport_Rejected() {
+	tests := []struct {
+		name  string
+		query domain.TaskQuery
+		actor domain.Actor
+		err   error
+	}{
+		{"invalid status", domain.TaskQuery{Status: "started"}, ownerActor, domain.ErrInvalidQuery},
+		{"invalid sort", domain.TaskQuery{SortBy: "owner"}, ownerActor, domain.ErrInvalidQuery},
+		{"not a member", domain.TaskQuery{ProjectID: sampleProject.ID}, otherActor, domain.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var out bytes.Buffer
+			err := s.exportUsecase.Export(s.ctx, tt.query, domain.ExportCSV, &out, tt.actor)
+			s.ErrorIs(err, tt.err)
+			s.Zero(out.Len())
+		})
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "StreamTasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskExportUsecaseTestSuite) TestExport_StreamFails() {
+	dbErr := errors.New("cursor killed")
+	s.mockRepo.On("StreamTasks", mock.Anything, mock.Anything, mock.Anything).Return(dbErr).Once()
+
+	var out bytes.Buffer
+	err := s.exportUsecase.Export(s.ctx, domain.TaskQuery{}, domain.ExportICS, &out, ownerActor)
+	s.ErrorIs(err, dbErr)
+	// the calendar is left unterminated
+	s.NotContains(out.String(), "END:VCALENDAR")
+}
+
+func TestTaskExportUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskExportUsecaseTestSuite))
+}
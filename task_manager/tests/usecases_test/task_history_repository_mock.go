@@ -0,0 +1,23 @@
+package usecases_test
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskHistoryRepository is a mock implementation of the TaskHistoryRepository interface
+type MockTaskHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskHistoryRepository) Append(c context.Context, entry *domain.TaskHistoryEntry) error {
+	args := m.Called(c, entry)
+	return args.Error(0)
+}
+
+func (m *MockTaskHistoryRepository) FetchByTaskID(c context.Context, taskID string) ([]domain.TaskHistoryEntry, error) {
+	args := m.Called(c, taskID)
+	return args.Get(0).([]domain.TaskHistoryEntry), args.Error(1)
+}
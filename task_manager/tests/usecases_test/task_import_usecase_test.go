@@ -0,0 +1,210 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskImportUsecaseTestSuite struct {
+	suite.Suite
+	mockRepo      *MockTaskRepository
+	mockHistory   *MockTaskHistoryRepository
+	mockProjects  *MockProjectRepository
+	mockEvents    *MockTaskEventPublisher
+	importUsecase domain.TaskImportUsecase
+	ctx           context.Context
+}
+
+func (s *TaskImportUsecaseTestSuite) SetupTest() {
+	s.mockRepo = new(MockTaskRepository)
+	s.mockHistory = new(MockTaskHistoryRepository)
+	s.mockHistory.On("Append", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	s.mockProjects.On("FetchByID", mock.Anything, "missing-project").Return(domain.Project{}, domain.ErrProjectNotFound).Maybe()
+	s.mockEvents = new(MockTaskEventPublisher)
+	s.mockEvents.On("Publish", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.importUsecase = usecases.NewTaskImportUsecase(s.mockRepo, s.mockHistory, s.mockProjects, s.mockEvents, time.Second*2)
+	s.ctx = context.Background()
+}
+
+// importDay is a due date in the future, in the date-only form accepted by imports
+func importDay() string {
+	return time.Now().AddDate(0, 0, 7).Format(time.DateOnly)
+}
+
+// importCSV is a file with a valid row, a row due in the past, a row with a missing project and another valid row
+func importCSV() string {
+	return "title,project_id,due_date,status,priority,tags\n" +
+		"Write report," + sampleProject.ID + "," + importDay() + ",pending,high,\"work, docs\"\n" +
+		"Old task," + sampleProject.ID + ",2000-01-01,pending,,\n" +
+		"Lost task,missing-project," + importDay() + ",pending,,\n" +
+		"Review report," + sampleProject.ID + "," + time.Now().Add(time.Hour).Format(time.RFC3339) + ",Pending,,\n"
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_DryRunReportsRows() {
+	report, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(importCSV()), true, ownerActor)
+	s.Require().NoError(err)
+
+	s.True(report.DryRun)
+	s.Equal(2, report.Valid)
+	s.Equal(2, report.Invalid)
+	s.Equal(0, report.Imported)
+	s.Require().Len(report.Rows, 4)
+	s.NoError(report.Rows[0].Err)
+	s.Equal(1, report.Rows[0].Row)
+	s.Equal("Write report", report.Rows[0].Title)
+	s.ErrorIs(report.Rows[1].Err, domain.ErrInvalidDueDate)
+	s.ErrorIs(report.Rows[2].Err, domain.ErrProjectNotFound)
+	s.NoError(report.Rows[3].Err)
+	s.Empty(report.Rows[0].TaskID)
+	s.mockRepo.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_CommitsValidRows() {
+	var created []*domain.Task
+	s.mockRepo.On("CreateMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).([]*domain.Task)
+		for i, task := range created {
+			task.ID = []string{"id-1", "id-2"}[i]
+		}
+	}).Return(nil).Once()
+
+	report, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(importCSV()), false, ownerActor)
+	s.Require().NoError(err)
+
+	s.False(report.DryRun)
+	s.Equal(2, report.Imported)
+	s.Equal("id-1", report.Rows[0].TaskID)
+	s.Empty(report.Rows[1].TaskID)
+	s.Equal("id-2", report.Rows[3].TaskID)
+
+	s.Require().Len(created, 2)
+	s.Equal(ownerActor.ID, created[0].OwnerID)
+	s.Equal(domain.PriorityHigh, created[0].Priority)
+	s.Equal([]string{"work", "docs"}, created[0].Tags)
+	s.Equal(23, created[0].DueDate.Hour())
+	s.Equal(domain.StatusPending, created[1].Status)
+	s.Equal(domain.DefaultPriority, created[1].Priority)
+	s.mockHistory.AssertNumberOfCalls(s.T(), "Append", 2)
+	// the project is looked up once for all of its rows
+	s.mockProjects.AssertNumberOfCalls(s.T(), "FetchByID", 2)
+}
+
+// Test imported tasks start pending like any new task
+func (s *TaskImportUsecaseTestSuite) TestImport_StartsPending() {
+	content := "title,project_id,due_date,status\n" +
+		"Done already," + sampleProject.ID + "," + importDay() + ",completed\n" +
+		"Missed already," + sampleProject.ID + "," + importDay() + ",missed\n"
+
+	report, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(content), true, ownerActor)
+	s.Require().NoError(err)
+	s.Equal(2, report.Invalid)
+	for _, row := range report.Rows {
+		s.ErrorIs(row.Err, domain.ErrInvalidStatusTransition)
+	}
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_JSON() {
+	content := `[
+		{"project_id": "` + sampleProject.ID + `", "title": "Daily standup", "due_date": "` + importDay() + `", "status": "pending", "rrule": "FREQ=DAILY", "tags": ["meetings"]},
+		{"project_id": "` + sampleProject.ID + `", "title": "", "due_date": "` + importDay() + `", "status": "pending"},
+		{"project_id": "` + sampleProject.ID + `", "title": "Unknown", "due_date": "` + importDay() + `", "status": "started"},
+		{"title": "No project", "due_date": "` + importDay() + `", "status": "pending"},
+		{"project_id": "` + sampleProject.ID + `", "title": "Bad date", "due_date": "next week", "status": "pending"}
+	]`
+
+	report, err := s.importUsecase.Import(s.ctx, domain.ImportJSON, strings.NewReader(content), true, ownerActor)
+	s.Require().NoError(err)
+
+	s.Equal(1, report.Valid)
+	s.NoError(report.Rows[0].Err)
+	s.ErrorIs(report.Rows[1].Err, domain.ErrEmptyTitle)
+	s.ErrorIs(report.Rows[2].Err, domain.ErrInvalidStatus)
+	s.ErrorIs(report.Rows[3].Err, domain.ErrProjectRequired)
+	s.ErrorIs(report.Rows[4].Err, domain.ErrInvalidImportValue)
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_ViewerCannotImport() {
+	content := "title,project_id,due_date,status\nTask," + sampleProject.ID + "," + importDay() + ",pending\n"
+
+	report, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(content), true, viewerActor)
+	s.Require().NoError(err)
+	s.ErrorIs(report.Rows[0].Err, domain.ErrProjectReadOnly)
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_NothingValid() {
+	content := "title,project_id,due_date,status\nOld task," + sampleProject.ID + ",2000-01-01,pending\n"
+
+	report, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(content), false, ownerActor)
+	s.Require().NoError(err)
+	s.Equal(0, report.Imported)
+	s.mockRepo.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_InvalidFile() {
+	tests := []struct {
+		name    string
+		format  domain.ImportFormat
+		content string
+		err     error
+	}{
+		{"unknown column", domain.ImportCSV, "title,owner\nTask,me\n", domain.ErrInvalidImport},
+		{"duplicate column", domain.ImportCSV, "title,Title\nA,B\n", domain.ErrInvalidImport},
+		{"short record", domain.ImportCSV, "title,status\nTask\n", domain.ErrInvalidImport},
+		{"header only", domain.ImportCSV, "title,status\n", domain.ErrEmptyImport},
+		{"empty csv", domain.ImportCSV, "", domain.ErrEmptyImport},
+		{"not an array", domain.ImportJSON, `{"title": "Task"}`, domain.ErrInvalidImport},
+		{"unknown field", domain.ImportJSON, `[{"name": "Task"}]`, domain.ErrInvalidImport},
+		{"trailing data", domain.ImportJSON, `[] []`, domain.ErrInvalidImport},
+		{"empty array", domain.ImportJSON, `[]`, domain.ErrEmptyImport},
+		{"unknown format", domain.ImportFormat("xml"), `<tasks/>`, domain.ErrInvalidImportFormat},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.importUsecase.Import(s.ctx, tt.format, strings.NewReader(tt.content), true, ownerActor)
+			s.ErrorIs(err, tt.err)
+		})
+	}
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_TooManyRows() {
+	var content strings.Builder
+	content.WriteString("title\n")
+	for range domain.MaxImportRows + 1 {
+		content.WriteString("Task\n")
+	}
+
+	_, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(content.String()), true, ownerActor)
+	s.ErrorIs(err, domain.ErrImportTooLarge)
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_ProjectLookupFails() {
+	dbErr := errors.New("connection reset")
+	s.mockProjects.On("FetchByID", mock.Anything, "broken-project").Return(domain.Project{}, dbErr).Once()
+	content := "title,project_id,due_date,status\nTask,broken-project," + importDay() + ",pending\n"
+
+	_, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(content), true, ownerActor)
+	s.ErrorIs(err, dbErr)
+}
+
+func (s *TaskImportUsecaseTestSuite) TestImport_CreateManyFails() {
+	s.mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(errors.New("write failed")).Once()
+
+	_, err := s.importUsecase.Import(s.ctx, domain.ImportCSV, strings.NewReader(importCSV()), false, ownerActor)
+	s.Error(err)
+	s.mockHistory.AssertNotCalled(s.T(), "Append", mock.Anything, mock.Anything)
+}
+
+func TestTaskImportUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskImportUsecaseTestSuite))
+}
@@ -2,6 +2,7 @@ package usecases_test
 
 import (
 	"context"
+	"time"
 
 	domain "github.com/A2SVTask7/Domain"
 	"github.com/stretchr/testify/mock"
@@ -17,22 +18,142 @@ func (m *MockTaskRepository) Create(c context.Context, task *domain.Task) error
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) CreateMany(c context.Context, tasks []*domain.Task) error {
+	args := m.Called(c, tasks)
+	return args.Error(0)
+}
+
 func (m *MockTaskRepository) FetchByTaskID(c context.Context, taskID string) (domain.Task, error) {
 	args := m.Called(c, taskID)
 	return args.Get(0).(domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) FetchAllTasks(c context.Context) ([]domain.Task, error) {
+func (m *MockTaskRepository) FetchAllTasks(c context.Context, query domain.TaskQuery) ([]domain.Task, int, error) {
+	args := m.Called(c, query)
+	return args.Get(0).([]domain.Task), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) DeleteByTaskID(c context.Context, taskID string, version int) (int, error) {
+	args := m.Called(c, taskID, version)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) FetchDeleted(c context.Context) ([]domain.Task, error) {
 	args := m.Called(c)
 	return args.Get(0).([]domain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) DeleteByTaskID(c context.Context, taskID string) (int, error) {
+func (m *MockTaskRepository) RestoreByTaskID(c context.Context, taskID string) (int, error) {
 	args := m.Called(c, taskID)
 	return args.Int(0), args.Error(1)
 }
 
-func (m *MockTaskRepository) UpdateByTaskID(c context.Context, task *domain.Task) (int, int, error) {
-	args := m.Called(c, task)
+func (m *MockTaskRepository) PurgeDeleted(c context.Context, before time.Time) ([]string, error) {
+	args := m.Called(c, before)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) MarkMissed(c context.Context, taskID string, version int, now time.Time) (int, error) {
+	args := m.Called(c, taskID, version, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) FetchPendingDueBetween(c context.Context, from time.Time, to time.Time) ([]domain.Task, error) {
+	args := m.Called(c, from, to)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateByTaskID(c context.Context, task *domain.Task, status domain.TaskStatus) (int, int, error) {
+	args := m.Called(c, task, status)
 	return args.Int(0), args.Int(1), args.Error(2)
 }
+
+func (m *MockTaskRepository) PatchByTaskID(c context.Context, taskID string, status domain.TaskStatus, patch domain.TaskPatch) (int, int, error) {
+	args := m.Called(c, taskID, status, patch)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) FetchByAssignee(c context.Context, userID string) ([]domain.Task, error) {
+	args := m.Called(c, userID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddAssignee(c context.Context, taskID string, version int, userID string) (int, error) {
+	args := m.Called(c, taskID, version, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) RemoveAssignee(c context.Context, taskID string, version int, userID string) (int, error) {
+	args := m.Called(c, taskID, version, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) Search(c context.Context, query domain.TaskSearchQuery) ([]domain.TaskSearchResult, error) {
+	args := m.Called(c, query)
+	return args.Get(0).([]domain.TaskSearchResult), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateRecurrence(c context.Context, taskID string, seriesID string, recurrence string) (int, error) {
+	args := m.Called(c, taskID, seriesID, recurrence)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) FetchSubtasks(c context.Context, parentID string) ([]domain.Task, error) {
+	args := m.Called(c, parentID)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateSubtaskPositions(c context.Context, parentID string, taskIDs []string) (int, error) {
+	args := m.Called(c, parentID, taskIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateChecklist(c context.Context, taskID string, version int, checklist []domain.ChecklistItem) (int, error) {
+	args := m.Called(c, taskID, version, checklist)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) StreamTasks(c context.Context, query domain.TaskQuery, fn func(task domain.Task) error) error {
+	args := m.Called(c, query, fn)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) FetchVersions(c context.Context, query domain.TaskQuery) ([]domain.TaskVersion, error) {
+	args := m.Called(c, query)
+	return args.Get(0).([]domain.TaskVersion), args.Error(1)
+}
+
+func (m *MockTaskRepository) FetchByTaskIDs(c context.Context, taskIDs []string) ([]domain.Task, error) {
+	args := m.Called(c, taskIDs)
+	return args.Get(0).([]domain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddBlocker(c context.Context, taskID string, version int, blockerID string) (int, error) {
+	args := m.Called(c, taskID, version, blockerID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) RemoveBlocker(c context.Context, taskID string, version int, blockerID string) (int, error) {
+	args := m.Called(c, taskID, version, blockerID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddTag(c context.Context, taskID string, version int, tag string) (int, error) {
+	args := m.Called(c, taskID, version, tag)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) RemoveTag(c context.Context, taskID string, version int, tag string) (int, error) {
+	args := m.Called(c, taskID, version, tag)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) CountTags(c context.Context, projectIDs []string) ([]domain.TagCount, error) {
+	args := m.Called(c, projectIDs)
+	return args.Get(0).([]domain.TagCount), args.Error(1)
+}
+
+func (m *MockTaskRepository) UnassignFromProject(c context.Context, projectID string, userID string) (int, error) {
+	args := m.Called(c, projectID, userID)
+	return args.Int(0), args.Error(1)
+}
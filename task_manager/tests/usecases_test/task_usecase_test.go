@@ -2,10 +2,14 @@ package usecases_test
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	domain "github.com/A2SVTask7/Domain"
+	infrastructure "github.com/A2SVTask7/Infrastructure"
 	usecases "github.com/A2SVTask7/Usecases"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -13,29 +17,72 @@ import (
 
 type TaskUsecaseTestSuite struct {
 	suite.Suite
-	mockRepo    *MockTaskRepository
-	taskUsecase domain.TaskUsecase
-	ctx         context.Context
+	mockRepo     *MockTaskRepository
+	mockUserRepo *MockUserRepository
+	mockHistory  *MockTaskHistoryRepository
+	mockProjects *MockProjectRepository
+	mockEvents   *MockTaskEventPublisher
+	attachments  *MockAttachmentUsecase
+	comments     *MockCommentRepository
+	cursors      domain.CursorService
+	taskUsecase  domain.TaskUsecase
+	ctx          context.Context
 }
 
 func (s *TaskUsecaseTestSuite) SetupTest() {
 	s.mockRepo = new(MockTaskRepository)
-	s.taskUsecase = usecases.NewTaskUsecase(s.mockRepo, time.Second*2)
+	s.mockUserRepo = new(MockUserRepository)
+	s.mockHistory = new(MockTaskHistoryRepository)
+	s.mockHistory.On("Append", mock.Anything, mock.Anything).Return(nil).Maybe()
+	// fetching a single task computes its progress from the subtasks
+	s.mockRepo.On("FetchSubtasks", mock.Anything, mock.Anything).Return([]domain.Task{}, nil).Maybe()
+	s.mockProjects = new(MockProjectRepository)
+	s.mockProjects.On("FetchByID", mock.Anything, sampleProject.ID).Return(sampleProject, nil).Maybe()
+	s.mockProjects.On("FetchByMember", mock.Anything, ownerActor.ID).Return([]domain.Project{sampleProject}, nil).Maybe()
+	s.mockProjects.On("FetchByMember", mock.Anything, viewerActor.ID).Return([]domain.Project{sampleProject}, nil).Maybe()
+	s.mockProjects.On("FetchByMember", mock.Anything, otherActor.ID).Return([]domain.Project{}, nil).Maybe()
+	s.mockEvents = new(MockTaskEventPublisher)
+	s.mockEvents.On("Publish", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.attachments = new(MockAttachmentUsecase)
+	s.comments = new(MockCommentRepository)
+	s.cursors = infrastructure.NewCursorService("test-secret")
+	s.taskUsecase = usecases.NewTaskUsecase(s.mockRepo, s.mockUserRepo, s.mockHistory, s.mockProjects, s.mockEvents, s.attachments, s.comments, s.cursors, trashRetention, time.Second*2)
 	s.ctx = context.Background()
 }
 
 var sampleTask = domain.Task{
 	ID:          "task-id-123",
+	ProjectID:   "project-id-123",
 	Title:       "Test Task",
 	Description: "Test Description",
 	DueDate:     time.Now().Add(time.Hour),
-	Status:      "Pending",
+	Status:      domain.StatusPending,
+	OwnerID:     "owner-id-123",
+}
+
+var trashRetention = 7 * 24 * time.Hour
+
+var (
+	ownerActor  = domain.Actor{ID: "owner-id-123"}
+	otherActor  = domain.Actor{ID: "other-id-456"}
+	adminActor  = domain.Actor{ID: "admin-id-789", IsAdmin: true}
+	viewerActor = domain.Actor{ID: "viewer-id-321"}
+)
+
+// sampleProject holds sampleTask; otherActor is not a member
+var sampleProject = domain.Project{
+	ID:   "project-id-123",
+	Name: "Test Project",
+	Members: []domain.ProjectMember{
+		{UserID: ownerActor.ID, Role: domain.ProjectRoleOwner},
+		{UserID: viewerActor.ID, Role: domain.ProjectRoleViewer},
+	},
 }
 
 func (s *TaskUsecaseTestSuite) TestCreate_Success() {
 	s.mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil)
 
-	err := s.taskUsecase.Create(s.ctx, &sampleTask)
+	err := s.taskUsecase.Create(s.ctx, &sampleTask, ownerActor)
 	s.NoError(err)
 	s.mockRepo.AssertCalled(s.T(), "Create", mock.Anything, &sampleTask)
 }
@@ -44,65 +91,1373 @@ func (s *TaskUsecaseTestSuite) TestCreate_InvalidDueDate() {
 	task := sampleTask
 	task.DueDate = time.Now().Add(-time.Hour)
 
-	err := s.taskUsecase.Create(s.ctx, &task)
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
 	s.EqualError(err, domain.ErrInvalidDueDate.Error())
 	s.mockRepo.AssertNotCalled(s.T(), "Create")
 }
 
+// Test a new task cannot skip the transition graph by starting completed or missed
+func (s *TaskUsecaseTestSuite) TestCreate_StartsPending() {
+	for _, status := range []domain.TaskStatus{domain.StatusCompleted, domain.StatusMissed} {
+		task := sampleTask
+		task.Status = status
+
+		err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+		s.ErrorIs(err, domain.ErrInvalidStatusTransition)
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_ProjectRequired() {
+	task := sampleTask
+	task.ProjectID = ""
+
+	err := s.taskUsecase.Create(s.ctx, &task, adminActor)
+	s.ErrorIs(err, domain.ErrProjectRequired)
+	s.mockRepo.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_ProjectAccess() {
+	task := sampleTask
+
+	err := s.taskUsecase.Create(s.ctx, &task, otherActor)
+	s.ErrorIs(err, domain.ErrProjectNotFound)
+
+	err = s.taskUsecase.Create(s.ctx, &task, viewerActor)
+	s.ErrorIs(err, domain.ErrProjectReadOnly)
+	s.mockRepo.AssertNotCalled(s.T(), "Create")
+}
+
 func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_Success() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
 	task := sampleTask
-	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task).Return(1, 1, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 1, nil)
 
-	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task)
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
 	s.NoError(err)
-	s.mockRepo.AssertCalled(s.T(), "UpdateByTaskID", mock.Anything, &task)
+	s.mockRepo.AssertCalled(s.T(), "UpdateByTaskID", mock.Anything, &task, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_Viewer() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	task := sampleTask
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, viewerActor)
+	s.ErrorIs(err, domain.ErrProjectReadOnly)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateByTaskID")
 }
 
 func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_NoMatch() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil).Once()
 	task := sampleTask
-	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task).Return(0, 0, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(0, 0, nil)
+	// deleted in between
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(domain.Task{}, domain.ErrTaskNotFound).Once()
 
-	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task)
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
 	s.EqualError(err, domain.ErrTaskNotFound.Error())
 }
 
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_StatusChangedConcurrently() {
+	completed := sampleTask
+	completed.Status = domain.StatusCompleted
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil).Once()
+	// the write is conditional on the status the transition was checked from
+	task := sampleTask
+	task.Status = domain.StatusMissed
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, domain.StatusPending).Return(0, 0, nil).Once()
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(completed, nil).Once()
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+	s.mockHistory.AssertNotCalled(s.T(), "Append", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_WritesVersionRead() {
+	stored := sampleTask
+	stored.Version = 4
+	title := "Renamed"
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(stored, nil).Once()
+	s.mockRepo.On("PatchByTaskID", mock.Anything, "task-id-123", domain.StatusPending, domain.TaskPatch{Title: &title, Version: 4}).Return(1, 1, nil).Once()
+
+	task, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Title: &title}, adminActor)
+	s.Require().NoError(err)
+	s.Equal("Renamed", task.Title)
+	s.Equal(5, task.Version)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_StatusChangedConcurrently() {
+	status := domain.StatusCompleted
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("PatchByTaskID", mock.Anything, "task-id-123", domain.StatusPending, domain.TaskPatch{Status: &status}).Return(0, 0, nil).Once()
+
+	_, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Status: &status}, adminActor)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+}
+
 func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_NoChange() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
 	task := sampleTask
-	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task).Return(1, 0, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 0, nil)
 
-	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task)
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
 	s.EqualError(err, domain.ErrNoChangesMade.Error())
 }
 
 func (s *TaskUsecaseTestSuite) TestDeleteByTaskID_Success() {
-	s.mockRepo.On("DeleteByTaskID", mock.Anything, "task-id-123").Return(1, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("DeleteByTaskID", mock.Anything, "task-id-123", 0).Return(1, nil)
 
-	err := s.taskUsecase.DeleteByTaskID(s.ctx, "task-id-123")
+	err := s.taskUsecase.DeleteByTaskID(s.ctx, "task-id-123", 0, adminActor)
 	s.NoError(err)
 }
 
 func (s *TaskUsecaseTestSuite) TestDeleteByTaskID_NotFound() {
-	s.mockRepo.On("DeleteByTaskID", mock.Anything, "non-existent-id").Return(0, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "non-existent-id").Return(domain.Task{}, domain.ErrTaskNotFound)
 
-	err := s.taskUsecase.DeleteByTaskID(s.ctx, "non-existent-id")
+	err := s.taskUsecase.DeleteByTaskID(s.ctx, "non-existent-id", 0, adminActor)
 	s.EqualError(err, domain.ErrTaskNotFound.Error())
+	s.mockRepo.AssertNotCalled(s.T(), "DeleteByTaskID")
+}
+
+func (s *TaskUsecaseTestSuite) TestDeleteByTaskID_Viewer() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.DeleteByTaskID(s.ctx, "task-id-123", 0, viewerActor)
+	s.ErrorIs(err, domain.ErrProjectReadOnly)
+	s.mockRepo.AssertNotCalled(s.T(), "DeleteByTaskID")
 }
 
 func (s *TaskUsecaseTestSuite) TestFetchByTaskID_Success() {
 	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
 
-	task, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123")
+	task, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", ownerActor)
+	s.NoError(err)
+	s.Equal(sampleTask.ID, task.ID)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchByTaskID_NotOwner() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	_, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", otherActor)
+	s.EqualError(err, domain.ErrTaskNotFound.Error())
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchByTaskID_Admin() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	task, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", adminActor)
 	s.NoError(err)
 	s.Equal(sampleTask.ID, task.ID)
 }
 
 func (s *TaskUsecaseTestSuite) TestFetchAllTasks_Success() {
-	s.mockRepo.On("FetchAllTasks", mock.Anything).Return([]domain.Task{sampleTask}, nil)
+	expectedQuery := domain.TaskQuery{ProjectIDs: []string{sampleProject.ID}, Limit: domain.DefaultPageSize + 1}
+	s.mockRepo.On("FetchAllTasks", mock.Anything, expectedQuery).Return([]domain.Task{sampleTask}, 1, nil)
+
+	page, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{}, ownerActor)
+	s.NoError(err)
+	s.Len(page.Tasks, 1)
+	s.Equal(1, page.Total)
+	s.Equal(domain.DefaultPageSize, page.Limit)
+	s.Equal(sampleTask.ID, page.Tasks[0].ID)
+	s.Empty(page.NextCursor)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_AdminSeesAll() {
+	expectedQuery := domain.TaskQuery{Limit: domain.MaxPageSize + 1, Status: "pending"}
+	s.mockRepo.On("FetchAllTasks", mock.Anything, expectedQuery).Return([]domain.Task{sampleTask}, 1, nil)
+
+	page, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Limit: 1000, Status: " Pending "}, adminActor)
+	s.NoError(err)
+	s.Len(page.Tasks, 1)
+	s.mockRepo.AssertCalled(s.T(), "FetchAllTasks", mock.Anything, expectedQuery)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_NoProjects() {
+	expectedQuery := domain.TaskQuery{ProjectIDs: []string{}, Limit: domain.DefaultPageSize + 1}
+	s.mockRepo.On("FetchAllTasks", mock.Anything, expectedQuery).Return([]domain.Task{}, 0, nil)
+
+	page, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{}, otherActor)
+	s.NoError(err)
+	s.Empty(page.Tasks)
+	s.mockRepo.AssertCalled(s.T(), "FetchAllTasks", mock.Anything, expectedQuery)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_ProjectFilter() {
+	expectedQuery := domain.TaskQuery{ProjectID: sampleProject.ID, ProjectIDs: []string{sampleProject.ID}, Limit: domain.DefaultPageSize + 1}
+	s.mockRepo.On("FetchAllTasks", mock.Anything, expectedQuery).Return([]domain.Task{sampleTask}, 1, nil)
+
+	page, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{ProjectID: sampleProject.ID}, viewerActor)
+	s.NoError(err)
+	s.Len(page.Tasks, 1)
+
+	// the project of another team is reported as missing
+	_, err = s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{ProjectID: sampleProject.ID}, otherActor)
+	s.ErrorIs(err, domain.ErrProjectNotFound)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "FetchAllTasks", 1)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_InvalidQuery() {
+	queries := []domain.TaskQuery{
+		{SortBy: "owner_id"},
+		{Limit: -1},
+		{DueAfter: time.Now().Add(time.Hour), DueBefore: time.Now()},
+		{Cursor: "token", Offset: 5},
+	}
+	for _, query := range queries {
+		_, err := s.taskUsecase.FetchAllTasks(s.ctx, query, ownerActor)
+		s.ErrorIs(err, domain.ErrInvalidQuery)
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "FetchAllTasks")
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_NextCursor() {
+	first := sampleTask
+	second := sampleTask
+	second.ID = "task-id-456"
+	s.mockRepo.On("FetchAllTasks", mock.Anything, mock.MatchedBy(func(q domain.TaskQuery) bool {
+		return q.Limit == 2 && q.After == nil
+	})).Return([]domain.Task{first, second}, 5, nil).Once()
+
+	page, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Limit: 1, SortBy: domain.TaskSortByDueDate}, adminActor)
+	s.Require().NoError(err)
+	s.Len(page.Tasks, 1)
+	s.NotEmpty(page.NextCursor)
+
+	// the cursor continues after the last task of the page
+	s.mockRepo.On("FetchAllTasks", mock.Anything, mock.MatchedBy(func(q domain.TaskQuery) bool {
+		return q.After != nil && q.After.ID == first.ID && q.After.SortBy == domain.TaskSortByDueDate
+	})).Return([]domain.Task{second}, 5, nil).Once()
+
+	next, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Limit: 1, SortBy: domain.TaskSortByDueDate, Cursor: page.NextCursor}, adminActor)
+	s.Require().NoError(err)
+	s.Len(next.Tasks, 1)
+	s.Empty(next.NextCursor)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_InvalidCursor() {
+	token, err := s.cursors.Encode(domain.Cursor{SortBy: domain.TaskSortByTitle, ID: "task-id-123"})
+	s.Require().NoError(err)
+
+	// cursor issued for another sort order
+	_, err = s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Cursor: token}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidCursor)
+
+	// cursor that was tampered with
+	_, err = s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Cursor: token + "x"}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidCursor)
+	s.mockRepo.AssertNotCalled(s.T(), "FetchAllTasks")
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchByTaskID_ProjectMember() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	fetched, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", viewerActor)
+	s.NoError(err)
+	s.Equal(sampleTask.ID, fetched.ID)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchByTaskID_AssigneeOutsideProject() {
+	task := sampleTask
+	task.Assignees = []string{otherActor.ID}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+
+	_, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", otherActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+func (s *TaskUsecaseTestSuite) TestAssignUser_Success() {
+	s.mockUserRepo.On("FetchByUserID", mock.Anything, viewerActor.ID).Return(domain.User{ID: viewerActor.ID}, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("AddAssignee", mock.Anything, "task-id-123", 0, viewerActor.ID).Return(1, nil)
+
+	err := s.taskUsecase.AssignUser(s.ctx, "task-id-123", viewerActor.ID, ownerActor)
+	s.NoError(err)
+}
+
+func (s *TaskUsecaseTestSuite) TestAssignUser_NotProjectMember() {
+	s.mockUserRepo.On("FetchByUserID", mock.Anything, "user-id-123").Return(domain.User{ID: "user-id-123"}, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.AssignUser(s.ctx, "task-id-123", "user-id-123", adminActor)
+	s.ErrorIs(err, domain.ErrNotProjectMember)
+	s.mockRepo.AssertNotCalled(s.T(), "AddAssignee")
+}
+
+func (s *TaskUsecaseTestSuite) TestAssignUser_UserNotFound() {
+	s.mockUserRepo.On("FetchByUserID", mock.Anything, "missing").Return(domain.User{}, domain.ErrUserNotFound)
+
+	err := s.taskUsecase.AssignUser(s.ctx, "task-id-123", "missing", adminActor)
+	s.EqualError(err, domain.ErrUserNotFound.Error())
+	s.mockRepo.AssertNotCalled(s.T(), "AddAssignee")
+}
+
+func (s *TaskUsecaseTestSuite) TestAssignUser_AlreadyAssigned() {
+	s.mockUserRepo.On("FetchByUserID", mock.Anything, ownerActor.ID).Return(domain.User{ID: ownerActor.ID}, nil)
+	task := sampleTask
+	task.Assignees = []string{ownerActor.ID}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+
+	err := s.taskUsecase.AssignUser(s.ctx, "task-id-123", ownerActor.ID, adminActor)
+	s.EqualError(err, domain.ErrUserAlreadyAssigned.Error())
+	s.mockRepo.AssertNotCalled(s.T(), "AddAssignee", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestUnassignUser_NotAssigned() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.UnassignUser(s.ctx, "task-id-123", "user-id-123", adminActor)
+	s.EqualError(err, domain.ErrUserNotAssigned.Error())
+	s.mockRepo.AssertNotCalled(s.T(), "RemoveAssignee", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAssignedTasks_Success() {
+	s.mockRepo.On("FetchByAssignee", mock.Anything, viewerActor.ID).Return([]domain.Task{sampleTask}, nil)
+
+	tasks, err := s.taskUsecase.FetchAssignedTasks(s.ctx, viewerActor.ID)
+	s.NoError(err)
+	s.Len(tasks, 1)
+}
 
-	tasks, err := s.taskUsecase.FetchAllTasks(s.ctx)
+func (s *TaskUsecaseTestSuite) TestFetchAssignedTasks_LeftProject() {
+	s.mockRepo.On("FetchByAssignee", mock.Anything, otherActor.ID).Return([]domain.Task{sampleTask}, nil)
+
+	tasks, err := s.taskUsecase.FetchAssignedTasks(s.ctx, otherActor.ID)
+	s.NoError(err)
+	s.Empty(tasks)
+}
+
+func (s *TaskUsecaseTestSuite) TestSearch_Highlights() {
+	task := sampleTask
+	task.Title = "Write quarterly report"
+	task.Description = strings.Repeat("filler ", 20) + "the Report is due to finance" + strings.Repeat(" filler", 20)
+	expectedQuery := domain.TaskSearchQuery{Text: "report -draft", ProjectIDs: []string{sampleProject.ID}, Limit: domain.DefaultPageSize}
+	s.mockRepo.On("Search", mock.Anything, expectedQuery).Return([]domain.TaskSearchResult{{Task: task, Score: 2}}, nil)
+
+	results, err := s.taskUsecase.Search(s.ctx, domain.TaskSearchQuery{Text: " report -draft "}, ownerActor)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Equal("Write quarterly <mark>report</mark>", results[0].Highlights["title"])
+
+	description := results[0].Highlights["description"]
+	s.Contains(description, "the <mark>Report</mark> is due")
+	s.True(strings.HasPrefix(description, "…"))
+	s.True(strings.HasSuffix(description, "…"))
+}
+
+func (s *TaskUsecaseTestSuite) TestSearch_HighlightsEscapeMarkup() {
+	task := sampleTask
+	task.Title = `<script>alert("report")</script> & <b>report</b>`
+	s.mockRepo.On("Search", mock.Anything, mock.Anything).Return([]domain.TaskSearchResult{{Task: task, Score: 1}}, nil)
+
+	results, err := s.taskUsecase.Search(s.ctx, domain.TaskSearchQuery{Text: "report"}, ownerActor)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Equal("&lt;script&gt;alert(&#34;<mark>report</mark>&#34;)&lt;/script&gt; &amp; &lt;b&gt;<mark>report</mark>&lt;/b&gt;", results[0].Highlights["title"])
+}
+
+func (s *TaskUsecaseTestSuite) TestSearch_EmptyText() {
+	_, err := s.taskUsecase.Search(s.ctx, domain.TaskSearchQuery{Text: "   "}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidQuery)
+	s.mockRepo.AssertNotCalled(s.T(), "Search")
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_Success() {
+	status := domain.TaskStatus(" Completed ")
+	expectedStatus := domain.StatusCompleted
+	patched := sampleTask
+	patched.Status = expectedStatus
+
+	s.mockRepo.On("PatchByTaskID", mock.Anything, "task-id-123", mock.Anything, domain.TaskPatch{Status: &expectedStatus}).Return(1, 1, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(patched, nil)
+
+	task, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Status: &status}, adminActor)
+	s.NoError(err)
+	s.Equal(expectedStatus, task.Status)
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_Validation() {
+	empty := "  "
+	invalidStatus := domain.TaskStatus("archived")
+	past := time.Now().Add(-time.Hour)
+
+	_, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{}, adminActor)
+	s.ErrorIs(err, domain.ErrEmptyPatch)
+
+	_, err = s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Title: &empty}, adminActor)
+	s.ErrorIs(err, domain.ErrEmptyTitle)
+
+	_, err = s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Status: &invalidStatus}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidStatus)
+
+	_, err = s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{DueDate: &past}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidDueDate)
+
+	s.mockRepo.AssertNotCalled(s.T(), "PatchByTaskID")
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_NotFound() {
+	title := "new title"
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	_, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Title: &title}, adminActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_VersionConflict() {
+	task := sampleTask
+	task.Version = 3
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(0, 0, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, task.ID).Return(sampleTask, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_VersionBumped() {
+	task := sampleTask
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	task.Version = 3
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.NoError(err)
+	s.Equal(4, task.Version)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_WritesVersionRead() {
+	stored := sampleTask
+	stored.Version = 3
+	task := sampleTask
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(stored, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.Version == 3
+	}), domain.StatusPending).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.NoError(err)
+	s.Equal(4, task.Version)
+}
+
+func (s *TaskUsecaseTestSuite) TestDeleteByTaskID_VersionConflict() {
+	s.mockRepo.On("DeleteByTaskID", mock.Anything, "task-id-123", 2).Return(0, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.DeleteByTaskID(s.ctx, "task-id-123", 2, adminActor)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+}
+
+func (s *TaskUsecaseTestSuite) TestDeleteByTaskID_VersionedNotFound() {
+	s.mockRepo.On("DeleteByTaskID", mock.Anything, "task-id-123", 2).Return(0, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	err := s.taskUsecase.DeleteByTaskID(s.ctx, "task-id-123", 2, adminActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchTrash() {
+	trashed := sampleTask
+	deletedAt := time.Now()
+	trashed.DeletedAt = &deletedAt
+	s.mockRepo.On("FetchDeleted", mock.Anything).Return([]domain.Task{trashed}, nil)
+
+	tasks, err := s.taskUsecase.FetchTrash(s.ctx)
 	s.NoError(err)
 	s.Len(tasks, 1)
-	s.Equal(sampleTask.ID, tasks[0].ID)
+}
+
+func (s *TaskUsecaseTestSuite) TestRestoreByTaskID_Success() {
+	s.mockRepo.On("RestoreByTaskID", mock.Anything, "task-id-123").Return(1, nil)
+
+	err := s.taskUsecase.RestoreByTaskID(s.ctx, "task-id-123", adminActor)
+	s.NoError(err)
+}
+
+func (s *TaskUsecaseTestSuite) TestRestoreByTaskID_NotInTrash() {
+	s.mockRepo.On("RestoreByTaskID", mock.Anything, "task-id-123").Return(0, nil)
+
+	err := s.taskUsecase.RestoreByTaskID(s.ctx, "task-id-123", adminActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+func (s *TaskUsecaseTestSuite) TestPurgeTrash_UsesRetention() {
+	now := time.Now()
+	s.mockRepo.On("PurgeDeleted", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		cutoff := now.Add(-trashRetention)
+		return !before.Before(cutoff) && before.Before(cutoff.Add(time.Minute))
+	})).Return([]string{"task-1", "task-2", "task-3"}, nil)
+	s.attachments.On("DeleteByTaskIDs", mock.Anything, mock.Anything).Return(nil)
+	s.comments.On("DeleteByTaskIDs", mock.Anything, mock.Anything).Return(0, nil)
+
+	purged, err := s.taskUsecase.PurgeTrash(s.ctx)
+	s.NoError(err)
+	s.Equal(3, purged)
+}
+
+func (s *TaskUsecaseTestSuite) TestPurgeTrash_DeletesAttachments() {
+	s.mockRepo.On("PurgeDeleted", mock.Anything, mock.Anything).Return([]string{"task-1", "task-2"}, nil)
+	s.attachments.On("DeleteByTaskIDs", mock.Anything, []string{"task-1", "task-2"}).Return(errors.New("store down"))
+	s.comments.On("DeleteByTaskIDs", mock.Anything, mock.Anything).Return(0, nil)
+
+	// the tasks are purged even if their attachments cannot be removed
+	purged, err := s.taskUsecase.PurgeTrash(s.ctx)
+	s.NoError(err)
+	s.Equal(2, purged)
+	s.attachments.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestPurgeTrash_DeletesComments() {
+	s.mockRepo.On("PurgeDeleted", mock.Anything, mock.Anything).Return([]string{"task-1", "task-2"}, nil)
+	s.attachments.On("DeleteByTaskIDs", mock.Anything, mock.Anything).Return(nil)
+	s.comments.On("DeleteByTaskIDs", mock.Anything, []string{"task-1", "task-2"}).Return(0, errors.New("db down"))
+
+	// the tasks are purged even if their comments cannot be removed
+	purged, err := s.taskUsecase.PurgeTrash(s.ctx)
+	s.NoError(err)
+	s.Equal(2, purged)
+	s.comments.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestPurgeTrash_NothingToPurge() {
+	s.mockRepo.On("PurgeDeleted", mock.Anything, mock.Anything).Return([]string{}, nil)
+
+	purged, err := s.taskUsecase.PurgeTrash(s.ctx)
+	s.NoError(err)
+	s.Zero(purged)
+	s.attachments.AssertNotCalled(s.T(), "DeleteByTaskIDs")
+	s.comments.AssertNotCalled(s.T(), "DeleteByTaskIDs", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_RecordsHistory() {
+	task := sampleTask
+	task.Status = "completed"
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.Require().NoError(err)
+
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return e.TaskID == "task-id-123" &&
+			e.ActorID == adminActor.ID &&
+			e.Action == domain.TaskActionUpdate &&
+			len(e.Changes) == 1 &&
+			e.Changes[0] == domain.FieldChange{Field: "status", Before: "pending", After: "completed"}
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_NoChangeNotRecorded() {
+	task := sampleTask
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 0, nil)
+
+	_ = s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.mockHistory.AssertNotCalled(s.T(), "Append", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_RecordsHistory() {
+	task := sampleTask
+	s.mockRepo.On("Create", mock.Anything, &task).Return(nil)
+
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.Require().NoError(err)
+
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return e.ActorID == task.OwnerID && e.Action == domain.TaskActionCreate && len(e.Changes) == 5
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_PublishesStatusChange() {
+	task := sampleTask
+	task.Status = "completed"
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.Require().NoError(err)
+
+	for _, eventType := range []string{domain.WebhookEventTaskUpdated, domain.WebhookEventTaskStatusChanged} {
+		s.mockEvents.AssertCalled(s.T(), "Publish", mock.Anything, mock.MatchedBy(func(e domain.TaskEvent) bool {
+			return e.Type == eventType && e.Task.ID == task.ID && e.Task.Status == domain.StatusCompleted &&
+				e.ActorID == adminActor.ID && len(e.Changes) == 1
+		}))
+	}
+}
+
+func (s *TaskUsecaseTestSuite) TestDeleteByTaskID_PublishesDeletedTask() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("DeleteByTaskID", mock.Anything, "task-id-123", 0).Return(1, nil)
+
+	err := s.taskUsecase.DeleteByTaskID(s.ctx, "task-id-123", 0, ownerActor)
+	s.Require().NoError(err)
+
+	s.mockEvents.AssertCalled(s.T(), "Publish", mock.Anything, mock.MatchedBy(func(e domain.TaskEvent) bool {
+		return e.Type == domain.WebhookEventTaskDeleted && e.Task.Title == sampleTask.Title
+	}))
+	s.mockEvents.AssertNumberOfCalls(s.T(), "Publish", 1)
+}
+
+func (s *TaskUsecaseTestSuite) TestAssignUser_RecordsAssignees() {
+	s.mockUserRepo.On("FetchByUserID", mock.Anything, viewerActor.ID).Return(domain.User{ID: viewerActor.ID}, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("AddAssignee", mock.Anything, "task-id-123", 0, viewerActor.ID).Return(1, nil)
+
+	err := s.taskUsecase.AssignUser(s.ctx, "task-id-123", viewerActor.ID, adminActor)
+	s.Require().NoError(err)
+
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return len(e.Changes) == 1 &&
+			e.Changes[0].Field == "assignees" &&
+			len(e.Changes[0].After.([]string)) == 1
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchHistory_Success() {
+	history := []domain.TaskHistoryEntry{{TaskID: "task-id-123", Action: domain.TaskActionCreate}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockHistory.On("FetchByTaskID", mock.Anything, "task-id-123").Return(history, nil)
+
+	entries, err := s.taskUsecase.FetchHistory(s.ctx, "task-id-123", ownerActor)
+	s.NoError(err)
+	s.Equal(history, entries)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchHistory_NotVisible() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	_, err := s.taskUsecase.FetchHistory(s.ctx, "task-id-123", otherActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+	s.mockHistory.AssertNotCalled(s.T(), "FetchByTaskID", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_InvalidStatus() {
+	task := sampleTask
+	task.Status = "archived"
+
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.ErrorIs(err, domain.ErrInvalidStatus)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_ForbiddenTransition() {
+	completed := sampleTask
+	completed.Status = domain.StatusCompleted
+	task := sampleTask
+	task.Status = domain.StatusMissed
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(completed, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidStatusTransition)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateByTaskID", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_Reopen() {
+	completed := sampleTask
+	completed.Status = domain.StatusCompleted
+	pending := domain.StatusPending
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(completed, nil).Once()
+	s.mockRepo.On("PatchByTaskID", mock.Anything, "task-id-123", mock.Anything, domain.TaskPatch{Status: &pending}).Return(1, 1, nil)
+
+	task, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Status: &pending}, adminActor)
+	s.NoError(err)
+	s.Equal(domain.StatusPending, task.Status)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_UnknownStatus() {
+	_, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Status: "archived"}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidQuery)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_StartsSeries() {
+	task := sampleTask
+	task.Recurrence = "rrule:freq=weekly;byday=fr,mo"
+	s.mockRepo.On("Create", mock.Anything, &task).Return(nil)
+
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.Require().NoError(err)
+	s.Equal("FREQ=WEEKLY;BYDAY=MO,FR", task.Recurrence)
+	s.NotEmpty(task.SeriesID)
+	s.Equal(1, task.Occurrence)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_InvalidRecurrence() {
+	task := sampleTask
+	task.Recurrence = "FREQ=HOURLY"
+
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.ErrorIs(err, domain.ErrInvalidRecurrence)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_CompletingCreatesNextOccurrence() {
+	recurring := sampleTask
+	recurring.DueDate = time.Now().Add(time.Hour).Truncate(time.Second)
+	recurring.Recurrence = "FREQ=DAILY"
+	recurring.SeriesID = "series-1"
+	recurring.Occurrence = 1
+	recurring.Assignees = []string{"user-id-123"}
+	status := domain.StatusCompleted
+
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(recurring, nil).Once()
+	s.mockRepo.On("PatchByTaskID", mock.Anything, "task-id-123", mock.Anything, domain.TaskPatch{Status: &status}).Return(1, 1, nil)
+	s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.SeriesID == "series-1" &&
+			t.Occurrence == 2 &&
+			t.Status == domain.StatusPending &&
+			t.DueDate.Equal(recurring.DueDate.AddDate(0, 0, 1)) &&
+			t.Recurrence == recurring.Recurrence &&
+			len(t.Assignees) == 1
+	})).Return(nil).Once()
+
+	_, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Status: &status}, adminActor)
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_NextOccurrenceKeepsOwner() {
+	recurring := sampleTask
+	recurring.DueDate = time.Now().Add(time.Hour).Truncate(time.Second)
+	recurring.Recurrence = "FREQ=DAILY"
+	recurring.SeriesID = "series-1"
+	recurring.Occurrence = 1
+	recurring.Assignees = []string{viewerActor.ID}
+	// a PUT body only carries the editable fields
+	task := domain.Task{
+		ID:          recurring.ID,
+		Title:       recurring.Title,
+		Description: recurring.Description,
+		DueDate:     recurring.DueDate,
+		Status:      domain.StatusCompleted,
+	}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(recurring, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, mock.Anything, domain.StatusPending).Return(1, 1, nil)
+	s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.OwnerID == recurring.OwnerID &&
+			t.ProjectID == recurring.ProjectID &&
+			slices.Equal(t.Assignees, recurring.Assignees) &&
+			t.SeriesID == "series-1" &&
+			t.Occurrence == 2
+	})).Return(nil).Once()
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_LastOccurrenceNotRepeated() {
+	recurring := sampleTask
+	recurring.Recurrence = "FREQ=DAILY;COUNT=2"
+	recurring.SeriesID = "series-1"
+	recurring.Occurrence = 2
+	task := recurring
+	task.Status = domain.StatusCompleted
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(recurring, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.NoError(err)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// expectOverdue makes the overdue sweep at now stream tasks
+func expectOverdue(repo *MockTaskRepository, now time.Time, tasks ...domain.Task) {
+	repo.On("StreamTasks", mock.Anything, domain.TaskQuery{Status: domain.StatusPending, DueBefore: now}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(task domain.Task) error)
+		for _, task := range tasks {
+			if err := fn(task); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func (s *TaskUsecaseTestSuite) TestMarkOverdueMissed_SkipsPastOccurrences() {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	overdue := sampleTask
+	overdue.DueDate = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	overdue.Recurrence = "FREQ=WEEKLY"
+	overdue.SeriesID = "series-1"
+	overdue.Occurrence = 4
+	oneOff := sampleTask
+	oneOff.ID = "task-id-456"
+	oneOff.DueDate = overdue.DueDate
+
+	expectOverdue(s.mockRepo, now, overdue, oneOff)
+	s.mockRepo.On("MarkMissed", mock.Anything, mock.Anything, sampleTask.Version, now).Return(1, nil).Twice()
+	s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		// March 8th has already passed, so the series continues on March 15th
+		return t.Occurrence == 6 && t.DueDate.Equal(time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC))
+	})).Return(nil).Once()
+
+	count, err := s.taskUsecase.MarkOverdueMissed(s.ctx, now)
+	s.NoError(err)
+	s.Equal(2, count)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestMarkOverdueMissed_OccurrenceAlreadyExists() {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	overdue := sampleTask
+	overdue.DueDate = time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	overdue.Recurrence = "FREQ=DAILY"
+	overdue.SeriesID = "series-1"
+	overdue.Occurrence = 1
+
+	expectOverdue(s.mockRepo, now, overdue)
+	s.mockRepo.On("MarkMissed", mock.Anything, overdue.ID, overdue.Version, now).Return(1, nil)
+	s.mockRepo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrOccurrenceExists).Once()
+
+	count, err := s.taskUsecase.MarkOverdueMissed(s.ctx, now)
+	s.NoError(err)
+	s.Equal(1, count)
+	// only the status change is recorded, not the occurrence that was not created
+	s.mockHistory.AssertNumberOfCalls(s.T(), "Append", 1)
+}
+
+func (s *TaskUsecaseTestSuite) TestMarkOverdueMissed_RecordsStatusChange() {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	overdue := sampleTask
+	overdue.DueDate = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	dueNow := sampleTask
+	dueNow.ID = "task-id-456"
+	dueNow.DueDate = now
+
+	expectOverdue(s.mockRepo, now, overdue, dueNow)
+	s.mockRepo.On("MarkMissed", mock.Anything, overdue.ID, overdue.Version, now).Return(1, nil)
+
+	count, err := s.taskUsecase.MarkOverdueMissed(s.ctx, now)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+
+	// a task due right now is not overdue yet
+	s.mockRepo.AssertNotCalled(s.T(), "MarkMissed", mock.Anything, dueNow.ID, mock.Anything, mock.Anything)
+	s.mockHistory.AssertNumberOfCalls(s.T(), "Append", 1)
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return e.TaskID == overdue.ID &&
+			e.ActorID == domain.SystemActor.ID &&
+			e.Action == domain.TaskActionUpdate &&
+			len(e.Changes) == 1 &&
+			e.Changes[0] == domain.FieldChange{Field: "status", Before: "pending", After: "missed"}
+	}))
+}
+
+// Test a task changed between the read and the update of the sweep is left alone
+func (s *TaskUsecaseTestSuite) TestMarkOverdueMissed_ChangedMeanwhile() {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	completed := sampleTask
+	completed.DueDate = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	completed.Recurrence = "FREQ=WEEKLY"
+	completed.SeriesID = "series-1"
+	completed.Occurrence = 1
+	overdue := sampleTask
+	overdue.ID = "task-id-456"
+	overdue.Version = 3
+	overdue.DueDate = completed.DueDate
+	overdue.Recurrence = "FREQ=WEEKLY"
+	overdue.SeriesID = "series-2"
+	overdue.Occurrence = 1
+
+	expectOverdue(s.mockRepo, now, completed, overdue)
+	s.mockRepo.On("MarkMissed", mock.Anything, completed.ID, completed.Version, now).Return(0, nil)
+	s.mockRepo.On("MarkMissed", mock.Anything, overdue.ID, overdue.Version, now).Return(1, nil)
+	s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.SeriesID == overdue.SeriesID
+	})).Return(nil).Once()
+
+	count, err := s.taskUsecase.MarkOverdueMissed(s.ctx, now)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+
+	// the status change and the next occurrence of the task that was marked, both by the system
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	s.mockHistory.AssertNumberOfCalls(s.T(), "Append", 2)
+	s.mockHistory.AssertNotCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return e.TaskID == completed.ID
+	}))
+	s.mockHistory.AssertNotCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return e.ActorID != domain.SystemActor.ID
+	}))
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return e.TaskID == overdue.ID && e.Changes[0] == domain.FieldChange{Field: "status", Before: "pending", After: "missed"}
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestSetRecurrence_StartsSeries() {
+	updated := sampleTask
+	updated.Recurrence = "FREQ=MONTHLY;BYMONTHDAY=-1"
+	updated.SeriesID = "series-1"
+	updated.Occurrence = 1
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil).Once()
+	s.mockRepo.On("UpdateRecurrence", mock.Anything, "task-id-123", mock.MatchedBy(func(id string) bool { return id != "" }), "FREQ=MONTHLY;BYMONTHDAY=-1").Return(1, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(updated, nil).Once()
+
+	task, err := s.taskUsecase.SetRecurrence(s.ctx, "task-id-123", "FREQ=MONTHLY;BYMONTHDAY=-1", adminActor)
+	s.NoError(err)
+	s.Equal(updated, task)
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return len(e.Changes) == 1 && e.Changes[0].Field == "recurrence"
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestSetRecurrence_KeepsSeries() {
+	recurring := sampleTask
+	recurring.Recurrence = "FREQ=DAILY"
+	recurring.SeriesID = "series-1"
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(recurring, nil)
+	s.mockRepo.On("UpdateRecurrence", mock.Anything, "task-id-123", "series-1", "FREQ=DAILY;INTERVAL=2").Return(3, nil)
+
+	_, err := s.taskUsecase.SetRecurrence(s.ctx, "task-id-123", "FREQ=DAILY;INTERVAL=2", adminActor)
+	s.NoError(err)
+}
+
+func (s *TaskUsecaseTestSuite) TestSetRecurrence_Invalid() {
+	_, err := s.taskUsecase.SetRecurrence(s.ctx, "task-id-123", "FREQ=DAILY;COUNT=0", adminActor)
+	s.ErrorIs(err, domain.ErrInvalidRecurrence)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateRecurrence", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestStopRecurrence_Success() {
+	recurring := sampleTask
+	recurring.Recurrence = "FREQ=DAILY"
+	recurring.SeriesID = "series-1"
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(recurring, nil)
+	s.mockRepo.On("UpdateRecurrence", mock.Anything, "task-id-123", "series-1", "").Return(2, nil)
+
+	err := s.taskUsecase.StopRecurrence(s.ctx, "task-id-123", adminActor)
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestStopRecurrence_NotRecurring() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.StopRecurrence(s.ctx, "task-id-123", adminActor)
+	s.ErrorIs(err, domain.ErrNotRecurring)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateRecurrence", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchByTaskID_Progress() {
+	s.mockRepo.ExpectedCalls = nil
+	task := sampleTask
+	task.Checklist = []domain.ChecklistItem{{ID: "a", Done: true}, {ID: "b"}}
+	subtasks := []domain.Task{{ID: "sub-1", Status: domain.StatusCompleted}, {ID: "sub-2", Status: domain.StatusPending}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+	s.mockRepo.On("FetchSubtasks", mock.Anything, "task-id-123").Return(subtasks, nil)
+
+	fetched, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", ownerActor)
+	s.Require().NoError(err)
+	s.Require().NotNil(fetched.Progress)
+	s.Equal(50, *fetched.Progress)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchByTaskID_NoProgressWithoutSteps() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	fetched, err := s.taskUsecase.FetchByTaskID(s.ctx, "task-id-123", ownerActor)
+	s.NoError(err)
+	s.Nil(fetched.Progress)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchSubtasks_NotVisible() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	_, err := s.taskUsecase.FetchSubtasks(s.ctx, "task-id-123", otherActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreateSubtask_AppendsToParent() {
+	s.mockRepo.ExpectedCalls = nil
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("FetchSubtasks", mock.Anything, "task-id-123").Return([]domain.Task{{ID: "sub-1"}}, nil)
+	s.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.ParentID == "task-id-123" && t.Position == 1 && t.ProjectID == sampleTask.ProjectID
+	})).Return(nil).Once()
+
+	subtask := domain.Task{Title: "step", DueDate: time.Now().Add(time.Hour), Status: "pending", OwnerID: "owner-id-123"}
+	err := s.taskUsecase.CreateSubtask(s.ctx, "task-id-123", &subtask, ownerActor)
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestCreateSubtask_ParentNotFound() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	subtask := domain.Task{Title: "step", DueDate: time.Now().Add(time.Hour), Status: "pending"}
+	err := s.taskUsecase.CreateSubtask(s.ctx, "task-id-123", &subtask, ownerActor)
+	s.ErrorIs(err, domain.ErrTaskNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreateSubtask_Viewer() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	subtask := domain.Task{Title: "step", DueDate: time.Now().Add(time.Hour), Status: "pending"}
+	err := s.taskUsecase.CreateSubtask(s.ctx, "task-id-123", &subtask, viewerActor)
+	s.ErrorIs(err, domain.ErrProjectReadOnly)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestReorderSubtasks() {
+	s.mockRepo.ExpectedCalls = nil
+	subtasks := []domain.Task{{ID: "sub-1"}, {ID: "sub-2", Position: 1}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("FetchSubtasks", mock.Anything, "task-id-123").Return(subtasks, nil)
+	s.mockRepo.On("UpdateSubtaskPositions", mock.Anything, "task-id-123", []string{"sub-2", "sub-1"}).Return(2, nil).Once()
+
+	ordered, err := s.taskUsecase.ReorderSubtasks(s.ctx, "task-id-123", []string{"sub-2", "sub-1"}, ownerActor)
+	s.Require().NoError(err)
+	s.Equal("sub-2", ordered[0].ID)
+	s.Equal(0, ordered[0].Position)
+	s.Equal(1, ordered[1].Position)
+
+	for _, ids := range [][]string{{"sub-1"}, {"sub-1", "sub-1"}, {"sub-1", "sub-3"}} {
+		_, err = s.taskUsecase.ReorderSubtasks(s.ctx, "task-id-123", ids, ownerActor)
+		s.ErrorIs(err, domain.ErrInvalidOrder)
+	}
+	s.mockRepo.AssertNumberOfCalls(s.T(), "UpdateSubtaskPositions", 1)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddChecklistItem() {
+	task := sampleTask
+	task.Version = 4
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+	s.mockRepo.On("UpdateChecklist", mock.Anything, "task-id-123", 4, mock.MatchedBy(func(items []domain.ChecklistItem) bool {
+		return len(items) == 1 && items[0].Text == "write tests" && items[0].ID != "" && !items[0].Done
+	})).Return(1, nil).Once()
+
+	item, err := s.taskUsecase.AddChecklistItem(s.ctx, "task-id-123", "  write tests ", adminActor)
+	s.NoError(err)
+	s.Equal("write tests", item.Text)
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return len(e.Changes) == 1 && e.Changes[0].Field == "checklist"
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestAddChecklistItem_EmptyText() {
+	_, err := s.taskUsecase.AddChecklistItem(s.ctx, "task-id-123", "  ", adminActor)
+	s.ErrorIs(err, domain.ErrEmptyChecklistItem)
+	s.mockRepo.AssertNotCalled(s.T(), "FetchByTaskID", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateChecklistItem_RetriesOnConcurrentChange() {
+	task := sampleTask
+	task.Version = 1
+	task.Checklist = []domain.ChecklistItem{{ID: "a", Text: "first"}, {ID: "b", Text: "second"}}
+	changed := task
+	changed.Version = 2
+	done := true
+
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil).Once()
+	s.mockRepo.On("UpdateChecklist", mock.Anything, "task-id-123", 1, mock.Anything).Return(0, nil).Once()
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(changed, nil).Once()
+	s.mockRepo.On("UpdateChecklist", mock.Anything, "task-id-123", 2, []domain.ChecklistItem{
+		{ID: "a", Text: "first"},
+		{ID: "b", Text: "second", Done: true},
+	}).Return(1, nil).Once()
+
+	item, err := s.taskUsecase.UpdateChecklistItem(s.ctx, "task-id-123", "b", domain.ChecklistItemPatch{Done: &done}, adminActor)
+	s.NoError(err)
+	s.True(item.Done)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateChecklistItem_GivesUpAfterConflicts() {
+	done := true
+	task := sampleTask
+	task.Checklist = []domain.ChecklistItem{{ID: "a"}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+	s.mockRepo.On("UpdateChecklist", mock.Anything, "task-id-123", mock.Anything, mock.Anything).Return(0, nil)
+
+	_, err := s.taskUsecase.UpdateChecklistItem(s.ctx, "task-id-123", "a", domain.ChecklistItemPatch{Done: &done}, adminActor)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+}
+
+func (s *TaskUsecaseTestSuite) TestRemoveChecklistItem_NotFound() {
+	task := sampleTask
+	task.Checklist = []domain.ChecklistItem{{ID: "a"}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+
+	err := s.taskUsecase.RemoveChecklistItem(s.ctx, "task-id-123", "missing", adminActor)
+	s.ErrorIs(err, domain.ErrChecklistItemNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateChecklist", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestReorderChecklist() {
+	task := sampleTask
+	task.Checklist = []domain.ChecklistItem{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	reordered := []domain.ChecklistItem{{ID: "c"}, {ID: "a"}, {ID: "b"}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+	s.mockRepo.On("UpdateChecklist", mock.Anything, "task-id-123", 0, reordered).Return(1, nil).Once()
+
+	items, err := s.taskUsecase.ReorderChecklist(s.ctx, "task-id-123", []string{"c", "a", "b"}, adminActor)
+	s.NoError(err)
+	s.Equal(reordered, items)
+
+	_, err = s.taskUsecase.ReorderChecklist(s.ctx, "task-id-123", []string{"c", "a"}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidOrder)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddDependency_Success() {
+	blocker := domain.Task{ID: "blocker", ProjectID: sampleTask.ProjectID, BlockedBy: []string{"other"}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "blocker").Return(blocker, nil)
+	s.mockRepo.On("FetchByTaskIDs", mock.Anything, []string{"other"}).Return([]domain.Task{{ID: "other"}}, nil)
+	s.mockRepo.On("AddBlocker", mock.Anything, "task-id-123", 0, "blocker").Return(1, nil)
+
+	err := s.taskUsecase.AddDependency(s.ctx, "task-id-123", "blocker", adminActor)
+	s.NoError(err)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddDependency_OtherProject() {
+	blocker := domain.Task{ID: "blocker", ProjectID: "project-id-456"}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "blocker").Return(blocker, nil)
+
+	err := s.taskUsecase.AddDependency(s.ctx, "task-id-123", "blocker", adminActor)
+	s.ErrorIs(err, domain.ErrProjectMismatch)
+	s.mockRepo.AssertNotCalled(s.T(), "AddBlocker", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddDependency_Self() {
+	err := s.taskUsecase.AddDependency(s.ctx, "task-id-123", "task-id-123", adminActor)
+	s.ErrorIs(err, domain.ErrDependencyCycle)
+	s.mockRepo.AssertNotCalled(s.T(), "AddBlocker", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddDependency_IndirectCycle() {
+	// task-id-123 blocks b, which blocks c; c cannot block task-id-123 in turn
+	blocker := domain.Task{ID: "c", ProjectID: sampleTask.ProjectID, BlockedBy: []string{"b"}}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "c").Return(blocker, nil)
+	s.mockRepo.On("FetchByTaskIDs", mock.Anything, []string{"b"}).Return([]domain.Task{{ID: "b", BlockedBy: []string{"task-id-123"}}}, nil)
+
+	err := s.taskUsecase.AddDependency(s.ctx, "task-id-123", "c", adminActor)
+	s.ErrorIs(err, domain.ErrDependencyCycle)
+	s.mockRepo.AssertNotCalled(s.T(), "AddBlocker", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddDependency_ConcurrentCycle() {
+	// c was made to depend on task-id-123 between the check and the write
+	blocker := domain.Task{ID: "c", ProjectID: sampleTask.ProjectID}
+	closed := blocker
+	closed.BlockedBy = []string{"task-id-123"}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "c").Return(blocker, nil).Once()
+	s.mockRepo.On("AddBlocker", mock.Anything, "task-id-123", 0, "c").Return(1, nil).Once()
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "c").Return(closed, nil).Once()
+	s.mockRepo.On("RemoveBlocker", mock.Anything, "task-id-123", 0, "c").Return(1, nil).Once()
+
+	err := s.taskUsecase.AddDependency(s.ctx, "task-id-123", "c", adminActor)
+	s.ErrorIs(err, domain.ErrDependencyCycle)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockHistory.AssertNotCalled(s.T(), "Append", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddDependency_AlreadyBlocked() {
+	task := sampleTask
+	task.BlockedBy = []string{"blocker"}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+
+	err := s.taskUsecase.AddDependency(s.ctx, "task-id-123", "blocker", adminActor)
+	s.ErrorIs(err, domain.ErrDependencyExists)
+}
+
+func (s *TaskUsecaseTestSuite) TestRemoveDependency_NotBlocked() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.RemoveDependency(s.ctx, "task-id-123", "blocker", adminActor)
+	s.ErrorIs(err, domain.ErrDependencyNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "RemoveBlocker", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_BlockedCompletion() {
+	task := sampleTask
+	task.BlockedBy = []string{"done", "open"}
+	open := domain.Task{ID: "open", Title: "Open blocker", Status: domain.StatusMissed}
+	completed := domain.StatusCompleted
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+	s.mockRepo.On("FetchByTaskIDs", mock.Anything, task.BlockedBy).
+		Return([]domain.Task{{ID: "done", Status: domain.StatusCompleted}, open}, nil)
+
+	_, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Status: &completed}, adminActor)
+	s.ErrorIs(err, domain.ErrTaskBlocked)
+
+	var blocked *domain.BlockedError
+	s.Require().ErrorAs(err, &blocked)
+	s.Equal([]domain.Task{open}, blocked.Blockers)
+	s.mockRepo.AssertNotCalled(s.T(), "PatchByTaskID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_CompletedBlockers() {
+	before := sampleTask
+	before.BlockedBy = []string{"done"}
+	task := sampleTask
+	task.Status = domain.StatusCompleted
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(before, nil)
+	s.mockRepo.On("FetchByTaskIDs", mock.Anything, []string{"done"}).
+		Return([]domain.Task{{ID: "done", Status: domain.StatusCompleted}}, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, &task, mock.Anything).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.NoError(err)
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_NormalizesTags() {
+	task := sampleTask
+	task.ID = ""
+	task.Tags = []string{" Work", "work", "Home"}
+	s.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.NoError(err)
+	s.Equal([]string{"work", "home"}, task.Tags)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_TagFilters() {
+	expected := domain.TaskQuery{Tags: []string{"work"}, AnyTags: []string{"home", "errands"}, Limit: domain.DefaultPageSize + 1}
+	s.mockRepo.On("FetchAllTasks", mock.Anything, expected).Return([]domain.Task{}, 0, nil)
+
+	_, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Tags: []string{"Work"}, AnyTags: []string{"home", "Errands"}}, adminActor)
+	s.NoError(err)
+
+	_, err = s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Tags: []string{" "}}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidQuery)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddTag_RecordsTags() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("AddTag", mock.Anything, "task-id-123", 0, "work").Return(1, nil)
+
+	err := s.taskUsecase.AddTag(s.ctx, "task-id-123", " Work ", adminActor)
+	s.Require().NoError(err)
+
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return len(e.Changes) == 1 && e.Changes[0].Field == "tags"
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestAddTag_AlreadyTagged() {
+	task := sampleTask
+	task.Tags = []string{"work"}
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(task, nil)
+
+	err := s.taskUsecase.AddTag(s.ctx, "task-id-123", "work", adminActor)
+	s.ErrorIs(err, domain.ErrTagExists)
+	s.mockRepo.AssertNotCalled(s.T(), "AddTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestAddTag_RetriesConcurrentChange() {
+	// another tag was added between the read and the write; the edit starts over from the new state
+	changed := sampleTask
+	changed.Tags = []string{"home"}
+	changed.Version = 1
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil).Once()
+	s.mockRepo.On("AddTag", mock.Anything, "task-id-123", 0, "work").Return(0, nil).Once()
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(changed, nil).Once()
+	s.mockRepo.On("AddTag", mock.Anything, "task-id-123", 1, "work").Return(1, nil).Once()
+
+	err := s.taskUsecase.AddTag(s.ctx, "task-id-123", "work", adminActor)
+	s.Require().NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+
+	s.mockHistory.AssertCalled(s.T(), "Append", mock.Anything, mock.MatchedBy(func(e *domain.TaskHistoryEntry) bool {
+		return len(e.Changes) == 1 &&
+			slices.Equal(e.Changes[0].Before.([]string), []string{"home"}) &&
+			slices.Equal(e.Changes[0].After.([]string), []string{"home", "work"})
+	}))
+}
+
+func (s *TaskUsecaseTestSuite) TestAddTag_GivesUpAfterRepeatedConflicts() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+	s.mockRepo.On("AddTag", mock.Anything, "task-id-123", 0, "work").Return(0, nil)
+
+	err := s.taskUsecase.AddTag(s.ctx, "task-id-123", "work", adminActor)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "AddTag", 3)
+}
+
+func (s *TaskUsecaseTestSuite) TestRemoveTag_NotTagged() {
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(sampleTask, nil)
+
+	err := s.taskUsecase.RemoveTag(s.ctx, "task-id-123", "work", adminActor)
+	s.ErrorIs(err, domain.ErrTagNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "RemoveTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchTags_OnlyVisibleTasks() {
+	counts := []domain.TagCount{{Tag: "work", Count: 2}}
+	s.mockRepo.On("CountTags", mock.Anything, []string{sampleProject.ID}).Return(counts, nil)
+	s.mockRepo.On("CountTags", mock.Anything, []string(nil)).Return([]domain.TagCount{}, nil)
+
+	tags, err := s.taskUsecase.FetchTags(s.ctx, ownerActor)
+	s.NoError(err)
+	s.Equal(counts, tags)
+
+	_, err = s.taskUsecase.FetchTags(s.ctx, adminActor)
+	s.NoError(err)
+	s.mockRepo.AssertCalled(s.T(), "CountTags", mock.Anything, []string(nil))
+}
+
+func (s *TaskUsecaseTestSuite) TestCreate_DefaultPriority() {
+	task := sampleTask
+	task.Priority = ""
+	s.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.NoError(err)
+	s.Equal(domain.DefaultPriority, task.Priority)
+
+	task.Priority = "asap"
+	err = s.taskUsecase.Create(s.ctx, &task, ownerActor)
+	s.ErrorIs(err, domain.ErrInvalidPriority)
+}
+
+func (s *TaskUsecaseTestSuite) TestUpdateByTaskID_KeepsPriority() {
+	before := sampleTask
+	before.Priority = domain.PriorityHigh
+	task := sampleTask
+	task.Title = "Renamed"
+	task.Priority = ""
+	s.mockRepo.On("FetchByTaskID", mock.Anything, "task-id-123").Return(before, nil)
+	s.mockRepo.On("UpdateByTaskID", mock.Anything, mock.MatchedBy(func(t *domain.Task) bool {
+		return t.Priority == domain.PriorityHigh
+	}), domain.StatusPending).Return(1, 1, nil)
+
+	err := s.taskUsecase.UpdateByTaskID(s.ctx, &task, adminActor)
+	s.NoError(err)
+}
+
+func (s *TaskUsecaseTestSuite) TestPatchByTaskID_InvalidPriority() {
+	priority := domain.TaskPriority("asap")
+	_, err := s.taskUsecase.PatchByTaskID(s.ctx, "task-id-123", domain.TaskPatch{Priority: &priority}, adminActor)
+	s.ErrorIs(err, domain.ErrInvalidPriority)
+	s.mockRepo.AssertNotCalled(s.T(), "FetchByTaskID", mock.Anything, mock.Anything)
+}
+
+func (s *TaskUsecaseTestSuite) TestFetchAllTasks_SortByUrgency() {
+	first := sampleTask
+	first.Priority = domain.PriorityCritical
+	second := sampleTask
+	second.ID = "task-id-456"
+	s.mockRepo.On("FetchAllTasks", mock.Anything, mock.MatchedBy(func(q domain.TaskQuery) bool {
+		return q.SortBy == domain.TaskSortByUrgency && q.After == nil
+	})).Return([]domain.Task{first, second}, 2, nil).Once()
+
+	page, err := s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Limit: 1, SortBy: domain.TaskSortByUrgency}, adminActor)
+	s.Require().NoError(err)
+	s.Require().Len(page.Tasks, 1)
+	// a critical task due within the hour is a week into its urgency
+	s.InDelta(7.0, page.Tasks[0].Urgency, 0.05)
+
+	// the cursor carries the urgency date of the last task
+	s.mockRepo.On("FetchAllTasks", mock.Anything, mock.MatchedBy(func(q domain.TaskQuery) bool {
+		return q.After != nil && q.After.SortValue == first.UrgentAt().UTC().Format(time.RFC3339Nano)
+	})).Return([]domain.Task{second}, 2, nil).Once()
+
+	_, err = s.taskUsecase.FetchAllTasks(s.ctx, domain.TaskQuery{Limit: 1, SortBy: domain.TaskSortByUrgency, Cursor: page.NextCursor}, adminActor)
+	s.NoError(err)
 }
 
 func TestTaskUsecaseTestSuite(t *testing.T) {
@@ -0,0 +1,21 @@
+package usecases_test
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransactor is a mock implementation of the Transactor interface
+// Unless an error is set up, the unit of work runs once on the given context
+type MockTransactor struct {
+	mock.Mock
+}
+
+func (m *MockTransactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(c)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(c)
+}
@@ -27,8 +27,8 @@ func (m *MockUserRepository) FetchByUsername(c context.Context, username string)
 	return args.Get(0).(domain.User), args.Error(1)
 }
 
-func (m *MockUserRepository) FetchAllUsers(c context.Context) ([]domain.User, error) {
-	args := m.Called(c)
+func (m *MockUserRepository) FetchAllUsers(c context.Context, query domain.UserQuery) ([]domain.User, error) {
+	args := m.Called(c, query)
 	return args.Get(0).([]domain.User), args.Error(1)
 }
 
@@ -22,7 +22,13 @@ type UserUsecaseTestSuite struct {
 
 func (s *UserUsecaseTestSuite) SetupTest() {
 	s.mockRepo = new(MockUserRepository)
-	s.userUsecase = usecases.NewUserUsecase(s.mockRepo, 2*time.Second)
+	s.userUsecase = usecases.NewUserUsecase(
+		s.mockRepo,
+		infrastructure.NewJWTService("testsecret123"),
+		infrastructure.NewPasswordService(),
+		infrastructure.NewCursorService("testsecret123"),
+		2*time.Second,
+	)
 	s.ctx = context.Background()
 }
 
@@ -35,7 +41,7 @@ var sampleUser = domain.User{
 
 func (s *UserUsecaseTestSuite) TestLogin_Success() {
 	user := sampleUser
-	hashed, _ := infrastructure.HashPassword("password")
+	hashed, _ := infrastructure.NewPasswordService().HashPassword("password")
 	user.Password = hashed
 
 	s.mockRepo.On("FetchByUsername", mock.Anything, "testuser").Return(user, nil)
@@ -114,12 +120,13 @@ func (s *UserUsecaseTestSuite) TestPromoteByUserID_NotFound() {
 }
 
 func (s *UserUsecaseTestSuite) TestFetchAllUsers() {
-	s.mockRepo.On("FetchAllUsers", mock.Anything).Return([]domain.User{sampleUser}, nil)
+	s.mockRepo.On("FetchAllUsers", mock.Anything, domain.UserQuery{Limit: domain.DefaultPageSize + 1}).Return([]domain.User{sampleUser}, nil)
 
-	users, err := s.userUsecase.FetchAllUsers(s.ctx)
+	page, err := s.userUsecase.FetchAllUsers(s.ctx, domain.UserQuery{})
 	s.NoError(err)
-	s.Len(users, 1)
-	s.Equal(sampleUser.ID, users[0].ID)
+	s.Len(page.Users, 1)
+	s.Equal(sampleUser.ID, page.Users[0].ID)
+	s.Empty(page.NextCursor)
 }
 
 func (s *UserUsecaseTestSuite) TestFetchByUsername() {
@@ -0,0 +1,39 @@
+package usecases_test
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookDeliveryRepository is a mock implementation of the WebhookDeliveryRepository interface
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveryRepository) CreateMany(c context.Context, deliveries []*domain.WebhookDelivery) error {
+	args := m.Called(c, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) FetchByID(c context.Context, deliveryID string) (domain.WebhookDelivery, error) {
+	args := m.Called(c, deliveryID)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) FetchByWebhookID(c context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(c, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) ClaimDue(c context.Context, now time.Time, lease time.Duration) (domain.WebhookDelivery, error) {
+	args := m.Called(c, now, lease)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) RecordAttempt(c context.Context, deliveryID string, attempt domain.WebhookAttempt, status domain.WebhookDeliveryStatus, nextAttemptAt time.Time) error {
+	args := m.Called(c, deliveryID, attempt, status, nextAttemptAt)
+	return args.Error(0)
+}
@@ -0,0 +1,43 @@
+package usecases_test
+
+import (
+	"context"
+
+	domain "github.com/A2SVTask7/Domain"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookRepository is a mock implementation of the WebhookRepository interface
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(c context.Context, webhook *domain.Webhook) error {
+	args := m.Called(c, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FetchByID(c context.Context, webhookID string) (domain.Webhook, error) {
+	args := m.Called(c, webhookID)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FetchAll(c context.Context) ([]domain.Webhook, error) {
+	args := m.Called(c)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FetchActiveByEvent(c context.Context, event string) ([]domain.Webhook, error) {
+	args := m.Called(c, event)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(c context.Context, webhook *domain.Webhook) (int, error) {
+	args := m.Called(c, webhook)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(c context.Context, webhookID string) (int, error) {
+	args := m.Called(c, webhookID)
+	return args.Int(0), args.Error(1)
+}
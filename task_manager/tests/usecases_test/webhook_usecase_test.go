@@ -0,0 +1,263 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/A2SVTask7/Domain"
+	usecases "github.com/A2SVTask7/Usecases"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookUsecaseTestSuite struct {
+	suite.Suite
+	mockWebhooks   *MockWebhookRepository
+	mockDeliveries *MockWebhookDeliveryRepository
+	webhookUsecase domain.WebhookUsecase
+	publisher      domain.TaskEventPublisher
+	ctx            context.Context
+}
+
+// sampleWebhook is subscribed to status changes
+var sampleWebhook = domain.Webhook{
+	ID:     "webhook-id-123",
+	URL:    "https://ci.example.com/hooks/tasks",
+	Events: []string{domain.WebhookEventTaskStatusChanged},
+	Secret: "webhook-secret",
+	Active: true,
+}
+
+func (s *WebhookUsecaseTestSuite) SetupTest() {
+	s.mockWebhooks = new(MockWebhookRepository)
+	s.mockDeliveries = new(MockWebhookDeliveryRepository)
+	s.mockWebhooks.On("FetchByID", mock.Anything, sampleWebhook.ID).Return(sampleWebhook, nil).Maybe()
+	s.mockWebhooks.On("FetchByID", mock.Anything, "missing-id").Return(domain.Webhook{}, domain.ErrWebhookNotFound).Maybe()
+	s.webhookUsecase = usecases.NewWebhookUsecase(s.mockWebhooks, s.mockDeliveries, time.Second*2)
+	s.publisher = usecases.NewTaskEventPublisher(s.mockWebhooks, s.mockDeliveries)
+	s.ctx = context.Background()
+}
+
+func (s *WebhookUsecaseTestSuite) TestCreate_GeneratesSecret() {
+	s.mockWebhooks.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+	webhook := domain.Webhook{
+		URL:    "http://chat.example.com/incoming",
+		Events: []string{" Task.Created", "task.created", "task.deleted"},
+		Active: true,
+	}
+	err := s.webhookUsecase.Create(s.ctx, &webhook, adminActor)
+	s.Require().NoError(err)
+	s.Equal([]string{domain.WebhookEventTaskCreated, domain.WebhookEventTaskDeleted}, webhook.Events)
+	s.Len(webhook.Secret, 64)
+	s.Equal(adminActor.ID, webhook.CreatedBy)
+	s.False(webhook.CreatedAt.IsZero())
+}
+
+func (s *WebhookUsecaseTestSuite) TestCreate_Invalid() {
+	tests := []struct {
+		name    string
+		webhook domain.Webhook
+		err     error
+	}{
+		{"relative url", domain.Webhook{URL: "/hooks", Events: []string{"task.created"}}, domain.ErrInvalidWebhookURL},
+		{"unsupported scheme", domain.Webhook{URL: "ftp://example.com/hooks", Events: []string{"task.created"}}, domain.ErrInvalidWebhookURL},
+		{"no events", domain.Webhook{URL: "https://example.com/hooks"}, domain.ErrInvalidWebhookEvent},
+		{"unknown event", domain.Webhook{URL: "https://example.com/hooks", Events: []string{"task.commented"}}, domain.ErrInvalidWebhookEvent},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.webhookUsecase.Create(s.ctx, &tt.webhook, adminActor)
+			s.ErrorIs(err, tt.err)
+		})
+	}
+	s.mockWebhooks.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *WebhookUsecaseTestSuite) TestFetch_HidesSecrets() {
+	s.mockWebhooks.On("FetchAll", mock.Anything).Return([]domain.Webhook{sampleWebhook}, nil).Once()
+
+	webhooks, err := s.webhookUsecase.FetchAll(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(webhooks, 1)
+	s.Empty(webhooks[0].Secret)
+
+	webhook, err := s.webhookUsecase.FetchByID(s.ctx, sampleWebhook.ID)
+	s.Require().NoError(err)
+	s.Empty(webhook.Secret)
+	s.Equal(sampleWebhook.URL, webhook.URL)
+}
+
+func (s *WebhookUsecaseTestSuite) TestUpdate_KeepsSecret() {
+	s.mockWebhooks.On("Update", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+		return w.Secret == sampleWebhook.Secret && !w.Active
+	})).Return(1, nil).Once()
+
+	webhook := domain.Webhook{ID: sampleWebhook.ID, URL: sampleWebhook.URL, Events: []string{"task.updated"}}
+	err := s.webhookUsecase.Update(s.ctx, &webhook)
+	s.Require().NoError(err)
+	s.Empty(webhook.Secret)
+	s.mockWebhooks.AssertExpectations(s.T())
+}
+
+func (s *WebhookUsecaseTestSuite) TestUpdate_NotFound() {
+	webhook := domain.Webhook{ID: "missing-id", URL: sampleWebhook.URL, Events: sampleWebhook.Events}
+	err := s.webhookUsecase.Update(s.ctx, &webhook)
+	s.ErrorIs(err, domain.ErrWebhookNotFound)
+	s.mockWebhooks.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *WebhookUsecaseTestSuite) TestDelete() {
+	s.mockWebhooks.On("Delete", mock.Anything, sampleWebhook.ID).Return(1, nil).Once()
+	s.NoError(s.webhookUsecase.Delete(s.ctx, sampleWebhook.ID))
+
+	s.mockWebhooks.On("Delete", mock.Anything, "missing-id").Return(0, nil).Once()
+	s.ErrorIs(s.webhookUsecase.Delete(s.ctx, "missing-id"), domain.ErrWebhookNotFound)
+}
+
+func (s *WebhookUsecaseTestSuite) TestFetchDeliveries() {
+	s.mockDeliveries.On("FetchByWebhookID", mock.Anything, sampleWebhook.ID, domain.DefaultPageSize).Return([]domain.WebhookDelivery{}, nil).Once()
+	_, err := s.webhookUsecase.FetchDeliveries(s.ctx, sampleWebhook.ID, 0)
+	s.NoError(err)
+
+	_, err = s.webhookUsecase.FetchDeliveries(s.ctx, sampleWebhook.ID, -1)
+	s.ErrorIs(err, domain.ErrInvalidQuery)
+
+	_, err = s.webhookUsecase.FetchDeliveries(s.ctx, "missing-id", 10)
+	s.ErrorIs(err, domain.ErrWebhookNotFound)
+	s.mockDeliveries.AssertExpectations(s.T())
+}
+
+func (s *WebhookUsecaseTestSuite) TestRedeliver() {
+	original := domain.WebhookDelivery{
+		ID:        "delivery-id-123",
+		WebhookID: sampleWebhook.ID,
+		EventID:   "event-id-123",
+		Event:     domain.WebhookEventTaskStatusChanged,
+		Payload:   json.RawMessage(`{"id":"event-id-123"}`),
+		Status:    domain.DeliveryFailed,
+		Attempts:  []domain.WebhookAttempt{{StatusCode: 500, Error: "webhook responded with status 500"}},
+	}
+	s.mockDeliveries.On("FetchByID", mock.Anything, original.ID).Return(original, nil)
+	s.mockDeliveries.On("CreateMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).([]*domain.WebhookDelivery)[0].ID = "delivery-id-456"
+	}).Return(nil).Once()
+
+	delivery, err := s.webhookUsecase.Redeliver(s.ctx, sampleWebhook.ID, original.ID)
+	s.Require().NoError(err)
+	s.Equal("delivery-id-456", delivery.ID)
+	s.Equal(original.ID, delivery.RedeliveryOf)
+	s.Equal(original.EventID, delivery.EventID)
+	s.Equal(original.Payload, delivery.Payload)
+	s.Equal(domain.DeliveryPending, delivery.Status)
+	s.Empty(delivery.Attempts)
+}
+
+func (s *WebhookUsecaseTestSuite) TestRedeliver_OtherWebhook() {
+	other := domain.WebhookDelivery{ID: "delivery-id-789", WebhookID: "webhook-id-456"}
+	s.mockDeliveries.On("FetchByID", mock.Anything, other.ID).Return(other, nil)
+
+	_, err := s.webhookUsecase.Redeliver(s.ctx, sampleWebhook.ID, other.ID)
+	s.ErrorIs(err, domain.ErrWebhookDeliveryNotFound)
+	s.mockDeliveries.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+}
+
+func (s *WebhookUsecaseTestSuite) TestPublish_QueuesDeliveryPerWebhook() {
+	second := sampleWebhook
+	second.ID = "webhook-id-456"
+	s.mockWebhooks.On("FetchActiveByEvent", mock.Anything, domain.WebhookEventTaskStatusChanged).Return([]domain.Webhook{sampleWebhook, second}, nil)
+	var queued []*domain.WebhookDelivery
+	s.mockDeliveries.On("CreateMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*domain.WebhookDelivery)
+	}).Return(nil).Once()
+
+	event := domain.TaskEvent{
+		Type:       domain.WebhookEventTaskStatusChanged,
+		Task:       sampleTask,
+		ActorID:    ownerActor.ID,
+		Changes:    []domain.FieldChange{{Field: "status", Before: "pending", After: "completed"}},
+		OccurredAt: time.Now(),
+	}
+	s.Require().NoError(s.publisher.Publish(s.ctx, event))
+
+	s.Require().Len(queued, 2)
+	s.Equal(sampleWebhook.ID, queued[0].WebhookID)
+	s.Equal(second.ID, queued[1].WebhookID)
+	s.Equal(queued[0].EventID, queued[1].EventID)
+	s.Equal(domain.DeliveryPending, queued[0].Status)
+
+	var payload struct {
+		ID      string `json:"id"`
+		Event   string `json:"event"`
+		ActorID string `json:"actor_id"`
+		Task    struct{ ID string }
+		Changes []struct{ Field string }
+	}
+	s.Require().NoError(json.Unmarshal(queued[0].Payload, &payload))
+	s.Equal(queued[0].EventID, payload.ID)
+	s.Equal(domain.WebhookEventTaskStatusChanged, payload.Event)
+	s.Equal(ownerActor.ID, payload.ActorID)
+	s.Equal(sampleTask.ID, payload.Task.ID)
+	s.Equal("status", payload.Changes[0].Field)
+}
+
+func (s *WebhookUsecaseTestSuite) TestPublish_NoSubscriber() {
+	s.mockWebhooks.On("FetchActiveByEvent", mock.Anything, domain.WebhookEventTaskCreated).Return([]domain.Webhook{}, nil)
+
+	err := s.publisher.Publish(s.ctx, domain.TaskEvent{Type: domain.WebhookEventTaskCreated, Task: sampleTask})
+	s.NoError(err)
+	s.mockDeliveries.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+}
+
+func (s *WebhookUsecaseTestSuite) TestPublish_LookupFails() {
+	dbErr := errors.New("db error")
+	s.mockWebhooks.On("FetchActiveByEvent", mock.Anything, domain.WebhookEventTaskDeleted).Return([]domain.Webhook{}, dbErr)
+
+	err := s.publisher.Publish(s.ctx, domain.TaskEvent{Type: domain.WebhookEventTaskDeleted, Task: sampleTask})
+	s.ErrorIs(err, dbErr)
+}
+
+func (s *WebhookUsecaseTestSuite) TestOverdueSweep_QueuesStatusChanged() {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	overdue := sampleTask
+	overdue.DueDate = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	tasks := new(MockTaskRepository)
+	expectOverdue(tasks, now, overdue)
+	tasks.On("MarkMissed", mock.Anything, overdue.ID, overdue.Version, now).Return(1, nil)
+	history := new(MockTaskHistoryRepository)
+	history.On("Append", mock.Anything, mock.Anything).Return(nil)
+
+	s.mockWebhooks.On("FetchActiveByEvent", mock.Anything, domain.WebhookEventTaskUpdated).Return([]domain.Webhook{}, nil)
+	s.mockWebhooks.On("FetchActiveByEvent", mock.Anything, domain.WebhookEventTaskStatusChanged).Return([]domain.Webhook{sampleWebhook}, nil)
+	var queued []*domain.WebhookDelivery
+	s.mockDeliveries.On("CreateMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*domain.WebhookDelivery)
+	}).Return(nil).Once()
+
+	taskUsecase := usecases.NewTaskUsecase(tasks, new(MockUserRepository), history, new(MockProjectRepository), s.publisher, new(MockAttachmentUsecase), new(MockCommentRepository), nil, trashRetention, time.Second*2)
+	_, err := taskUsecase.MarkOverdueMissed(s.ctx, now)
+	s.Require().NoError(err)
+
+	s.Require().Len(queued, 1)
+	s.Equal(sampleWebhook.ID, queued[0].WebhookID)
+	s.Equal(domain.WebhookEventTaskStatusChanged, queued[0].Event)
+
+	var payload struct {
+		ActorID string `json:"actor_id"`
+		Task    struct{ ID, Status string }
+		Changes []struct{ Field, Before, After string }
+	}
+	s.Require().NoError(json.Unmarshal(queued[0].Payload, &payload))
+	s.Equal(domain.SystemActor.ID, payload.ActorID)
+	s.Equal(overdue.ID, payload.Task.ID)
+	s.Equal(string(domain.StatusMissed), payload.Task.Status)
+	s.Equal([]struct{ Field, Before, After string }{{"status", "pending", "missed"}}, payload.Changes)
+}
+
+func TestWebhookUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUsecaseTestSuite))
+}